@@ -1,44 +1,32 @@
 package api
 
 import (
-	"database/sql"
-	"errors"
 	"net/http"
 
-	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
 	"github.com/NguyenMinhKhanhBK/simple_bank/token"
 	"github.com/gin-gonic/gin"
-	"github.com/lib/pq"
 )
 
 type createAccountParams struct {
-	Currency string `json:"currency" binding:"required,currency"`
+	Currency    string `json:"currency"`
+	AccountType string `json:"account_type"`
 }
 
 func (s *Server) createAccount(ctx *gin.Context) {
 	var req createAccountParams
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		badRequest(ctx, err)
 		return
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	arg := db.CreateAccountParams{
-		Owner:    authPayload.Username,
-		Balance:  0,
-		Currency: req.Currency,
-	}
-
-	account, err := s.store.CreateAccount(ctx, arg)
+	account, err := s.service.CreateAccount(ctx, authPayload.Username, service.CreateAccountParams{
+		Currency:    req.Currency,
+		AccountType: req.AccountType,
+	})
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "foreign_key_violation", "unique_violation":
-				ctx.JSON(http.StatusForbidden, errorResponse(err))
-				return
-			}
-		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		serviceError(ctx, err)
 		return
 	}
 
@@ -46,30 +34,20 @@ func (s *Server) createAccount(ctx *gin.Context) {
 }
 
 type getAccountRequest struct {
-	ID int64 `uri:"id" binding:"required,min=1"`
+	ID int64 `uri:"id"`
 }
 
 func (s *Server) getAccount(ctx *gin.Context) {
 	var req getAccountRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-
-	account, err := s.store.GetAccount(ctx, req.ID)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		badRequest(ctx, err)
 		return
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if account.Owner != authPayload.Username {
-		err := errors.New("account does not belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	account, err := s.service.GetAccount(ctx, authPayload.Username, req.ID)
+	if err != nil {
+		serviceError(ctx, err)
 		return
 	}
 
@@ -77,27 +55,24 @@ func (s *Server) getAccount(ctx *gin.Context) {
 }
 
 type listAccountRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	PageID   int32 `form:"page_id"`
+	PageSize int32 `form:"page_size"`
 }
 
 func (s *Server) listAccount(ctx *gin.Context) {
 	var req listAccountRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		badRequest(ctx, err)
 		return
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	arg := db.ListAccountsParams{
-		Owner:  authPayload.Username,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
-	}
-
-	accounts, err := s.store.ListAccounts(ctx, arg)
+	accounts, err := s.service.ListAccounts(ctx, authPayload.Username, service.Page{
+		ID:   req.PageID,
+		Size: req.PageSize,
+	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		serviceError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, accounts)
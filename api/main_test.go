@@ -8,7 +8,6 @@ import (
 	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
 	"github.com/NguyenMinhKhanhBK/simple_bank/util"
 	"github.com/gin-gonic/gin"
-	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
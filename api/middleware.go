@@ -3,10 +3,9 @@ package api
 import (
 	"errors"
 	"fmt"
-	"net/http"
 	"strings"
 
-	token "github.com/NguyenMinhKhanhBK/simple_bank/token"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
 	"github.com/gin-gonic/gin"
 )
 
@@ -16,19 +15,21 @@ const (
 	authorizationPayloadKey = "authorization_payload"
 )
 
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+// authMiddleware authenticates the requests, rejecting the tokens of blocked
+// users.
+func authMiddleware(svc *service.Service) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 		if len(authorizationHeader) == 0 {
 			err := errors.New("authorization header is not provided")
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			serviceError(ctx, &service.Error{Kind: service.ErrUnauthenticated, Err: err})
 			return
 		}
 
 		fields := strings.Fields(authorizationHeader)
 		if len(fields) < 2 {
 			err := errors.New("invalid authorization header format")
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			serviceError(ctx, &service.Error{Kind: service.ErrUnauthenticated, Err: err})
 			return
 		}
 
@@ -38,14 +39,14 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		case authorizationTypeBearer:
 		default:
 			err := fmt.Errorf("unsupported authorization type: %s", authorizationType)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			serviceError(ctx, &service.Error{Kind: service.ErrUnauthenticated, Err: err})
 			return
 		}
 
 		accessToken := fields[1]
-		payload, err := tokenMaker.VerifyToken(accessToken)
+		payload, err := svc.AuthenticateUser(ctx, accessToken)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			serviceError(ctx, err)
 			return
 		}
 
@@ -3,16 +3,17 @@ package api
 import (
 	"fmt"
 
+	"github.com/NguyenMinhKhanhBK/simple_bank/apierror"
 	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
 	token "github.com/NguyenMinhKhanhBK/simple_bank/token"
 	"github.com/NguyenMinhKhanhBK/simple_bank/util"
 	"github.com/gin-gonic/gin"
-	"github.com/gin-gonic/gin/binding"
-	"github.com/go-playground/validator/v10"
 )
 
 type Server struct {
 	store      db.Store
+	service    *service.Service
 	router     *gin.Engine
 	tokenMaker token.Maker
 	config     util.Config
@@ -24,20 +25,26 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
-	server := &Server{store: store, tokenMaker: tokenMaker, config: config}
-
-	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		v.RegisterValidation("currency", validCurrency)
+	server := &Server{
+		store:      store,
+		service:    service.New(config, store, tokenMaker),
+		tokenMaker: tokenMaker,
+		config:     config,
 	}
 
 	server.setupRouter()
 	return server, nil
 }
 
-func errorResponse(err error) gin.H {
-	return gin.H{
-		"error": err.Error(),
-	}
+// serviceError responds with the problem details of an error of the service.
+func serviceError(ctx *gin.Context, err error) {
+	apierror.WriteProblem(ctx.Writer, err)
+	ctx.Abort()
+}
+
+// badRequest responds to a request that cannot be bound.
+func badRequest(ctx *gin.Context, err error) {
+	serviceError(ctx, &service.Error{Kind: service.ErrInvalidArgument, Err: err})
 }
 
 func (s *Server) Start(address string) error {
@@ -51,7 +58,7 @@ func (s *Server) setupRouter() {
 	router.POST("/users/login", s.loginUser)
 	router.POST("/token/renew_access", s.renewAccessToken)
 
-	authRoutes := router.Group("/").Use(authMiddleware(s.tokenMaker))
+	authRoutes := router.Group("/").Use(authMiddleware(s.service))
 	authRoutes.POST("/accounts", s.createAccount)
 	authRoutes.GET("/accounts/:id", s.getAccount)
 	authRoutes.GET("/accounts", s.listAccount)
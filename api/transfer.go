@@ -1,78 +1,63 @@
 package api
 
 import (
-	"database/sql"
-	"errors"
-	"fmt"
 	"net/http"
 
 	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
 	"github.com/NguyenMinhKhanhBK/simple_bank/token"
 	"github.com/gin-gonic/gin"
 )
 
+// The recipient of a transfer is given by exactly one of to_account_id,
+// to_username or to_email. With a username or email, the account of the
+// recipient in the transfer currency is resolved server-side.
 type transferRequest struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"`
-	Currency      string `json:"currency" binding:"required,currency"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	ToUsername    string `json:"to_username"`
+	ToEmail       string `json:"to_email"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+}
+
+// recipientTransferResponse leaves out the account and entry of a recipient
+// that was addressed by username or email.
+type recipientTransferResponse struct {
+	Transfer    db.Transfer `json:"transfer"`
+	FromAccount db.Account  `json:"from_account"`
+	FromEntry   db.Entry    `json:"from_entry"`
 }
 
 func (s *Server) createTransfer(ctx *gin.Context) {
 	var req transferRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-
-	fromAccount, valid := s.validAccount(ctx, req.FromAccountID, req.Currency)
-	if !valid {
+		badRequest(ctx, err)
 		return
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if fromAccount.Owner != authPayload.Username {
-		err := errors.New("from account does not belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
-	if _, valid := s.validAccount(ctx, req.ToAccountID, req.Currency); !valid {
-		return
-	}
-
-	arg := db.TransferTxParams{
+	result, err := s.service.CreateTransfer(ctx, authPayload.Username, service.CreateTransferParams{
 		FromAccountID: req.FromAccountID,
 		ToAccountID:   req.ToAccountID,
+		ToUsername:    req.ToUsername,
+		ToEmail:       req.ToEmail,
 		Amount:        req.Amount,
-	}
-
-	result, err := s.store.TransferTx(ctx, arg)
+		Currency:      req.Currency,
+	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		serviceError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, result)
-}
-
-func (s *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
-	account, err := s.store.GetAccount(ctx, accountID)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return account, false
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return account, false
-	}
-
-	if account.Currency != currency {
-		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return account, false
+	if req.ToAccountID == 0 {
+		ctx.JSON(http.StatusOK, recipientTransferResponse{
+			Transfer:    result.Transfer,
+			FromAccount: result.FromAccount,
+			FromEntry:   result.FromEntry,
+		})
+		return
 	}
 
-	return account, true
+	ctx.JSON(http.StatusOK, result)
 }
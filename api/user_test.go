@@ -11,12 +11,12 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/NguyenMinhKhanhBK/simple_bank/apierror"
 	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
 	mock_sqlc "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc/mock"
 	"github.com/NguyenMinhKhanhBK/simple_bank/util"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
-	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -58,7 +58,7 @@ func TestCreateUser(t *testing.T) {
 					FullName: user.FullName,
 					Email:    user.Email,
 				}
-				store.EXPECT().CreateUser(gomock.Any(), EqCreateUserParams(arg, password)).Times(1).Return(user, nil)
+				store.EXPECT().CreateUserTx(gomock.Any(), EqCreateUserTxParams(arg, password)).Times(1).Return(db.CreateUserTxResult{User: user}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				assert.Equal(t, http.StatusOK, recorder.Code)
@@ -74,7 +74,7 @@ func TestCreateUser(t *testing.T) {
 				"email":     user.Email,
 			},
 			buildStubs: func(store *mock_sqlc.MockStore) {
-				store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, sql.ErrConnDone)
+				store.EXPECT().CreateUserTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CreateUserTxResult{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				assert.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -89,10 +89,11 @@ func TestCreateUser(t *testing.T) {
 				"email":     user.Email,
 			},
 			buildStubs: func(store *mock_sqlc.MockStore) {
-				store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, &pq.Error{Code: "23505"})
+				store.EXPECT().CreateUserTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CreateUserTxResult{}, &db.QueryError{Kind: db.ErrUniqueViolation, Constraint: "users_pkey"})
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				assert.Equal(t, http.StatusForbidden, recorder.Code)
+				assert.Equal(t, http.StatusConflict, recorder.Code)
+				assert.Equal(t, apierror.ContentType, recorder.Header().Get("Content-Type"))
 			},
 		},
 		{
@@ -163,16 +164,17 @@ func requireBodyMatchUser(t *testing.T, body *bytes.Buffer, user db.User) {
 	assert.Empty(t, gotUser.HashedPassword)
 }
 
-type eqCreateUserParamsMatcher struct {
+type eqCreateUserTxParamsMatcher struct {
 	arg      db.CreateUserParams
 	password string
 }
 
-func (e eqCreateUserParamsMatcher) Matches(x interface{}) bool {
-	arg, ok := x.(db.CreateUserParams)
+func (e eqCreateUserTxParamsMatcher) Matches(x interface{}) bool {
+	txArg, ok := x.(db.CreateUserTxParams)
 	if !ok {
 		return false
 	}
+	arg := txArg.CreateUserParams
 
 	err := util.CheckPassword(e.password, arg.HashedPassword)
 	if err != nil {
@@ -182,10 +184,10 @@ func (e eqCreateUserParamsMatcher) Matches(x interface{}) bool {
 	return reflect.DeepEqual(e.arg, arg)
 }
 
-func (e eqCreateUserParamsMatcher) String() string {
+func (e eqCreateUserTxParamsMatcher) String() string {
 	return fmt.Sprintf("matches arg %v and password %v", e.arg, e.password)
 }
 
-func EqCreateUserParams(arg db.CreateUserParams, password string) gomock.Matcher {
-	return eqCreateUserParamsMatcher{arg, password}
+func EqCreateUserTxParams(arg db.CreateUserParams, password string) gomock.Matcher {
+	return eqCreateUserTxParamsMatcher{arg, password}
 }
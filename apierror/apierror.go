@@ -0,0 +1,173 @@
+// Package apierror reports the errors of the service, and of the store below
+// it, the same way over gRPC and over HTTP.
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Domain is the domain of the ErrorInfo details.
+const Domain = "simplebank"
+
+// retryDelay is the delay suggested to the clients of a transaction that
+// failed to serialize.
+const retryDelay = 100 * time.Millisecond
+
+type mapping struct {
+	kind   error
+	code   codes.Code
+	status int
+	// reason is the ErrorInfo reason of the database errors
+	reason string
+}
+
+// mappings is the one table from the kinds of errors to gRPC codes and HTTP
+// statuses. The service errors come first, since they often wrap a database
+// error. A code always maps to the same HTTP status.
+var mappings = []mapping{
+	{kind: service.ErrInvalidArgument, code: codes.InvalidArgument, status: http.StatusBadRequest},
+	{kind: service.ErrNotFound, code: codes.NotFound, status: http.StatusNotFound},
+	{kind: service.ErrAlreadyExists, code: codes.AlreadyExists, status: http.StatusConflict},
+	{kind: service.ErrUnauthenticated, code: codes.Unauthenticated, status: http.StatusUnauthorized},
+	{kind: service.ErrPermissionDenied, code: codes.PermissionDenied, status: http.StatusForbidden},
+	{kind: service.ErrFailedPrecondition, code: codes.FailedPrecondition, status: http.StatusUnprocessableEntity},
+	{kind: service.ErrLimitExceeded, code: codes.ResourceExhausted, status: http.StatusUnprocessableEntity},
+
+	{kind: db.ErrNotFound, code: codes.NotFound, status: http.StatusNotFound, reason: "NOT_FOUND"},
+	{kind: db.ErrUniqueViolation, code: codes.AlreadyExists, status: http.StatusConflict, reason: "UNIQUE_VIOLATION"},
+	{kind: db.ErrForeignKeyViolation, code: codes.FailedPrecondition, status: http.StatusUnprocessableEntity, reason: "FOREIGN_KEY_VIOLATION"},
+	{kind: db.ErrCheckViolation, code: codes.FailedPrecondition, status: http.StatusUnprocessableEntity, reason: "CHECK_VIOLATION"},
+	{kind: db.ErrSerializationFailure, code: codes.Aborted, status: http.StatusConflict, reason: "SERIALIZATION_FAILURE"},
+	{kind: db.ErrAccountNotActive, code: codes.FailedPrecondition, status: http.StatusUnprocessableEntity},
+	{kind: db.ErrInvalidAccountStatusTransition, code: codes.FailedPrecondition, status: http.StatusUnprocessableEntity},
+	{kind: db.ErrAccountStatusChangeNotAllowed, code: codes.PermissionDenied, status: http.StatusForbidden},
+	{kind: db.ErrAccountBalanceNotZero, code: codes.FailedPrecondition, status: http.StatusUnprocessableEntity},
+	{kind: db.ErrInsufficientFunds, code: codes.FailedPrecondition, status: http.StatusUnprocessableEntity},
+	{kind: db.ErrHoldNotActive, code: codes.FailedPrecondition, status: http.StatusUnprocessableEntity},
+	{kind: db.ErrHoldExpired, code: codes.FailedPrecondition, status: http.StatusUnprocessableEntity},
+	{kind: db.ErrTransferIsReversal, code: codes.FailedPrecondition, status: http.StatusUnprocessableEntity},
+	{kind: db.ErrReversalExceedsTransfer, code: codes.FailedPrecondition, status: http.StatusUnprocessableEntity},
+}
+
+func lookup(err error) (mapping, bool) {
+	for _, m := range mappings {
+		if errors.Is(err, m.kind) {
+			return m, true
+		}
+	}
+	return mapping{}, false
+}
+
+// HTTPStatus returns the HTTP status of a gRPC code.
+func HTTPStatus(code codes.Code) int {
+	for _, m := range mappings {
+		if m.code == code {
+			return m.status
+		}
+	}
+
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// Status turns an error into a gRPC status. Status errors are kept as they
+// are, and the errors of unknown kinds are internal errors.
+func Status(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(validationErr.Violations))
+		for _, violation := range validationErr.Violations {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+		return InvalidArgument(violations)
+	}
+
+	var limitErr *db.TransferLimitError
+	if errors.As(err, &limitErr) {
+		return withDetails(status.New(codes.ResourceExhausted, limitErr.Error()), &errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{
+				{
+					Subject:     fmt.Sprintf("account:%d/%s", limitErr.AccountID, limitErr.Limit),
+					Description: limitErr.Error(),
+				},
+			},
+		})
+	}
+
+	m, ok := lookup(err)
+	if !ok {
+		return status.Newf(codes.Internal, "%s", err)
+	}
+
+	st := status.New(m.code, err.Error())
+	var queryErr *db.QueryError
+	if !errors.As(err, &queryErr) {
+		return st
+	}
+
+	if m.reason == "" {
+		// a service error wrapping a database error
+		m, _ = lookup(queryErr.Kind)
+	}
+	info := &errdetails.ErrorInfo{Reason: m.reason, Domain: Domain}
+	if queryErr.Constraint != "" {
+		info.Metadata = map[string]string{
+			"constraint": queryErr.Constraint,
+			"table":      queryErr.Table,
+		}
+	}
+	if errors.Is(err, db.ErrSerializationFailure) {
+		return withDetails(st, info, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)})
+	}
+	return withDetails(st, info)
+}
+
+// Error turns an error into a gRPC status error.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	return Status(err).Err()
+}
+
+// InvalidArgument is the status of a request with invalid fields.
+func InvalidArgument(violations []*errdetails.BadRequest_FieldViolation) *status.Status {
+	return withDetails(status.New(codes.InvalidArgument, "invalid argument"), &errdetails.BadRequest{FieldViolations: violations})
+}
+
+func withDetails(st *status.Status, details ...protoiface.MessageV1) *status.Status {
+	stDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return stDetails
+}
@@ -0,0 +1,104 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatus(t *testing.T) {
+	uniqueErr := &db.QueryError{Kind: db.ErrUniqueViolation, Constraint: "users_email_key", Table: "users"}
+
+	testCases := []struct {
+		name       string
+		err        error
+		code       codes.Code
+		httpStatus int
+	}{
+		{"NotFound", fmt.Errorf("get account: %w", &db.QueryError{Kind: db.ErrNotFound}), codes.NotFound, http.StatusNotFound},
+		{"UniqueViolation", uniqueErr, codes.AlreadyExists, http.StatusConflict},
+		{"ForeignKeyViolation", &db.QueryError{Kind: db.ErrForeignKeyViolation}, codes.FailedPrecondition, http.StatusUnprocessableEntity},
+		{"CheckViolation", &db.QueryError{Kind: db.ErrCheckViolation}, codes.FailedPrecondition, http.StatusUnprocessableEntity},
+		{"SerializationFailure", &db.QueryError{Kind: db.ErrSerializationFailure}, codes.Aborted, http.StatusConflict},
+		{"ServiceError", &service.Error{Kind: service.ErrAlreadyExists, Message: "username already exists", Err: uniqueErr}, codes.AlreadyExists, http.StatusConflict},
+		{"PermissionDenied", &service.Error{Kind: service.ErrPermissionDenied}, codes.PermissionDenied, http.StatusForbidden},
+		{"AccountNotActive", db.ErrAccountNotActive, codes.FailedPrecondition, http.StatusUnprocessableEntity},
+		{"TransferLimit", &db.TransferLimitError{AccountID: 1, Limit: "daily", Remaining: 5}, codes.ResourceExhausted, http.StatusUnprocessableEntity},
+		{"StatusError", status.Error(codes.Unavailable, "try later"), codes.Unavailable, http.StatusServiceUnavailable},
+		{"Unknown", errors.New("connection refused"), codes.Internal, http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := Status(tc.err)
+			require.Equal(t, tc.code, st.Code())
+			require.Equal(t, tc.httpStatus, HTTPStatus(st.Code()))
+			require.Equal(t, tc.httpStatus, NewProblem(tc.err).Status)
+		})
+	}
+}
+
+func TestStatusDetails(t *testing.T) {
+	err := &service.Error{
+		Kind:    service.ErrAlreadyExists,
+		Message: "email already exists",
+		Err:     &db.QueryError{Kind: db.ErrUniqueViolation, Constraint: "users_email_key", Table: "users"},
+	}
+
+	st := Status(err)
+	require.Equal(t, "email already exists", st.Message())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	require.Equal(t, "UNIQUE_VIOLATION", info.GetReason())
+	require.Equal(t, Domain, info.GetDomain())
+	require.Equal(t, "users_email_key", info.GetMetadata()["constraint"])
+
+	st = Status(&db.QueryError{Kind: db.ErrSerializationFailure})
+	require.Len(t, st.Details(), 2)
+	_, ok = st.Details()[1].(*errdetails.RetryInfo)
+	require.True(t, ok)
+
+	st = Status(&service.ValidationError{Violations: []service.FieldViolation{{Field: "email", Description: "is not a valid email address"}}})
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Equal(t, "email", badRequest.GetFieldViolations()[0].GetField())
+}
+
+func TestWriteProblem(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	WriteProblem(recorder, &service.ValidationError{Violations: []service.FieldViolation{{Field: "amount", Description: "must be positive"}}})
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Equal(t, ContentType, recorder.Header().Get("Content-Type"))
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+	require.Equal(t, Problem{
+		Type:          "about:blank",
+		Title:         "Bad Request",
+		Status:        http.StatusBadRequest,
+		Detail:        "invalid argument",
+		Code:          "InvalidArgument",
+		InvalidParams: []InvalidParam{{Name: "amount", Reason: "must be positive"}},
+	}, problem)
+
+	recorder = httptest.NewRecorder()
+	WriteProblem(recorder, &db.QueryError{Kind: db.ErrSerializationFailure})
+
+	require.Equal(t, http.StatusConflict, recorder.Code)
+	require.Equal(t, "1", recorder.Header().Get("Retry-After"))
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+	require.Equal(t, "SERIALIZATION_FAILURE", problem.Reason)
+}
@@ -0,0 +1,121 @@
+package apierror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// ContentType is the media type of the problem details.
+const ContentType = "application/problem+json"
+
+// Problem is the body of an HTTP error response, as described by RFC 7807.
+// Besides the standard members it carries the gRPC code and the details of
+// the status.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+
+	Code          string         `json:"code,omitempty"`
+	Reason        string         `json:"reason,omitempty"`
+	Constraint    string         `json:"constraint,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"`
+
+	// retryAfter is sent in the Retry-After header
+	retryAfter int
+}
+
+// InvalidParam is a field of the request that failed validation.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// NewProblem describes an error, see Status, as problem details.
+func NewProblem(err error) Problem {
+	return problemFromStatus(Status(err), 0)
+}
+
+// NewHTTPProblem describes an error of a plain HTTP handler, which has no
+// gRPC code.
+func NewHTTPProblem(httpStatus int, err error) Problem {
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(httpStatus),
+		Status: httpStatus,
+		Detail: err.Error(),
+	}
+}
+
+func problemFromStatus(st *status.Status, httpStatus int) Problem {
+	if httpStatus == 0 {
+		httpStatus = HTTPStatus(st.Code())
+	}
+
+	problem := Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(httpStatus),
+		Status: httpStatus,
+		Detail: st.Message(),
+		Code:   st.Code().String(),
+	}
+
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, violation := range d.GetFieldViolations() {
+				problem.InvalidParams = append(problem.InvalidParams, InvalidParam{
+					Name:   violation.GetField(),
+					Reason: violation.GetDescription(),
+				})
+			}
+		case *errdetails.ErrorInfo:
+			problem.Reason = d.GetReason()
+			problem.Constraint = d.GetMetadata()["constraint"]
+		case *errdetails.RetryInfo:
+			problem.retryAfter = int(math.Ceil(d.GetRetryDelay().AsDuration().Seconds()))
+		}
+	}
+
+	return problem
+}
+
+// Write writes the problem as the response.
+func (p Problem) Write(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", ContentType)
+	if p.retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(p.retryAfter))
+	}
+	w.WriteHeader(p.Status)
+	if err := json.NewEncoder(w).Encode(p); err != nil {
+		logrus.WithError(err).Error("cannot write problem details")
+	}
+}
+
+// WriteProblem writes an error as problem details.
+func WriteProblem(w http.ResponseWriter, err error) {
+	NewProblem(err).Write(w)
+}
+
+// GatewayErrorHandler is a runtime.ErrorHandlerFunc that writes the errors of
+// the gateway as problem details.
+func GatewayErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	var httpStatus int
+	var statusErr *runtime.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		httpStatus = statusErr.HTTPStatus
+		err = statusErr.Err
+	}
+
+	problemFromStatus(Status(err), httpStatus).Write(w)
+}
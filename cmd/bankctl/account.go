@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+)
+
+var accountHeader = []string{"ID", "TYPE", "CURRENCY", "BALANCE", "AVAILABLE", "STATUS", "CREATED"}
+
+func accountRow(account *pb.Account) []string {
+	return []string{
+		formatInt(account.GetId()),
+		account.GetAccountType(),
+		account.GetCurrency(),
+		formatInt(account.GetBalance()),
+		formatInt(account.GetAvailableBalance()),
+		account.GetStatus(),
+		formatTime(account.GetCreatedAt()),
+	}
+}
+
+func runAccountCreate(ctx context.Context, app *app, args []string) error {
+	flags := newFlagSet("account create", "")
+	currency := flags.String("currency", "", "currency of the account (required)")
+	accountType := flags.String("type", "", "type of the account, checking by default")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if *currency == "" {
+		flags.Usage()
+		return errUsage
+	}
+
+	ctx, client, err := app.authorizedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &pb.CreateAccountRequest{
+		Currency: *currency,
+	}
+	if *accountType != "" {
+		req.AccountType = accountType
+	}
+
+	rsp, err := client.CreateAccount(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return app.printer.print(rsp.GetAccount(), accountHeader, [][]string{accountRow(rsp.GetAccount())})
+}
+
+func runAccountList(ctx context.Context, app *app, args []string) error {
+	flags := newFlagSet("account list", "")
+	pageID := flags.Int("page", 1, "page to show")
+	pageSize := flags.Int("page-size", 10, "accounts per page, between 5 and 10")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	ctx, client, err := app.authorizedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := client.ListAccounts(ctx, &pb.ListAccountsRequest{
+		PageId:   int32(*pageID),
+		PageSize: int32(*pageSize),
+	})
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, account := range rsp.GetAccounts() {
+		rows = append(rows, accountRow(account))
+	}
+	return app.printer.print(rsp, accountHeader, rows)
+}
+
+func runAccountShow(ctx context.Context, app *app, args []string) error {
+	flags := newFlagSet("account show", "<id>")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errUsage
+	}
+	id, err := strconv.ParseInt(flags.Arg(0), 10, 64)
+	if err != nil {
+		flags.Usage()
+		return errUsage
+	}
+
+	ctx, client, err := app.authorizedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := client.GetAccount(ctx, &pb.GetAccountRequest{Id: id})
+	if err != nil {
+		return err
+	}
+
+	return app.printer.print(rsp.GetAccount(), accountHeader, [][]string{accountRow(rsp.GetAccount())})
+}
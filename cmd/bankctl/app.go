@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+type Config struct {
+	Address         string
+	GatewayURL      string
+	CredentialsPath string
+	Output          string
+}
+
+func defaultConfig() Config {
+	config := Config{
+		Address:    "localhost:9090",
+		GatewayURL: "http://localhost:8080",
+		Output:     outputTable,
+	}
+	if addr := os.Getenv("BANKCTL_ADDR"); addr != "" {
+		config.Address = addr
+	}
+	if gateway := os.Getenv("BANKCTL_GATEWAY"); gateway != "" {
+		config.GatewayURL = gateway
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		config.CredentialsPath = filepath.Join(dir, "bankctl", "credentials.json")
+	}
+	return config
+}
+
+// app holds what the commands share: the connection to the server, the
+// stored credentials and the output.
+type app struct {
+	config  Config
+	out     io.Writer
+	printer printer
+	conn    *grpc.ClientConn
+}
+
+func newApp(config Config, out io.Writer) *app {
+	return &app{
+		config:  config,
+		out:     out,
+		printer: newPrinter(config.Output, out),
+	}
+}
+
+// client connects to the server on first use.
+func (a *app) client() (pb.SimpleBankClient, error) {
+	if a.conn == nil {
+		conn, err := grpc.Dial(a.config.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot connect to %s: %w", a.config.Address, err)
+		}
+		a.conn = conn
+	}
+	return pb.NewSimpleBankClient(a.conn), nil
+}
+
+// credentials returns the stored credentials when their access token can
+// still be used.
+func (a *app) credentials() (credentials, error) {
+	creds, err := loadCredentials(a.config.CredentialsPath)
+	if err != nil {
+		return creds, err
+	}
+
+	if time.Now().After(creds.AccessTokenExpiresAt) {
+		return creds, fmt.Errorf("access token of %s expired, run bankctl login again", creds.Username)
+	}
+	return creds, nil
+}
+
+// authorizedClient returns the client with a context that carries the stored
+// access token.
+func (a *app) authorizedClient(ctx context.Context) (context.Context, pb.SimpleBankClient, error) {
+	creds, err := a.credentials()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+creds.AccessToken)
+	return ctx, client, nil
+}
+
+func (a *app) Close() error {
+	if a.conn == nil {
+		return nil
+	}
+	return a.conn.Close()
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// credentials are what login stores for the next commands.
+type credentials struct {
+	Username              string    `json:"username"`
+	SessionID             string    `json:"session_id"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+func loadCredentials(path string) (credentials, error) {
+	var creds credentials
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return creds, fmt.Errorf("not logged in, run bankctl login first")
+		}
+		return creds, fmt.Errorf("cannot read credentials: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return creds, fmt.Errorf("cannot parse credentials in %s: %w", path, err)
+	}
+	return creds, nil
+}
+
+// saveCredentials writes the credentials so that only the current user can
+// read them.
+func saveCredentials(path string, creds credentials) error {
+	if path == "" {
+		return fmt.Errorf("no credentials file, set one with -credentials")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("cannot create credentials directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("cannot write credentials: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/statement"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	dateLayout = "2006-01-02"
+
+	exportPollInterval = 2 * time.Second
+)
+
+var exportHeader = []string{"ID", "ACCOUNT", "FORMAT", "FROM", "TO", "STATUS", "DOWNLOAD"}
+
+func exportRow(export *pb.StatementExport) []string {
+	status := export.GetStatus()
+	if export.GetError() != "" {
+		status += ": " + export.GetError()
+	}
+
+	return []string{
+		formatInt(export.GetId()),
+		formatInt(export.GetAccountId()),
+		export.GetFormat(),
+		export.GetPeriodStart().AsTime().Format(dateLayout),
+		// the period end is exclusive, the last day is shown
+		export.GetPeriodEnd().AsTime().AddDate(0, 0, -1).Format(dateLayout),
+		status,
+		export.GetDownloadUrl(),
+	}
+}
+
+// runHistoryExport exports the statement of an account for a period. It waits
+// for the export to complete and downloads it when an output file is given.
+func runHistoryExport(ctx context.Context, app *app, args []string) error {
+	flags := newFlagSet("history export", "")
+	accountID := flags.Int64("account", 0, "id of the account (required)")
+	format := flags.String("format", "csv", "csv or pdf")
+	from := flags.String("from", "", "first day of the period as YYYY-MM-DD (required)")
+	to := flags.String("to", "", "last day of the period as YYYY-MM-DD (required)")
+	wait := flags.Bool("wait", true, "wait for the export to complete")
+	out := flags.String("out", "", "file to download the completed export to, - for stdout")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	periodStart, err := time.Parse(dateLayout, *from)
+	if err != nil {
+		flags.Usage()
+		return errUsage
+	}
+	lastDay, err := time.Parse(dateLayout, *to)
+	if err != nil || *accountID == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	ctx, client, err := app.authorizedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := client.CreateStatementExport(ctx, &pb.CreateStatementExportRequest{
+		AccountId:   *accountID,
+		Format:      *format,
+		PeriodStart: timestamppb.New(periodStart),
+		PeriodEnd:   timestamppb.New(lastDay.AddDate(0, 0, 1)),
+	})
+	if err != nil {
+		return err
+	}
+	export := rsp.GetStatementExport()
+
+	if *wait || *out != "" {
+		export, err = waitForExport(ctx, client, export)
+		if err != nil {
+			return err
+		}
+	}
+
+	if *out != "" {
+		if export.GetStatus() != statement.ExportCompleted {
+			return fmt.Errorf("export %d %s: %s", export.GetId(), export.GetStatus(), export.GetError())
+		}
+		return app.download(ctx, export.GetDownloadUrl(), *out)
+	}
+
+	return app.printer.print(export, exportHeader, [][]string{exportRow(export)})
+}
+
+func waitForExport(ctx context.Context, client pb.SimpleBankClient, export *pb.StatementExport) (*pb.StatementExport, error) {
+	ticker := time.NewTicker(exportPollInterval)
+	defer ticker.Stop()
+
+	for export.GetStatus() == statement.ExportPending {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		rsp, err := client.GetStatementExport(ctx, &pb.GetStatementExportRequest{Id: export.GetId()})
+		if err != nil {
+			return nil, err
+		}
+		export = rsp.GetStatementExport()
+	}
+
+	return export, nil
+}
+
+// download fetches a path of the gateway into a file, or stdout for "-".
+func (a *app) download(ctx context.Context, path string, out string) error {
+	creds, err := a.credentials()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(a.config.GatewayURL, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+creds.AccessToken)
+
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(rsp.Body, 1024))
+		return fmt.Errorf("download failed with %s: %s", rsp.Status, strings.TrimSpace(string(body)))
+	}
+
+	if out == "-" {
+		_, err = io.Copy(a.out, rsp.Body)
+		return err
+	}
+
+	file, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, rsp.Body); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
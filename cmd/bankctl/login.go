@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+)
+
+func runLogin(ctx context.Context, app *app, args []string) error {
+	flags := newFlagSet("login", "")
+	username := flags.String("username", "", "username (required)")
+	password := flags.String("password", "", "password, read from stdin when not set (BANKCTL_PASSWORD)")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if *username == "" {
+		flags.Usage()
+		return errUsage
+	}
+
+	if *password == "" {
+		*password = os.Getenv("BANKCTL_PASSWORD")
+	}
+	if *password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("cannot read password: %w", err)
+		}
+		*password = strings.TrimRight(line, "\r\n")
+	}
+
+	client, err := app.client()
+	if err != nil {
+		return err
+	}
+
+	rsp, err := client.LoginUser(ctx, &pb.LoginUserRequest{
+		Username: *username,
+		Password: *password,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = saveCredentials(app.config.CredentialsPath, credentials{
+		Username:              rsp.GetUser().GetUsername(),
+		SessionID:             rsp.GetSessionId(),
+		AccessToken:           rsp.GetAccessToken(),
+		AccessTokenExpiresAt:  rsp.GetAccessTokenExpiresAt().AsTime(),
+		RefreshToken:          rsp.GetRefreshToken(),
+		RefreshTokenExpiresAt: rsp.GetRefreshTokenExpiresAt().AsTime(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// the tokens are secrets and only go to the credentials file
+	return app.printer.print(rsp.GetUser(), userHeader, [][]string{userRow(rsp.GetUser())})
+}
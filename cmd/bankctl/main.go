@@ -0,0 +1,130 @@
+// Command bankctl operates the bank through its gRPC API.
+//
+// Usage:
+//
+//	bankctl [global flags] <command> [<subcommand>] [flags]
+//
+// Run bankctl -h for the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+)
+
+const usage = `Usage: bankctl [global flags] <command> [<subcommand>] [flags]
+
+Commands:
+  login                      log in and store the tokens locally
+  account create             open an account
+  account list               list your accounts
+  account show <id>          show an account
+  transfer                   transfer money
+  history export             export the statement of an account
+  session list               list your login sessions
+  session revoke <id>        revoke a login session
+  user update                update your profile
+
+Global flags:
+`
+
+// errUsage is returned for a malformed command line, after its usage was
+// printed.
+var errUsage = errors.New("invalid usage")
+
+type command func(ctx context.Context, app *app, args []string) error
+
+var commands = map[string]map[string]command{
+	"login":    {"": runLogin},
+	"account":  {"create": runAccountCreate, "list": runAccountList, "show": runAccountShow},
+	"transfer": {"": runTransfer},
+	"history":  {"export": runHistoryExport},
+	"session":  {"list": runSessionList, "revoke": runSessionRevoke},
+	"user":     {"update": runUserUpdate},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintln(os.Stderr, "bankctl:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("bankctl", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+
+	config := defaultConfig()
+	flags.StringVar(&config.Address, "addr", config.Address, "address of the gRPC server (BANKCTL_ADDR)")
+	flags.StringVar(&config.GatewayURL, "gateway", config.GatewayURL, "base URL of the HTTP gateway, for downloads (BANKCTL_GATEWAY)")
+	flags.StringVar(&config.CredentialsPath, "credentials", config.CredentialsPath, "file the tokens are stored in")
+	flags.StringVar(&config.Output, "o", config.Output, "output format: table or json")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+
+	if config.Output != outputTable && config.Output != outputJSON {
+		return fmt.Errorf("unsupported output format %q", config.Output)
+	}
+
+	args = flags.Args()
+	if len(args) == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	subcommands, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q, see bankctl -h", args[0])
+	}
+
+	name, args := args[0], args[1:]
+	cmd, ok := subcommands[""]
+	if !ok {
+		if len(args) == 0 {
+			return fmt.Errorf("%s needs a subcommand, see bankctl -h", name)
+		}
+		cmd, ok = subcommands[args[0]]
+		if !ok {
+			return fmt.Errorf("unknown command %q, see bankctl -h", name+" "+args[0])
+		}
+		args = args[1:]
+	}
+
+	app := newApp(config, os.Stdout)
+	defer app.Close()
+
+	return cmd(ctx, app, args)
+}
+
+// newFlagSet returns the flags of a command, which print their own usage.
+func newFlagSet(name string, argsUsage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: bankctl %s [flags] %s\n\nFlags:\n", name, argsUsage)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakeServer struct {
+	pb.UnimplementedSimpleBankServer
+	authorization string
+}
+
+func (s *fakeServer) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
+	return &pb.LoginUserResponse{
+		User:                 &pb.User{Username: req.GetUsername(), FullName: "Alice"},
+		SessionId:            "session",
+		AccessToken:          "access-token",
+		AccessTokenExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+		RefreshToken:         "refresh-token",
+	}, nil
+}
+
+func (s *fakeServer) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.authorization = md.Get("authorization")[0]
+
+	return &pb.ListAccountsResponse{
+		Accounts: []*pb.Account{
+			{Id: 1, Currency: "USD", AccountType: "checking", Balance: 100, AvailableBalance: 80, Status: "active"},
+		},
+	}, nil
+}
+
+func newTestApp(t *testing.T, output string) (*app, *fakeServer, *bytes.Buffer) {
+	listener := bufconn.Listen(1 << 20)
+	server := &fakeServer{}
+	grpcServer := grpc.NewServer()
+	pb.RegisterSimpleBankServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	app := newApp(Config{
+		CredentialsPath: filepath.Join(t.TempDir(), "credentials.json"),
+		Output:          output,
+	}, out)
+	app.conn = conn
+	t.Cleanup(func() { app.Close() })
+
+	return app, server, out
+}
+
+func TestLoginStoresCredentials(t *testing.T) {
+	app, server, out := newTestApp(t, outputTable)
+
+	err := runLogin(context.Background(), app, []string{"-username", "alice", "-password", "secret"})
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "alice")
+	require.NotContains(t, out.String(), "access-token")
+
+	creds, err := loadCredentials(app.config.CredentialsPath)
+	require.NoError(t, err)
+	require.Equal(t, "alice", creds.Username)
+	require.Equal(t, "access-token", creds.AccessToken)
+
+	out.Reset()
+	err = runAccountList(context.Background(), app, nil)
+	require.NoError(t, err)
+	require.Equal(t, "Bearer access-token", server.authorization)
+	require.Contains(t, out.String(), "ID  TYPE      CURRENCY  BALANCE  AVAILABLE  STATUS")
+	require.Contains(t, out.String(), "1   checking  USD       100      80         active")
+}
+
+func TestAccountListJSON(t *testing.T) {
+	app, _, out := newTestApp(t, outputJSON)
+
+	require.NoError(t, saveCredentials(app.config.CredentialsPath, credentials{
+		Username:             "alice",
+		AccessToken:          "access-token",
+		AccessTokenExpiresAt: time.Now().Add(time.Hour),
+	}))
+
+	err := runAccountList(context.Background(), app, nil)
+	require.NoError(t, err)
+
+	var rsp struct {
+		Accounts []struct {
+			ID       string `json:"id"`
+			Currency string `json:"currency"`
+		} `json:"accounts"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &rsp))
+	require.Len(t, rsp.Accounts, 1)
+	require.Equal(t, "1", rsp.Accounts[0].ID)
+	require.Equal(t, "USD", rsp.Accounts[0].Currency)
+}
+
+func TestExpiredCredentials(t *testing.T) {
+	app, _, _ := newTestApp(t, outputTable)
+
+	require.NoError(t, saveCredentials(app.config.CredentialsPath, credentials{
+		Username:             "alice",
+		AccessTokenExpiresAt: time.Now().Add(-time.Minute),
+	}))
+
+	err := runAccountList(context.Background(), app, nil)
+	require.ErrorContains(t, err, "run bankctl login again")
+}
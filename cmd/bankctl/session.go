@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+)
+
+var sessionHeader = []string{"ID", "USER AGENT", "CLIENT IP", "BLOCKED", "EXPIRES", "CREATED"}
+
+func sessionRow(session *pb.Session) []string {
+	return []string{
+		session.GetId(),
+		session.GetUserAgent(),
+		session.GetClientIp(),
+		formatBool(session.GetIsBlocked()),
+		formatTime(session.GetExpiresAt()),
+		formatTime(session.GetCreatedAt()),
+	}
+}
+
+func runSessionList(ctx context.Context, app *app, args []string) error {
+	flags := newFlagSet("session list", "")
+	pageID := flags.Int("page", 1, "page to show")
+	pageSize := flags.Int("page-size", 10, "sessions per page, between 5 and 10")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	ctx, client, err := app.authorizedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := client.ListSessions(ctx, &pb.ListSessionsRequest{
+		PageId:   int32(*pageID),
+		PageSize: int32(*pageSize),
+	})
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, session := range rsp.GetSessions() {
+		rows = append(rows, sessionRow(session))
+	}
+	return app.printer.print(rsp, sessionHeader, rows)
+}
+
+func runSessionRevoke(ctx context.Context, app *app, args []string) error {
+	flags := newFlagSet("session revoke", "<id>")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errUsage
+	}
+
+	ctx, client, err := app.authorizedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := client.RevokeSession(ctx, &pb.RevokeSessionRequest{
+		SessionId: flags.Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	return app.printer.print(rsp.GetSession(), sessionHeader, [][]string{sessionRow(rsp.GetSession())})
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var transferHeader = []string{"ID", "FROM", "TO", "AMOUNT", "DESCRIPTION", "REFERENCE", "CREATED"}
+
+func transferRow(transfer *pb.Transfer) []string {
+	return []string{
+		formatInt(transfer.GetId()),
+		formatInt(transfer.GetFromAccountId()),
+		formatInt(transfer.GetToAccountId()),
+		formatInt(transfer.GetAmount()),
+		transfer.GetDescription(),
+		transfer.GetExternalReference(),
+		formatTime(transfer.GetCreatedAt()),
+	}
+}
+
+func runTransfer(ctx context.Context, app *app, args []string) error {
+	flags := newFlagSet("transfer", "")
+	from := flags.Int64("from", 0, "id of your account to transfer from (required)")
+	toAccount := flags.Int64("to", 0, "id of the account to transfer to")
+	toUsername := flags.String("to-user", "", "username of the recipient, instead of -to")
+	toEmail := flags.String("to-email", "", "email of the recipient, instead of -to")
+	payee := flags.Int64("payee", 0, "id of a saved payee, instead of -to")
+	amount := flags.Int64("amount", 0, "amount to transfer (required)")
+	currency := flags.String("currency", "", "currency of the transfer (required)")
+	description := flags.String("description", "", "description of the transfer")
+	reference := flags.String("reference", "", "your reference of the transfer, unique per source account")
+	metadata := flags.String("metadata", "", "JSON object stored with the transfer")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	req := &pb.CreateTransferRequest{
+		FromAccountId: *from,
+		Amount:        *amount,
+		Currency:      *currency,
+	}
+
+	recipients := 0
+	if *toAccount != 0 {
+		req.Recipient = &pb.CreateTransferRequest_ToAccountId{ToAccountId: *toAccount}
+		recipients++
+	}
+	if *toUsername != "" {
+		req.Recipient = &pb.CreateTransferRequest_ToUsername{ToUsername: *toUsername}
+		recipients++
+	}
+	if *toEmail != "" {
+		req.Recipient = &pb.CreateTransferRequest_ToEmail{ToEmail: *toEmail}
+		recipients++
+	}
+	if *payee != 0 {
+		req.Recipient = &pb.CreateTransferRequest_PayeeId{PayeeId: *payee}
+		recipients++
+	}
+
+	if *from == 0 || *amount == 0 || *currency == "" || recipients != 1 {
+		flags.Usage()
+		return errUsage
+	}
+
+	if *description != "" {
+		req.Description = description
+	}
+	if *reference != "" {
+		req.ExternalReference = reference
+	}
+	if *metadata != "" {
+		req.Metadata = &structpb.Struct{}
+		if err := protojson.Unmarshal([]byte(*metadata), req.Metadata); err != nil {
+			return err
+		}
+	}
+
+	ctx, client, err := app.authorizedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := client.CreateTransfer(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return app.printer.print(rsp, transferHeader, [][]string{transferRow(rsp.GetTransfer())})
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+)
+
+var userHeader = []string{"USERNAME", "FULL NAME", "EMAIL", "PASSWORD CHANGED", "CREATED"}
+
+func userRow(user *pb.User) []string {
+	return []string{
+		user.GetUsername(),
+		user.GetFullName(),
+		user.GetEmail(),
+		formatTime(user.GetPasswordChangedAt()),
+		formatTime(user.GetCreatedAt()),
+	}
+}
+
+func runUserUpdate(ctx context.Context, app *app, args []string) error {
+	flags := newFlagSet("user update", "")
+	fullName := flags.String("full-name", "", "new full name")
+	email := flags.String("email", "", "new email")
+	password := flags.String("password", "", "new password")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	creds, err := loadCredentials(app.config.CredentialsPath)
+	if err != nil {
+		return err
+	}
+
+	req := &pb.UpdateUserRequest{
+		Username: creds.Username,
+	}
+	if *fullName != "" {
+		req.FullName = fullName
+	}
+	if *email != "" {
+		req.Email = email
+	}
+	if *password != "" {
+		req.Password = password
+	}
+
+	if req.FullName == nil && req.Email == nil && req.Password == nil {
+		flags.Usage()
+		return errUsage
+	}
+
+	ctx, client, err := app.authorizedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := client.UpdateUser(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return app.printer.print(rsp.GetUser(), userHeader, [][]string{userRow(rsp.GetUser())})
+}
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS "interest_postings";
+DROP TABLE IF EXISTS "interest_accruals";
+
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "accounts_account_type_fkey";
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "accrued_interest";
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "account_type";
+
+DROP TABLE IF EXISTS "account_types";
+
+-- the $system user and its accounts are kept since ledger entries reference them
@@ -0,0 +1,63 @@
+CREATE TABLE "account_types" (
+  "name" varchar PRIMARY KEY,
+  "annual_interest_rate_bps" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+INSERT INTO "account_types" ("name", "annual_interest_rate_bps") VALUES
+  ('checking', 0),
+  ('savings', 200);
+
+ALTER TABLE "accounts" ADD COLUMN "account_type" varchar NOT NULL DEFAULT 'checking';
+ALTER TABLE "accounts" ADD COLUMN "accrued_interest" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("account_type") REFERENCES "account_types" ("name");
+
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_interest_rate_bps" integer NOT NULL,
+  "amount" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "interest_postings" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "period" date NOT NULL,
+  "amount" bigint NOT NULL,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE UNIQUE INDEX ON "interest_accruals" ("account_id", "accrual_date");
+
+CREATE UNIQUE INDEX ON "interest_postings" ("account_id", "period");
+
+COMMENT ON COLUMN "account_types"."annual_interest_rate_bps" IS 'annual interest rate in basis points';
+
+COMMENT ON COLUMN "accounts"."accrued_interest" IS 'unposted interest in millionths of the minor currency unit';
+
+COMMENT ON COLUMN "interest_accruals"."amount" IS 'in millionths of the minor currency unit';
+
+COMMENT ON COLUMN "interest_postings"."period" IS 'first day of the month the interest was posted for';
+
+-- the system user owns the accounts that fund interest payments, its name is
+-- not a valid username so it can never be registered or logged in
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email") VALUES
+  ('$system', '', 'Simple Bank', 'system@simplebank.internal')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO "accounts" ("owner", "balance", "currency") VALUES
+  ('$system', 0, 'USD'),
+  ('$system', 0, 'EUR'),
+  ('$system', 0, 'CAD')
+ON CONFLICT DO NOTHING;
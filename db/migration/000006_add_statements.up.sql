@@ -0,0 +1,41 @@
+ALTER TABLE "entries" ADD COLUMN "transfer_id" bigint;
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "entries" ("account_id", "created_at");
+
+-- a transfer and its two entries are created in one transaction and so share
+-- the same created_at, which links the entries that existed before the column
+UPDATE "entries" e
+SET "transfer_id" = t."id"
+FROM "transfers" t
+WHERE t."created_at" = e."created_at"
+  AND (
+    (t."from_account_id" = e."account_id" AND e."amount" = -t."amount") OR
+    (t."to_account_id" = e."account_id" AND e."amount" = t."amount")
+  );
+
+CREATE TABLE "statement_exports" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "format" varchar NOT NULL,
+  "period_start" timestamptz NOT NULL,
+  "period_end" timestamptz NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "file_path" varchar NOT NULL DEFAULT '',
+  "error" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "completed_at" timestamptz
+);
+
+ALTER TABLE "statement_exports" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "statement_exports" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "statement_exports" ADD CONSTRAINT "statement_exports_status_check"
+  CHECK ("status" IN ('pending', 'completed', 'failed'));
+
+CREATE INDEX ON "statement_exports" ("owner");
+
+COMMENT ON COLUMN "statement_exports"."period_end" IS 'exclusive';
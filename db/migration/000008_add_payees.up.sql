@@ -0,0 +1,27 @@
+CREATE TABLE "payees" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "nickname" varchar NOT NULL,
+  "account_id" bigint,
+  "username" varchar,
+  "currency" varchar NOT NULL,
+  "available_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "payees" ADD CONSTRAINT "payees_target_check" CHECK (("account_id" IS NULL) <> ("username" IS NULL));
+
+CREATE UNIQUE INDEX ON "payees" ("owner", "nickname");
+
+COMMENT ON COLUMN "payees"."account_id" IS 'target account, NULL when the target is a username';
+
+COMMENT ON COLUMN "payees"."username" IS 'target user, whose account in the currency receives the transfer';
+
+COMMENT ON COLUMN "payees"."available_at" IS 'end of the cooling-off period, no transfer to the payee before it';
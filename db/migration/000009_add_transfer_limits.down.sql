@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "transfers_from_account_id_created_at_idx";
+
+DROP TABLE IF EXISTS "user_transfer_limits";
+
+ALTER TABLE IF EXISTS "account_types" DROP COLUMN IF EXISTS "monthly_transfer_limit";
+ALTER TABLE IF EXISTS "account_types" DROP COLUMN IF EXISTS "daily_transfer_limit";
+ALTER TABLE IF EXISTS "account_types" DROP COLUMN IF EXISTS "max_transfer_amount";
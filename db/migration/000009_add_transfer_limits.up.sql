@@ -0,0 +1,38 @@
+ALTER TABLE "account_types" ADD COLUMN "max_transfer_amount" bigint;
+ALTER TABLE "account_types" ADD COLUMN "daily_transfer_limit" bigint;
+ALTER TABLE "account_types" ADD COLUMN "monthly_transfer_limit" bigint;
+
+UPDATE "account_types" SET
+  "max_transfer_amount" = 1000000,
+  "daily_transfer_limit" = 2500000,
+  "monthly_transfer_limit" = 25000000
+WHERE "name" = 'checking';
+
+UPDATE "account_types" SET
+  "max_transfer_amount" = 1000000,
+  "daily_transfer_limit" = 1000000,
+  "monthly_transfer_limit" = 5000000
+WHERE "name" = 'savings';
+
+CREATE TABLE "user_transfer_limits" (
+  "username" varchar PRIMARY KEY,
+  "max_transfer_amount" bigint,
+  "daily_transfer_limit" bigint,
+  "monthly_transfer_limit" bigint,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "user_transfer_limits" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "user_transfer_limits" ADD FOREIGN KEY ("updated_by") REFERENCES "users" ("username");
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+COMMENT ON COLUMN "account_types"."max_transfer_amount" IS 'largest single outgoing transfer, NULL for no limit';
+
+COMMENT ON COLUMN "account_types"."daily_transfer_limit" IS 'outgoing transfers per UTC day, NULL for no limit';
+
+COMMENT ON COLUMN "account_types"."monthly_transfer_limit" IS 'outgoing transfers per UTC month, NULL for no limit';
+
+COMMENT ON TABLE "user_transfer_limits" IS 'overrides the limits of the account types for all accounts of the user, a NULL column keeps the limit of the account type';
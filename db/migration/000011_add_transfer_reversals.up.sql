@@ -0,0 +1,20 @@
+ALTER TABLE "transfers" ADD COLUMN "reversal_of_id" bigint;
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("reversal_of_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "transfers" ("reversal_of_id");
+
+CREATE TABLE "transfer_reversals" (
+  "transfer_id" bigint PRIMARY KEY,
+  "reason" varchar NOT NULL,
+  "reversed_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("reversed_by") REFERENCES "users" ("username");
+
+COMMENT ON COLUMN "transfers"."reversal_of_id" IS 'transfer this one reverses, fully or partially';
+
+COMMENT ON TABLE "transfer_reversals" IS 'audit record of the compensating transfer of a reversal';
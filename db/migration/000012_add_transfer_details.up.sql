@@ -0,0 +1,11 @@
+ALTER TABLE "transfers" ADD COLUMN "description" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "transfers" ADD COLUMN "external_reference" varchar;
+
+ALTER TABLE "transfers" ADD COLUMN "metadata" jsonb NOT NULL DEFAULT '{}';
+
+CREATE UNIQUE INDEX ON "transfers" ("from_account_id", "external_reference");
+
+COMMENT ON COLUMN "transfers"."external_reference" IS 'supplied by the client, unique per source account';
+
+COMMENT ON COLUMN "transfers"."metadata" IS 'JSON object supplied by the client';
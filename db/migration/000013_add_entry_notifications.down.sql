@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS entry_created ON "entries";
+
+DROP FUNCTION IF EXISTS notify_entry_created();
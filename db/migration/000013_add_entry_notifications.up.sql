@@ -0,0 +1,9 @@
+CREATE FUNCTION notify_entry_created() RETURNS trigger AS $$
+BEGIN
+  PERFORM pg_notify('entry_created', NEW.account_id::text);
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER entry_created AFTER INSERT ON "entries"
+FOR EACH ROW EXECUTE FUNCTION notify_entry_created();
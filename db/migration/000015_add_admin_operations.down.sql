@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "balance_adjustments";
+
+ALTER TABLE IF EXISTS "users" DROP COLUMN IF EXISTS "is_blocked";
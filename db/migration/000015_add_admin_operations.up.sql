@@ -0,0 +1,27 @@
+ALTER TABLE "users" ADD COLUMN "is_blocked" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE "balance_adjustments" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "entry_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "reason" varchar NOT NULL,
+  "adjusted_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "balance_adjustments" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "balance_adjustments" ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "balance_adjustments" ADD FOREIGN KEY ("adjusted_by") REFERENCES "users" ("username");
+
+ALTER TABLE "balance_adjustments" ADD CONSTRAINT "balance_adjustments_amount_check" CHECK ("amount" <> 0);
+
+CREATE INDEX ON "balance_adjustments" ("account_id");
+
+CREATE UNIQUE INDEX ON "balance_adjustments" ("entry_id");
+
+COMMENT ON COLUMN "users"."is_blocked" IS 'blocked users cannot log in';
+
+COMMENT ON TABLE "balance_adjustments" IS 'audit record of a manual correction of a balance';
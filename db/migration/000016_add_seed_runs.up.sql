@@ -0,0 +1,7 @@
+CREATE TABLE "seed_runs" (
+  "id" bigserial PRIMARY KEY,
+  "started_at" timestamptz NOT NULL DEFAULT (now()),
+  "completed_at" timestamptz
+);
+
+COMMENT ON TABLE "seed_runs" IS 'runs of the seed command, a run that did not complete left a partly seeded database';
@@ -0,0 +1,3 @@
+ALTER TABLE "statement_exports" ADD COLUMN "file_path" varchar NOT NULL DEFAULT '';
+
+DROP TABLE IF EXISTS "statement_files";
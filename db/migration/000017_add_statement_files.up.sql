@@ -0,0 +1,11 @@
+CREATE TABLE "statement_files" (
+  "export_id" bigint PRIMARY KEY,
+  "content" bytea NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "statement_files" ADD FOREIGN KEY ("export_id") REFERENCES "statement_exports" ("id") ON DELETE CASCADE;
+
+COMMENT ON TABLE "statement_files" IS 'files of the completed statement exports, kept in the database so that the gateway can serve what the worker generated';
+
+ALTER TABLE "statement_exports" DROP COLUMN "file_path";
@@ -0,0 +1,8 @@
+CREATE TABLE "event_outbox" (
+  "id" bigserial PRIMARY KEY,
+  "event_name" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON TABLE "event_outbox" IS 'domain events written by the transactions that made them happen, deleted once published by the worker';
@@ -0,0 +1,6 @@
+package mockdb
+
+import db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+
+// fails to build when the mock falls behind db.Store, see go generate
+var _ db.Store = (*MockStore)(nil)
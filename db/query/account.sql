@@ -2,15 +2,33 @@
 INSERT INTO accounts (
     owner,
     balance,
-    currency
+    currency,
+    account_type
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4
 ) RETURNING *;
 
 -- name: GetAccount :one
 SELECT * FROM accounts
 WHERE id = $1 LIMIT 1;
 
+-- name: GetAccountByOwnerAndCurrency :one
+SELECT * FROM accounts
+WHERE owner = $1 AND currency = $2 LIMIT 1;
+
+-- name: GetRecipientAccount :one
+-- Resolves the account of the recipient of a transfer by username or email in
+-- a single query, so that a missing user, a missing account and an account
+-- that cannot receive money all look the same to the caller.
+SELECT accounts.* FROM accounts
+JOIN users ON users.username = accounts.owner
+WHERE
+    (users.username = sqlc.narg(username) OR users.email = sqlc.narg(email)) AND
+    users.username <> '$system' AND
+    accounts.currency = sqlc.arg(currency) AND
+    accounts.status = 'active'
+LIMIT 1;
+
 -- name: GetAccountForUpdate :one
 SELECT * FROM accounts
 WHERE id = $1 LIMIT 1
@@ -22,6 +40,36 @@ SET balance = balance + sqlc.arg(amount)
 WHERE id = sqlc.arg(id)
 RETURNING *;
 
+-- name: AddAccountAccruedInterest :one
+UPDATE accounts
+SET accrued_interest = accrued_interest + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = sqlc.arg(status)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateAccountStatusChange :one
+INSERT INTO account_status_changes (
+    account_id,
+    from_status,
+    to_status,
+    reason,
+    changed_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListAccountStatusChanges :many
+SELECT * FROM account_status_changes
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
+
 -- name: ListAccounts :many
 SELECT * FROM accounts
 WHERE owner = $1
@@ -39,3 +87,14 @@ RETURNING *;
 DELETE FROM accounts
 WHERE id = $1;
 
+
+-- name: AddAccountHeldAmount :one
+UPDATE accounts
+SET held_amount = held_amount + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListAccountIDs :many
+SELECT id FROM accounts
+WHERE owner = $1
+ORDER BY id;
@@ -0,0 +1,10 @@
+-- name: CreateBalanceAdjustment :one
+INSERT INTO balance_adjustments (
+    account_id,
+    entry_id,
+    amount,
+    reason,
+    adjusted_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
@@ -1,9 +1,10 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  transfer_id
 ) VALUES (
-  $1, $2
+  $1, $2, $3
 ) RETURNING *;
 
 -- name: GetEntry :one
@@ -16,3 +17,45 @@ WHERE account_id = $1
 ORDER BY id
 LIMIT $2
 OFFSET $3;
+
+-- name: ListAccountEntries :many
+SELECT
+    entries.*,
+    COALESCE(transfers.description, balance_adjustments.reason, '')::varchar AS description,
+    transfers.external_reference,
+    COALESCE(transfers.metadata, '{}')::jsonb AS metadata
+FROM entries
+LEFT JOIN transfers ON transfers.id = entries.transfer_id
+LEFT JOIN balance_adjustments ON balance_adjustments.entry_id = entries.id
+WHERE entries.account_id = $1
+ORDER BY entries.id DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: ListEntriesAfter :many
+SELECT
+    entries.*,
+    COALESCE(transfers.description, balance_adjustments.reason, '')::varchar AS description,
+    transfers.external_reference,
+    COALESCE(transfers.metadata, '{}')::jsonb AS metadata
+FROM entries
+LEFT JOIN transfers ON transfers.id = entries.transfer_id
+LEFT JOIN balance_adjustments ON balance_adjustments.entry_id = entries.id
+WHERE
+    entries.account_id = ANY(sqlc.arg(account_ids)::bigint[]) AND
+    entries.id > sqlc.arg(after_id)
+ORDER BY entries.id
+LIMIT sqlc.arg(page_size);
+
+-- name: GetLastEntryID :one
+SELECT COALESCE(MAX(id), 0)::bigint AS last_entry_id FROM entries
+WHERE account_id = ANY(sqlc.arg(account_ids)::bigint[]);
+
+-- name: GetLastEntryIDBefore :one
+-- Entries created before created_before belong to transactions that are long
+-- committed, so the entry returned is a safe point to read new entries after.
+SELECT COALESCE(MAX(id), 0)::bigint AS last_entry_id FROM entries
+WHERE
+    account_id = ANY(sqlc.arg(account_ids)::bigint[]) AND
+    id <= sqlc.arg(max_id) AND
+    created_at < sqlc.arg(created_before);
@@ -0,0 +1,18 @@
+-- name: CreateOutboxEvent :exec
+INSERT INTO event_outbox (
+    event_name,
+    payload
+) VALUES (
+    $1, $2
+);
+
+-- name: ListOutboxEventsForUpdate :many
+-- Locked events are being published by another worker and are skipped.
+SELECT * FROM event_outbox
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: DeleteOutboxEvents :exec
+DELETE FROM event_outbox
+WHERE id = ANY(sqlc.arg(ids)::bigint[]);
@@ -0,0 +1,64 @@
+-- name: GetAccountType :one
+SELECT * FROM account_types
+WHERE name = $1 LIMIT 1;
+
+-- name: ListAccountTypes :many
+SELECT * FROM account_types
+ORDER BY name;
+
+-- name: ListInterestBearingAccounts :many
+SELECT accounts.* FROM accounts
+JOIN account_types ON account_types.name = accounts.account_type
+WHERE
+    account_types.annual_interest_rate_bps > 0 AND
+    accounts.status <> 'closed' AND
+    accounts.id > sqlc.arg(after_id)
+ORDER BY accounts.id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListAccountsWithAccruedInterest :many
+SELECT * FROM accounts
+WHERE
+    accrued_interest >= sqlc.arg(min_accrued_interest) AND
+    status = 'active' AND
+    id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(page_size);
+
+-- name: GetEntriesSumSince :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total FROM entries
+WHERE account_id = $1 AND created_at >= $2;
+
+-- name: CreateInterestAccrual :one
+INSERT INTO interest_accruals (
+    account_id,
+    accrual_date,
+    balance,
+    annual_interest_rate_bps,
+    amount
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (account_id, accrual_date) DO NOTHING
+RETURNING *;
+
+-- name: ListInterestAccruals :many
+SELECT * FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrual_date
+LIMIT $2
+OFFSET $3;
+
+-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (
+    account_id,
+    period,
+    amount,
+    transfer_id
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetInterestPosting :one
+SELECT * FROM interest_postings
+WHERE account_id = $1 AND period = $2 LIMIT 1;
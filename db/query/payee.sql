@@ -0,0 +1,35 @@
+-- name: CreatePayee :one
+INSERT INTO payees (
+    owner,
+    nickname,
+    account_id,
+    username,
+    currency,
+    available_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetPayee :one
+SELECT * FROM payees
+WHERE id = $1 LIMIT 1;
+
+-- name: ListPayees :many
+SELECT * FROM payees
+WHERE owner = $1
+ORDER BY nickname
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdatePayee :one
+UPDATE payees
+SET
+    nickname = sqlc.arg(nickname),
+    updated_at = now()
+WHERE
+    id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeletePayee :exec
+DELETE FROM payees
+WHERE id = $1;
@@ -0,0 +1,68 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+    owner,
+    from_account_id,
+    to_account_id,
+    amount,
+    recurrence,
+    next_run_at,
+    end_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1;
+
+-- name: GetScheduledTransferForUpdate :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: ListDueScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE status = 'active'
+    AND next_run_at <= sqlc.arg(now)
+    AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(page_size);
+
+-- name: UpdateScheduledTransfer :one
+UPDATE scheduled_transfers
+SET
+    amount = COALESCE(sqlc.narg(amount), amount),
+    recurrence = COALESCE(sqlc.narg(recurrence), recurrence),
+    next_run_at = COALESCE(sqlc.narg(next_run_at), next_run_at),
+    end_at = COALESCE(sqlc.narg(end_at), end_at),
+    status = COALESCE(sqlc.narg(status), status),
+    failed_attempts = COALESCE(sqlc.narg(failed_attempts), failed_attempts),
+    updated_at = now()
+WHERE
+    id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateScheduledTransferExecution :one
+INSERT INTO scheduled_transfer_executions (
+    scheduled_transfer_id,
+    scheduled_at,
+    status,
+    transfer_id,
+    failure_reason
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListScheduledTransferExecutions :many
+SELECT * FROM scheduled_transfer_executions
+WHERE scheduled_transfer_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
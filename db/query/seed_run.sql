@@ -0,0 +1,14 @@
+-- name: CreateSeedRun :one
+INSERT INTO seed_runs DEFAULT VALUES
+RETURNING *;
+
+-- name: CompleteSeedRun :one
+UPDATE seed_runs
+SET completed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetLastSeedRun :one
+SELECT * FROM seed_runs
+ORDER BY id DESC
+LIMIT 1;
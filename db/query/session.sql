@@ -14,3 +14,23 @@ INSERT INTO sessions (
 -- name: GetSession :one
 SELECT * FROM sessions 
 WHERE id = $1 LIMIT 1;
+
+-- name: UpdateSessionBlocked :one
+UPDATE sessions
+SET is_blocked = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: BlockUserSessions :many
+-- Blocks the sessions of the user that can still be used to renew tokens.
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1 AND is_blocked = false AND expires_at > now()
+RETURNING *;
+
+-- name: ListUserSessions :many
+SELECT * FROM sessions
+WHERE username = $1
+ORDER BY created_at DESC
+LIMIT $2
+OFFSET $3;
@@ -0,0 +1,71 @@
+-- name: GetStatementBalances :one
+-- Computed in one statement so that the balances and last_entry_id come from
+-- the same snapshot. Entries after last_entry_id are not part of the statement.
+SELECT
+    (a.balance - COALESCE(SUM(e.amount), 0))::bigint AS opening_balance,
+    (a.balance - COALESCE(SUM(e.amount) FILTER (WHERE e.created_at >= sqlc.arg(period_end)), 0))::bigint AS closing_balance,
+    COALESCE(MAX(e.id), 0)::bigint AS last_entry_id
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id AND e.created_at >= sqlc.arg(period_start)
+WHERE a.id = sqlc.arg(account_id)
+GROUP BY a.id;
+
+-- name: ListStatementEntries :many
+SELECT
+    e.id,
+    e.amount,
+    e.created_at,
+    e.transfer_id,
+    COALESCE(c.id, 0)::bigint AS counterparty_account_id,
+    COALESCE(c.owner, '')::varchar AS counterparty_owner
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+LEFT JOIN accounts c ON c.id = (
+    CASE WHEN t.from_account_id = e.account_id THEN t.to_account_id ELSE t.from_account_id END
+)
+WHERE e.account_id = sqlc.arg(account_id)
+    AND e.created_at >= sqlc.arg(period_start)
+    AND e.created_at < sqlc.arg(period_end)
+    AND e.id > sqlc.arg(after_id)
+    AND e.id <= sqlc.arg(last_entry_id)
+ORDER BY e.id
+LIMIT sqlc.arg(page_size);
+
+-- name: CreateStatementExport :one
+INSERT INTO statement_exports (
+    owner,
+    account_id,
+    format,
+    period_start,
+    period_end
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetStatementExport :one
+SELECT * FROM statement_exports
+WHERE id = $1 LIMIT 1;
+
+-- name: CompleteStatementExport :one
+UPDATE statement_exports
+SET
+    status = sqlc.arg(status),
+    error = sqlc.arg(error),
+    completed_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SaveStatementFile :exec
+-- Replaces the file of an earlier attempt whose export was not completed.
+INSERT INTO statement_files (
+    export_id,
+    content
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (export_id) DO UPDATE
+SET content = EXCLUDED.content, created_at = now();
+
+-- name: GetStatementFile :one
+SELECT * FROM statement_files
+WHERE export_id = $1 LIMIT 1;
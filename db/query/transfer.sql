@@ -2,15 +2,38 @@
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  reversal_of_id,
+  description,
+  external_reference,
+  metadata
 ) VALUES (
-  $1, $2, $3
+  sqlc.arg(from_account_id), sqlc.arg(to_account_id), sqlc.arg(amount), sqlc.narg(reversal_of_id),
+  sqlc.arg(description), sqlc.narg(external_reference), sqlc.arg(metadata)
 ) RETURNING *;
 
 -- name: GetTransfer :one
 SELECT * FROM transfers
 WHERE id = $1 LIMIT 1;
 
+-- name: GetTransferForUpdate :one
+SELECT * FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetReversedAmount :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS reversed_amount FROM transfers
+WHERE reversal_of_id = $1;
+
+-- name: CreateTransferReversal :one
+INSERT INTO transfer_reversals (
+    transfer_id,
+    reason,
+    reversed_by
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
 -- name: ListTransfers :many
 SELECT * FROM transfers
 WHERE 
@@ -19,3 +42,12 @@ WHERE
 ORDER BY id
 LIMIT $3
 OFFSET $4;
+
+-- name: ListAccountTransfers :many
+SELECT * FROM transfers
+WHERE
+    (from_account_id = sqlc.arg(account_id) OR to_account_id = sqlc.arg(account_id)) AND
+    (sqlc.narg(external_reference)::varchar IS NULL OR external_reference = sqlc.narg(external_reference))
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size)
+OFFSET sqlc.arg(page_offset);
@@ -0,0 +1,48 @@
+-- name: GetTransferLimits :one
+SELECT
+    account_types.max_transfer_amount,
+    account_types.daily_transfer_limit,
+    account_types.monthly_transfer_limit,
+    user_transfer_limits.max_transfer_amount AS user_max_transfer_amount,
+    user_transfer_limits.daily_transfer_limit AS user_daily_transfer_limit,
+    user_transfer_limits.monthly_transfer_limit AS user_monthly_transfer_limit
+FROM accounts
+JOIN account_types ON account_types.name = accounts.account_type
+LEFT JOIN user_transfer_limits ON user_transfer_limits.username = accounts.owner
+WHERE accounts.id = $1;
+
+-- name: GetOutgoingTransferTotals :one
+-- The day starts within the month, so both totals come from a single scan of
+-- the transfers of the month. Only the transfers made by the customer count:
+-- reversals and postings to the system accounts are made by the bank.
+SELECT
+    COALESCE(SUM(transfers.amount) FILTER (WHERE transfers.created_at >= sqlc.arg(day_start)), 0)::bigint AS daily_total,
+    COALESCE(SUM(transfers.amount), 0)::bigint AS monthly_total
+FROM transfers
+WHERE
+    transfers.from_account_id = sqlc.arg(account_id) AND
+    transfers.created_at >= sqlc.arg(month_start) AND
+    transfers.reversal_of_id IS NULL AND
+    transfers.to_account_id NOT IN (SELECT accounts.id FROM accounts WHERE accounts.owner = '$system');
+
+-- name: GetUserTransferLimits :one
+SELECT * FROM user_transfer_limits
+WHERE username = $1 LIMIT 1;
+
+-- name: UpsertUserTransferLimits :one
+INSERT INTO user_transfer_limits (
+    username,
+    max_transfer_amount,
+    daily_transfer_limit,
+    monthly_transfer_limit,
+    updated_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) ON CONFLICT (username) DO UPDATE SET
+    max_transfer_amount = EXCLUDED.max_transfer_amount,
+    daily_transfer_limit = EXCLUDED.daily_transfer_limit,
+    monthly_transfer_limit = EXCLUDED.monthly_transfer_limit,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING *;
+
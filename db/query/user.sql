@@ -22,3 +22,20 @@ SET
 WHERE
     username = sqlc.arg(username)
 RETURNING *;
+
+-- name: SearchUsers :many
+-- Matches the pattern against the username, full name and email of users.
+SELECT * FROM users
+WHERE
+    username ILIKE sqlc.arg(pattern)::varchar OR
+    full_name ILIKE sqlc.arg(pattern)::varchar OR
+    email ILIKE sqlc.arg(pattern)::varchar
+ORDER BY username
+LIMIT sqlc.arg(page_size)
+OFFSET sqlc.arg(page_offset);
+
+-- name: UpdateUserBlocked :one
+UPDATE users
+SET is_blocked = $2
+WHERE username = $1
+RETURNING *;
@@ -0,0 +1,189 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateAccountStatusTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccount(t)
+	assert.Equal(t, util.AccountStatusActive, account.Status)
+
+	admin := util.RandomOwner()
+	result, err := store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID:     account.ID,
+		Status:        util.AccountStatusFrozen,
+		Reason:        "suspicious activity",
+		ChangedBy:     admin,
+		ChangedByRole: util.AdminRole,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, util.AccountStatusFrozen, result.Account.Status)
+	assert.Equal(t, account.ID, result.StatusChange.AccountID)
+	assert.Equal(t, util.AccountStatusActive, result.StatusChange.FromStatus)
+	assert.Equal(t, util.AccountStatusFrozen, result.StatusChange.ToStatus)
+	assert.Equal(t, "suspicious activity", result.StatusChange.Reason)
+	assert.Equal(t, admin, result.StatusChange.ChangedBy)
+
+	// frozen accounts can neither send nor receive money
+	other := createRandomAccount(t)
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: other.ID,
+		ToAccountID:   account.ID,
+		Amount:        1,
+	})
+	assert.ErrorIs(t, err, ErrAccountNotActive)
+
+	// a frozen account cannot be closed while it holds money
+	_, err = store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID:     account.ID,
+		Status:        util.AccountStatusClosed,
+		Reason:        "customer request",
+		ChangedBy:     admin,
+		ChangedByRole: util.AdminRole,
+	})
+	assert.ErrorIs(t, err, ErrAccountBalanceNotZero)
+
+	changes, err := store.ListAccountStatusChanges(context.Background(), ListAccountStatusChangesParams{
+		AccountID: account.ID,
+		Limit:     5,
+		Offset:    0,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+}
+
+func TestCloseAndReopenAccount(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	account, err := store.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:       user.Username,
+		Balance:     0,
+		Currency:    util.USD,
+		AccountType: util.AccountTypeChecking,
+	})
+	assert.NoError(t, err)
+
+	arg := UpdateAccountStatusTxParams{
+		AccountID:     account.ID,
+		Status:        util.AccountStatusClosed,
+		Reason:        "customer request",
+		ChangedBy:     user.Username,
+		ChangedByRole: user.Role,
+	}
+	result, err := store.UpdateAccountStatusTx(context.Background(), arg)
+	assert.NoError(t, err)
+	assert.Equal(t, util.AccountStatusClosed, result.Account.Status)
+
+	arg.Status = util.AccountStatusFrozen
+	_, err = store.UpdateAccountStatusTx(context.Background(), arg)
+	assert.ErrorIs(t, err, ErrInvalidAccountStatusTransition)
+
+	arg.Status = util.AccountStatusActive
+	result, err = store.UpdateAccountStatusTx(context.Background(), arg)
+	assert.NoError(t, err)
+	assert.Equal(t, util.AccountStatusActive, result.Account.Status)
+}
+
+func TestUpdateAccountStatusTxAccruedInterest(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomSavingsAccount(t, 0)
+
+	account, err := store.AddAccountAccruedInterest(context.Background(), AddAccountAccruedInterestParams{
+		Amount: 2*util.InterestScale + 1234,
+		ID:     account.ID,
+	})
+	assert.NoError(t, err)
+
+	// freezing pays the whole units, since interest is only posted to active
+	// accounts
+	result, err := store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID:     account.ID,
+		Status:        util.AccountStatusFrozen,
+		Reason:        "suspicious activity",
+		ChangedBy:     util.RandomOwner(),
+		ChangedByRole: util.AdminRole,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result.Account.Balance)
+	assert.Equal(t, int64(1234), result.Account.AccruedInterest)
+	assert.Equal(t, int64(2), result.InterestTransfer.ToEntry.Amount)
+	assert.Equal(t, util.SystemUsername, result.InterestTransfer.FromAccount.Owner)
+
+	// the rest is posted once the account is active again
+	accounts, err := store.ListAccountsWithAccruedInterest(context.Background(), ListAccountsWithAccruedInterestParams{
+		MinAccruedInterest: 1,
+		AfterID:            account.ID - 1,
+		PageSize:           1,
+	})
+	assert.NoError(t, err)
+	for _, listed := range accounts {
+		assert.NotEqual(t, account.ID, listed.ID)
+	}
+
+	// closing forfeits the accrued interest
+	empty := createRandomSavingsAccount(t, 0)
+	_, err = store.AddAccountAccruedInterest(context.Background(), AddAccountAccruedInterestParams{
+		Amount: 1234,
+		ID:     empty.ID,
+	})
+	assert.NoError(t, err)
+
+	result, err = store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID:     empty.ID,
+		Status:        util.AccountStatusClosed,
+		Reason:        "customer request",
+		ChangedBy:     empty.Owner,
+		ChangedByRole: util.DepositorRole,
+	})
+	assert.NoError(t, err)
+	assert.Zero(t, result.Account.AccruedInterest)
+}
+
+// TestUpdateAccountStatusTxConcurrentChange checks the role against the locked
+// account: an owner who saw an active account cannot close it once an admin
+// has frozen it in the meantime.
+func TestUpdateAccountStatusTxConcurrentChange(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	account, err := store.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:       user.Username,
+		Balance:     0,
+		Currency:    util.USD,
+		AccountType: util.AccountTypeChecking,
+	})
+	assert.NoError(t, err)
+
+	// the handler of the owner reads the account before the admin freezes it
+	read, err := store.GetAccount(context.Background(), account.ID)
+	assert.NoError(t, err)
+	assert.True(t, util.CanChangeAccountStatus(util.DepositorRole, read.Status, util.AccountStatusClosed))
+
+	_, err = store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID:     account.ID,
+		Status:        util.AccountStatusFrozen,
+		Reason:        "suspicious activity",
+		ChangedBy:     util.RandomOwner(),
+		ChangedByRole: util.AdminRole,
+	})
+	assert.NoError(t, err)
+
+	_, err = store.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID:     account.ID,
+		Status:        util.AccountStatusClosed,
+		Reason:        "customer request",
+		ChangedBy:     user.Username,
+		ChangedByRole: util.DepositorRole,
+	})
+	assert.ErrorIs(t, err, ErrAccountStatusChangeNotAllowed)
+
+	account, err = store.GetAccount(context.Background(), account.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, util.AccountStatusFrozen, account.Status)
+}
@@ -2,11 +2,11 @@ package db
 
 import (
 	"context"
-	"database/sql"
 	"testing"
 	"time"
 
 	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,9 +14,10 @@ func createRandomAccount(t *testing.T) Account {
 	user := createRandomUser(t)
 
 	arg := CreateAccountParams{
-		Owner:    user.Username,
-		Balance:  util.RandomMoney(),
-		Currency: util.RandomCurrency(),
+		Owner:       user.Username,
+		Balance:     util.RandomMoney(),
+		Currency:    util.RandomCurrency(),
+		AccountType: util.AccountTypeChecking,
 	}
 
 	account, err := testQueries.CreateAccount(context.Background(), arg)
@@ -26,12 +27,24 @@ func createRandomAccount(t *testing.T) Account {
 	assert.Equal(t, arg.Owner, account.Owner)
 	assert.Equal(t, arg.Balance, account.Balance)
 	assert.Equal(t, arg.Currency, account.Currency)
+	assert.Equal(t, arg.AccountType, account.AccountType)
 	assert.NotZero(t, account.ID)
 	assert.NotZero(t, account.CreatedAt)
 
 	return account
 }
 
+// fundAccount adds money to the account, for tests that need its balance to
+// cover their transfers.
+func fundAccount(t *testing.T, account Account, amount int64) Account {
+	account, err := testQueries.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     account.ID,
+		Amount: amount,
+	})
+	assert.NoError(t, err)
+	return account
+}
+
 func TestCreateAccount(t *testing.T) {
 	createRandomAccount(t)
 }
@@ -72,7 +85,7 @@ func TestDeleteAccount(t *testing.T) {
 
 	acc2, err := testQueries.GetAccount(context.Background(), acc1.ID)
 	assert.Error(t, err)
-	assert.EqualError(t, err, sql.ErrNoRows.Error())
+	assert.EqualError(t, err, pgx.ErrNoRows.Error())
 	assert.Empty(t, acc2)
 }
 
@@ -0,0 +1,120 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSession(t *testing.T, username string, expiresAt time.Time) Session {
+	session, err := testQueries.CreateSession(context.Background(), CreateSessionParams{
+		ID:           uuid.New(),
+		Username:     username,
+		RefreshToken: util.RandomString(32),
+		UserAgent:    "test",
+		ClientIp:     "127.0.0.1",
+		ExpiresAt:    expiresAt,
+	})
+	require.NoError(t, err)
+	return session
+}
+
+func TestSearchUsers(t *testing.T) {
+	user := createRandomUser(t)
+
+	users, err := testQueries.SearchUsers(context.Background(), SearchUsersParams{
+		Pattern:  "%" + user.Email + "%",
+		PageSize: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, user.Username, users[0].Username)
+}
+
+func TestBlockUserTx(t *testing.T) {
+	store, published := newRecordingStore()
+
+	user := createRandomUser(t)
+	active := createRandomSession(t, user.Username, time.Now().Add(time.Hour))
+	createRandomSession(t, user.Username, time.Now().Add(-time.Hour))
+
+	result, err := store.BlockUserTx(context.Background(), BlockUserTxParams{
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.User.IsBlocked)
+
+	// the expired session cannot renew tokens anyway
+	require.Len(t, result.RevokedSessions, 1)
+	assert.Equal(t, active.ID, result.RevokedSessions[0].ID)
+	assert.True(t, result.RevokedSessions[0].IsBlocked)
+
+	require.Len(t, *published, 1)
+	event, ok := (*published)[0].(events.SessionRevoked)
+	require.True(t, ok)
+	assert.Equal(t, active.ID, event.SessionID)
+	assert.Equal(t, user.Username, event.Username)
+}
+
+func TestAdjustBalanceTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	admin := createRandomUser(t)
+	account := createRandomAccount(t)
+
+	result, err := store.AdjustBalanceTx(context.Background(), AdjustBalanceTxParams{
+		AccountID:  account.ID,
+		Amount:     50,
+		Reason:     "correction of a failed deposit",
+		AdjustedBy: admin.Username,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, account.Balance+50, result.Account.Balance)
+	assert.Equal(t, int64(50), result.Entry.Amount)
+	assert.Equal(t, result.Transfer.Transfer.ID, result.Entry.TransferID.Int64)
+	assert.Equal(t, result.Entry.ID, result.Adjustment.EntryID)
+
+	// the money comes from the system account, so the ledger still balances
+	assert.Equal(t, util.SystemUsername, result.Transfer.FromAccount.Owner)
+	assert.Equal(t, account.Currency, result.Transfer.FromAccount.Currency)
+	assert.Equal(t, int64(-50), result.Transfer.FromEntry.Amount)
+	assert.Equal(t, admin.Username, result.Adjustment.AdjustedBy)
+
+	entries, err := testQueries.ListAccountEntries(context.Background(), ListAccountEntriesParams{
+		AccountID: account.ID,
+		Limit:     1,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "correction of a failed deposit", entries[0].Description)
+
+	_, err = store.AdjustBalanceTx(context.Background(), AdjustBalanceTxParams{
+		AccountID:  account.ID,
+		Amount:     -(result.Account.AvailableBalance + 1),
+		Reason:     "too much",
+		AdjustedBy: admin.Username,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestRevokeSessionTx(t *testing.T) {
+	store, published := newRecordingStore()
+
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username, time.Now().Add(time.Hour))
+
+	result, err := store.RevokeSessionTx(context.Background(), RevokeSessionTxParams{
+		ID: session.ID,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Session.IsBlocked)
+
+	require.Len(t, *published, 1)
+	assert.Equal(t, session.ID, (*published)[0].(events.SessionRevoked).SessionID)
+}
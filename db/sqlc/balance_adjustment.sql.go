@@ -0,0 +1,51 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.18.0
+// source: balance_adjustment.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createBalanceAdjustment = `-- name: CreateBalanceAdjustment :one
+INSERT INTO balance_adjustments (
+    account_id,
+    entry_id,
+    amount,
+    reason,
+    adjusted_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, account_id, entry_id, amount, reason, adjusted_by, created_at
+`
+
+type CreateBalanceAdjustmentParams struct {
+	AccountID  int64  `json:"account_id"`
+	EntryID    int64  `json:"entry_id"`
+	Amount     int64  `json:"amount"`
+	Reason     string `json:"reason"`
+	AdjustedBy string `json:"adjusted_by"`
+}
+
+func (q *Queries) CreateBalanceAdjustment(ctx context.Context, arg CreateBalanceAdjustmentParams) (BalanceAdjustment, error) {
+	row := q.db.QueryRow(ctx, createBalanceAdjustment,
+		arg.AccountID,
+		arg.EntryID,
+		arg.Amount,
+		arg.Reason,
+		arg.AdjustedBy,
+	)
+	var i BalanceAdjustment
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.EntryID,
+		&i.Amount,
+		&i.Reason,
+		&i.AdjustedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
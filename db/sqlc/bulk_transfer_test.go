@@ -0,0 +1,151 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkTransferTxAtomic(t *testing.T) {
+	store := NewStore(testDB)
+	from := fundAccount(t, createRandomAccount(t), 30)
+	to1 := createRandomAccount(t)
+	to2 := createRandomAccount(t)
+
+	result, err := store.BulkTransferTx(context.Background(), BulkTransferTxParams{
+		FromAccountID: from.ID,
+		Items: []BulkTransferItem{
+			{ToAccountID: to1.ID, Amount: 10},
+			{ToAccountID: to2.ID, Amount: 20},
+		},
+		Atomic: true,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result.Results, 2)
+	assert.Equal(t, to1.ID, result.Results[0].Transfer.Transfer.ToAccountID)
+	assert.Equal(t, to1.Balance+10, result.Results[0].Transfer.ToAccount.Balance)
+	assert.Equal(t, to2.Balance+20, result.Results[1].Transfer.ToAccount.Balance)
+	assert.Equal(t, from.Balance-30, result.FromAccount.Balance)
+}
+
+func TestBulkTransferTxAtomicRollback(t *testing.T) {
+	store := NewStore(testDB)
+	from := fundAccount(t, createRandomAccount(t), 10)
+	to1 := createRandomAccount(t)
+	to2 := createRandomAccount(t)
+
+	// the second item cannot be covered once the first one is executed
+	_, err := store.BulkTransferTx(context.Background(), BulkTransferTxParams{
+		FromAccountID: from.ID,
+		Items: []BulkTransferItem{
+			{ToAccountID: to1.ID, Amount: 10},
+			{ToAccountID: to2.ID, Amount: from.AvailableBalance},
+		},
+		Atomic: true,
+	})
+	var itemErr *BulkTransferItemError
+	assert.ErrorAs(t, err, &itemErr)
+	assert.Equal(t, 1, itemErr.Index)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+	account, err := store.GetAccount(context.Background(), from.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, from.Balance, account.Balance)
+
+	account, err = store.GetAccount(context.Background(), to1.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, to1.Balance, account.Balance)
+}
+
+func TestBulkTransferTxAtomicUniqueReference(t *testing.T) {
+	store := NewStore(testDB)
+	from := fundAccount(t, createRandomAccount(t), 10)
+	to := createRandomAccount(t)
+	reference := sql.NullString{String: util.RandomString(10), Valid: true}
+
+	_, err := store.BulkTransferTx(context.Background(), BulkTransferTxParams{
+		FromAccountID: from.ID,
+		Items: []BulkTransferItem{
+			{ToAccountID: to.ID, Amount: 1, ExternalReference: reference},
+			{ToAccountID: to.ID, Amount: 1, ExternalReference: reference},
+		},
+		Atomic: true,
+	})
+	var itemErr *BulkTransferItemError
+	require.ErrorAs(t, err, &itemErr)
+	require.Equal(t, 1, itemErr.Index)
+	require.ErrorIs(t, itemErr.Err, ErrUniqueViolation)
+}
+
+func TestBulkTransferTxBestEffort(t *testing.T) {
+	store := NewStore(testDB)
+	from := fundAccount(t, createRandomAccount(t), 10)
+	to1 := createRandomAccount(t)
+	to2 := createRandomAccount(t)
+	reference := sql.NullString{String: util.RandomString(10), Valid: true}
+
+	result, err := store.BulkTransferTx(context.Background(), BulkTransferTxParams{
+		FromAccountID: from.ID,
+		Items: []BulkTransferItem{
+			{ToAccountID: to1.ID, Amount: 5, ExternalReference: reference},
+			{ToAccountID: to2.ID, Amount: from.AvailableBalance},
+			// fails on the unique reference, which aborts the statement
+			{ToAccountID: to2.ID, Amount: 1, ExternalReference: reference},
+			{ToAccountID: to2.ID, Amount: 5},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result.Results, 4)
+	assert.NoError(t, result.Results[0].Err)
+	assert.ErrorIs(t, result.Results[1].Err, ErrInsufficientFunds)
+	require.ErrorIs(t, result.Results[2].Err, ErrUniqueViolation)
+	assert.Zero(t, result.Results[2].Transfer.Transfer.ID)
+	assert.NoError(t, result.Results[3].Err)
+	assert.Equal(t, from.Balance-10, result.FromAccount.Balance)
+
+	account, err := store.GetAccount(context.Background(), to2.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, to2.Balance+5, account.Balance)
+}
+
+func TestBulkTransferTxDeadlock(t *testing.T) {
+	store := NewStore(testDB)
+	acc1 := fundAccount(t, createRandomAccount(t), 100)
+	acc2 := fundAccount(t, createRandomAccount(t), 100)
+	acc3 := fundAccount(t, createRandomAccount(t), 100)
+	accounts := []Account{acc1, acc2, acc3}
+
+	n := 6
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		from := accounts[i%3]
+		// the recipients in the reverse order of their ids
+		items := []BulkTransferItem{
+			{ToAccountID: accounts[(i+2)%3].ID, Amount: 1},
+			{ToAccountID: accounts[(i+1)%3].ID, Amount: 1},
+		}
+		go func() {
+			_, err := store.BulkTransferTx(context.Background(), BulkTransferTxParams{
+				FromAccountID: from.ID,
+				Items:         items,
+				Atomic:        true,
+			})
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		assert.NoError(t, <-errs)
+	}
+
+	// every account sent and received the same amount
+	for _, account := range accounts {
+		updated, err := store.GetAccount(context.Background(), account.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, account.Balance, updated.Balance)
+	}
+}
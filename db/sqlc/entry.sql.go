@@ -1,59 +1,162 @@
 // Code generated by sqlc. DO NOT EDIT.
 // versions:
-//   sqlc v1.14.0
+//   sqlc v1.18.0
 // source: entry.sql
 
 package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
 )
 
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  transfer_id
 ) VALUES (
-  $1, $2
-) RETURNING id, account_id, amount, created_at
+  $1, $2, $3
+) RETURNING id, account_id, amount, created_at, transfer_id
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	AccountID  int64         `json:"account_id"`
+	Amount     int64         `json:"amount"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRowContext(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRow(ctx, createEntry, arg.AccountID, arg.Amount, arg.TransferID)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
 	)
 	return i, err
 }
 
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, transfer_id FROM entries
 WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
-	row := q.db.QueryRowContext(ctx, getEntry, id)
+	row := q.db.QueryRow(ctx, getEntry, id)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
 	)
 	return i, err
 }
 
+const getLastEntryID = `-- name: GetLastEntryID :one
+SELECT COALESCE(MAX(id), 0)::bigint AS last_entry_id FROM entries
+WHERE account_id = ANY($1::bigint[])
+`
+
+func (q *Queries) GetLastEntryID(ctx context.Context, accountIds []int64) (int64, error) {
+	row := q.db.QueryRow(ctx, getLastEntryID, accountIds)
+	var last_entry_id int64
+	err := row.Scan(&last_entry_id)
+	return last_entry_id, err
+}
+
+const getLastEntryIDBefore = `-- name: GetLastEntryIDBefore :one
+SELECT COALESCE(MAX(id), 0)::bigint AS last_entry_id FROM entries
+WHERE
+    account_id = ANY($1::bigint[]) AND
+    id <= $2 AND
+    created_at < $3
+`
+
+type GetLastEntryIDBeforeParams struct {
+	AccountIds    []int64   `json:"account_ids"`
+	MaxID         int64     `json:"max_id"`
+	CreatedBefore time.Time `json:"created_before"`
+}
+
+// Entries created before created_before belong to transactions that are long
+// committed, so the entry returned is a safe point to read new entries after.
+func (q *Queries) GetLastEntryIDBefore(ctx context.Context, arg GetLastEntryIDBeforeParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getLastEntryIDBefore, arg.AccountIds, arg.MaxID, arg.CreatedBefore)
+	var last_entry_id int64
+	err := row.Scan(&last_entry_id)
+	return last_entry_id, err
+}
+
+const listAccountEntries = `-- name: ListAccountEntries :many
+SELECT
+    entries.id, entries.account_id, entries.amount, entries.created_at, entries.transfer_id,
+    COALESCE(transfers.description, balance_adjustments.reason, '')::varchar AS description,
+    transfers.external_reference,
+    COALESCE(transfers.metadata, '{}')::jsonb AS metadata
+FROM entries
+LEFT JOIN transfers ON transfers.id = entries.transfer_id
+LEFT JOIN balance_adjustments ON balance_adjustments.entry_id = entries.id
+WHERE entries.account_id = $1
+ORDER BY entries.id DESC
+LIMIT $2
+OFFSET $3
+`
+
+type ListAccountEntriesParams struct {
+	AccountID int64 `json:"account_id"`
+	Limit     int32 `json:"limit"`
+	Offset    int32 `json:"offset"`
+}
+
+type ListAccountEntriesRow struct {
+	ID                int64           `json:"id"`
+	AccountID         int64           `json:"account_id"`
+	Amount            int64           `json:"amount"`
+	CreatedAt         time.Time       `json:"created_at"`
+	TransferID        sql.NullInt64   `json:"transfer_id"`
+	Description       string          `json:"description"`
+	ExternalReference sql.NullString  `json:"external_reference"`
+	Metadata          json.RawMessage `json:"metadata"`
+}
+
+func (q *Queries) ListAccountEntries(ctx context.Context, arg ListAccountEntriesParams) ([]ListAccountEntriesRow, error) {
+	rows, err := q.db.Query(ctx, listAccountEntries, arg.AccountID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountEntriesRow{}
+	for rows.Next() {
+		var i ListAccountEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+			&i.Description,
+			&i.ExternalReference,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, transfer_id FROM entries
 WHERE account_id = $1
 ORDER BY id
 LIMIT $2
@@ -67,7 +170,7 @@ type ListEntriesParams struct {
 }
 
 func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error) {
-	rows, err := q.db.QueryContext(ctx, listEntries, arg.AccountID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listEntries, arg.AccountID, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -80,14 +183,74 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.TransferID,
 		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
 	}
-	if err := rows.Close(); err != nil {
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntriesAfter = `-- name: ListEntriesAfter :many
+SELECT
+    entries.id, entries.account_id, entries.amount, entries.created_at, entries.transfer_id,
+    COALESCE(transfers.description, balance_adjustments.reason, '')::varchar AS description,
+    transfers.external_reference,
+    COALESCE(transfers.metadata, '{}')::jsonb AS metadata
+FROM entries
+LEFT JOIN transfers ON transfers.id = entries.transfer_id
+LEFT JOIN balance_adjustments ON balance_adjustments.entry_id = entries.id
+WHERE
+    entries.account_id = ANY($1::bigint[]) AND
+    entries.id > $2
+ORDER BY entries.id
+LIMIT $3
+`
+
+type ListEntriesAfterParams struct {
+	AccountIds []int64 `json:"account_ids"`
+	AfterID    int64   `json:"after_id"`
+	PageSize   int32   `json:"page_size"`
+}
+
+type ListEntriesAfterRow struct {
+	ID                int64           `json:"id"`
+	AccountID         int64           `json:"account_id"`
+	Amount            int64           `json:"amount"`
+	CreatedAt         time.Time       `json:"created_at"`
+	TransferID        sql.NullInt64   `json:"transfer_id"`
+	Description       string          `json:"description"`
+	ExternalReference sql.NullString  `json:"external_reference"`
+	Metadata          json.RawMessage `json:"metadata"`
+}
+
+func (q *Queries) ListEntriesAfter(ctx context.Context, arg ListEntriesAfterParams) ([]ListEntriesAfterRow, error) {
+	rows, err := q.db.Query(ctx, listEntriesAfter, arg.AccountIds, arg.AfterID, arg.PageSize)
+	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+	items := []ListEntriesAfterRow{}
+	for rows.Next() {
+		var i ListEntriesAfterRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+			&i.Description,
+			&i.ExternalReference,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+)
+
+var (
+	ErrAccountNotActive               = errors.New("account is not active")
+	ErrInvalidAccountStatusTransition = errors.New("invalid account status transition")
+	ErrAccountStatusChangeNotAllowed  = errors.New("account status change not allowed")
+	ErrAccountBalanceNotZero          = errors.New("account balance is not zero")
+	ErrTransferLimitExceeded          = errors.New("transfer limit exceeded")
+	ErrInsufficientFunds              = errors.New("insufficient funds")
+	ErrHoldNotActive                  = errors.New("hold is not active")
+	ErrHoldExpired                    = errors.New("hold has expired")
+	ErrTransferIsReversal             = errors.New("transfer is a reversal")
+	ErrReversalExceedsTransfer        = errors.New("reversal exceeds the amount left to reverse")
+)
+
+// checkAccountsActive returns an error wrapping ErrAccountNotActive for the
+// first account that is frozen or closed.
+func checkAccountsActive(accounts ...Account) error {
+	for _, account := range accounts {
+		if account.Status != util.AccountStatusActive {
+			return fmt.Errorf("account %d is %s: %w", account.ID, account.Status, ErrAccountNotActive)
+		}
+	}
+	return nil
+}
+
+// checkAvailableFunds returns an error wrapping ErrInsufficientFunds when the
+// money of the account that is not reserved by holds cannot cover amount.
+func checkAvailableFunds(account Account, amount int64) error {
+	if account.AvailableBalance < amount {
+		return fmt.Errorf("account %d has %d available: %w", account.ID, account.AvailableBalance, ErrInsufficientFunds)
+	}
+	return nil
+}
+
+// TransferLimitError is returned when a transfer would exceed one of the
+// limits of the source account. It wraps ErrTransferLimitExceeded.
+type TransferLimitError struct {
+	AccountID int64
+	// TransferLimitPerTransfer, TransferLimitDaily or TransferLimitMonthly
+	Limit string
+	Max   int64
+	// the largest amount the account can transfer right now under all of its
+	// limits, never negative
+	Remaining int64
+}
+
+func (e *TransferLimitError) Error() string {
+	return fmt.Sprintf("%s transfer limit of %d exceeded for account %d, remaining allowance is %d",
+		e.Limit, e.Max, e.AccountID, e.Remaining)
+}
+
+func (e *TransferLimitError) Unwrap() error {
+	return ErrTransferLimitExceeded
+}
+
+// BulkTransferItemError is returned by an all-or-nothing bulk transfer when
+// one of its items fails. It wraps the error of the item.
+type BulkTransferItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *BulkTransferItemError) Error() string {
+	return fmt.Sprintf("item %d: %s", e.Index, e.Err)
+}
+
+func (e *BulkTransferItemError) Unwrap() error {
+	return e.Err
+}
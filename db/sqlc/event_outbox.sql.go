@@ -0,0 +1,73 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.18.0
+// source: event_outbox.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const createOutboxEvent = `-- name: CreateOutboxEvent :exec
+INSERT INTO event_outbox (
+    event_name,
+    payload
+) VALUES (
+    $1, $2
+)
+`
+
+type CreateOutboxEventParams struct {
+	EventName string          `json:"event_name"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+func (q *Queries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error {
+	_, err := q.db.Exec(ctx, createOutboxEvent, arg.EventName, arg.Payload)
+	return err
+}
+
+const deleteOutboxEvents = `-- name: DeleteOutboxEvents :exec
+DELETE FROM event_outbox
+WHERE id = ANY($1::bigint[])
+`
+
+func (q *Queries) DeleteOutboxEvents(ctx context.Context, ids []int64) error {
+	_, err := q.db.Exec(ctx, deleteOutboxEvents, ids)
+	return err
+}
+
+const listOutboxEventsForUpdate = `-- name: ListOutboxEventsForUpdate :many
+SELECT id, event_name, payload, created_at FROM event_outbox
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED
+`
+
+// Locked events are being published by another worker and are skipped.
+func (q *Queries) ListOutboxEventsForUpdate(ctx context.Context, limit int32) ([]EventOutbox, error) {
+	rows, err := q.db.Query(ctx, listOutboxEventsForUpdate, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EventOutbox{}
+	for rows.Next() {
+		var i EventOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventName,
+			&i.Payload,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,21 @@
+package db
+
+import "github.com/NguyenMinhKhanhBK/simple_bank/events"
+
+func transferCompleted(result TransferTxResult) events.TransferCompleted {
+	transfer := result.Transfer
+	return events.TransferCompleted{
+		TransferID:        transfer.ID,
+		FromAccountID:     transfer.FromAccountID,
+		FromOwner:         result.FromAccount.Owner,
+		ToAccountID:       transfer.ToAccountID,
+		ToOwner:           result.ToAccount.Owner,
+		Amount:            transfer.Amount,
+		Currency:          result.FromAccount.Currency,
+		Description:       transfer.Description,
+		ExternalReference: transfer.ExternalReference.String,
+		Metadata:          transfer.Metadata,
+		ReversalOfID:      transfer.ReversalOfID.Int64,
+		CreatedAt:         transfer.CreatedAt,
+	}
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecordingStore() (Store, *[]events.Event) {
+	var published []events.Event
+	bus := events.NewBus()
+	bus.Subscribe("test", func(ctx context.Context, event events.Event) error {
+		published = append(published, event)
+		return nil
+	})
+	return NewStore(testDB, WithPublisher(bus)), &published
+}
+
+// publishEvents empties the outbox, which also holds the events of the other
+// tests.
+func publishEvents(t *testing.T, store Store) {
+	for {
+		taken, err := store.PublishEventsTx(context.Background(), 100)
+		require.NoError(t, err)
+		if taken == 0 {
+			return
+		}
+	}
+}
+
+func createRandomUserTx(t *testing.T, store Store) CreateUserTxResult {
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	result, err := store.CreateUserTx(context.Background(), CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:       util.RandomOwner(),
+			HashedPassword: hashedPassword,
+			FullName:       util.RandomOwner(),
+			Email:          util.RandomEmail(),
+		},
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func TestCreateUserTxPublishesEvent(t *testing.T) {
+	store, published := newRecordingStore()
+
+	result := createRandomUserTx(t, store)
+	require.Empty(t, *published)
+
+	publishEvents(t, store)
+	assert.Contains(t, *published, events.UserCreated{
+		Username:  result.User.Username,
+		FullName:  result.User.FullName,
+		Email:     result.User.Email,
+		CreatedAt: result.User.CreatedAt,
+	})
+}
+
+func TestTransferTxPublishesEvent(t *testing.T) {
+	store, published := newRecordingStore()
+
+	acc1 := fundAccount(t, createRandomAccount(t), 100)
+	acc2 := createRandomAccount(t)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	publishEvents(t, store)
+	var event events.TransferCompleted
+	for _, e := range *published {
+		if completed, ok := e.(events.TransferCompleted); ok && completed.TransferID == result.Transfer.ID {
+			event = completed
+		}
+	}
+	require.Equal(t, result.Transfer.ID, event.TransferID)
+	assert.Equal(t, acc1.Owner, event.FromOwner)
+	assert.Equal(t, acc2.Owner, event.ToOwner)
+	assert.Equal(t, int64(10), event.Amount)
+}
+
+func TestFailedTransferTxPublishesNothing(t *testing.T) {
+	store, published := newRecordingStore()
+	publishEvents(t, store)
+	*published = nil
+
+	acc1 := createRandomAccount(t)
+	acc2 := createRandomAccount(t)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	publishEvents(t, store)
+	for _, event := range *published {
+		if completed, ok := event.(events.TransferCompleted); ok {
+			assert.NotEqual(t, acc1.ID, completed.FromAccountID)
+		}
+	}
+}
+
+func TestPublishEventsTxKeepsFailedEvents(t *testing.T) {
+	recording, published := newRecordingStore()
+	publishEvents(t, recording)
+
+	bus := events.NewBus()
+	bus.Subscribe("failing", func(ctx context.Context, event events.Event) error {
+		return errors.New("failed")
+	})
+	store := NewStore(testDB, WithPublisher(bus))
+
+	result := createRandomUserTx(t, store)
+
+	taken, err := store.PublishEventsTx(context.Background(), 100)
+	require.ErrorContains(t, err, "failed")
+	require.Zero(t, taken)
+
+	// the event is still in the outbox
+	*published = nil
+	publishEvents(t, recording)
+	require.Len(t, *published, 1)
+	require.Equal(t, result.User.Username, (*published)[0].(events.UserCreated).Username)
+}
@@ -0,0 +1,141 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func createRandomHold(t *testing.T, store Store, from Account, to Account, amount int64, expiresAt time.Time) Hold {
+	result, err := store.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   from.ID,
+		ToAccountID: to.ID,
+		Amount:      amount,
+		ExpiresAt:   expiresAt,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, util.HoldStatusHeld, result.Hold.Status)
+	assert.Equal(t, amount, result.Hold.Amount)
+	assert.Equal(t, from.Balance, result.Account.Balance)
+	assert.Equal(t, from.HeldAmount+amount, result.Account.HeldAmount)
+	assert.Equal(t, from.AvailableBalance-amount, result.Account.AvailableBalance)
+
+	return result.Hold
+}
+
+func TestCreateHoldTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+	from := fundAccount(t, createRandomAccount(t), 10)
+	to := createRandomAccount(t)
+
+	createRandomHold(t, store, from, to, from.Balance, time.Now().Add(time.Hour))
+
+	// the held money can neither be held again nor transferred
+	_, err := store.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   from.ID,
+		ToAccountID: to.ID,
+		Amount:      1,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        1,
+	})
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestCaptureHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	from := fundAccount(t, createRandomAccount(t), 10)
+	to := createRandomAccount(t)
+	hold := createRandomHold(t, store, from, to, 10, time.Now().Add(time.Hour))
+
+	result, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: hold.ID,
+		Now:    time.Now(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, util.HoldStatusCaptured, result.Hold.Status)
+	assert.Equal(t, result.Transfer.Transfer.ID, result.Hold.TransferID.Int64)
+	assert.Equal(t, from.Balance-10, result.Transfer.FromAccount.Balance)
+	assert.Equal(t, from.HeldAmount, result.Transfer.FromAccount.HeldAmount)
+	assert.Equal(t, to.Balance+10, result.Transfer.ToAccount.Balance)
+
+	// a hold is captured at most once
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: hold.ID,
+		Now:    time.Now(),
+	})
+	assert.ErrorIs(t, err, ErrHoldNotActive)
+
+	_, err = store.ReleaseHoldTx(context.Background(), ReleaseHoldTxParams{
+		HoldID: hold.ID,
+		Now:    time.Now(),
+	})
+	assert.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestReleaseHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	from := fundAccount(t, createRandomAccount(t), 10)
+	to := createRandomAccount(t)
+	hold := createRandomHold(t, store, from, to, 10, time.Now().Add(time.Hour))
+
+	result, err := store.ReleaseHoldTx(context.Background(), ReleaseHoldTxParams{
+		HoldID: hold.ID,
+		Now:    time.Now(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, util.HoldStatusReleased, result.Hold.Status)
+	assert.False(t, result.Hold.TransferID.Valid)
+	assert.Equal(t, from.Balance, result.Account.Balance)
+	assert.Equal(t, from.AvailableBalance, result.Account.AvailableBalance)
+}
+
+func TestExpireHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	from := fundAccount(t, createRandomAccount(t), 10)
+	to := createRandomAccount(t)
+
+	now := time.Now()
+	hold := createRandomHold(t, store, from, to, 10, now.Add(time.Minute))
+
+	// not expired yet
+	result, err := store.ExpireHoldTx(context.Background(), ExpireHoldTxParams{
+		HoldID: hold.ID,
+		Now:    now,
+	})
+	assert.NoError(t, err)
+	assert.False(t, result.Expired)
+
+	later := now.Add(time.Minute)
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: hold.ID,
+		Now:    later,
+	})
+	assert.ErrorIs(t, err, ErrHoldExpired)
+
+	holds, err := store.ListExpiredHolds(context.Background(), ListExpiredHoldsParams{
+		Now:      later,
+		AfterID:  hold.ID - 1,
+		PageSize: 1,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, holds, 1)
+	assert.Equal(t, hold.ID, holds[0].ID)
+
+	result, err = store.ExpireHoldTx(context.Background(), ExpireHoldTxParams{
+		HoldID: hold.ID,
+		Now:    later,
+	})
+	assert.NoError(t, err)
+	assert.True(t, result.Expired)
+	assert.Equal(t, util.HoldStatusExpired, result.Hold.Status)
+	assert.Equal(t, from.AvailableBalance, result.Account.AvailableBalance)
+}
@@ -0,0 +1,320 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.18.0
+// source: interest.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createInterestAccrual = `-- name: CreateInterestAccrual :one
+INSERT INTO interest_accruals (
+    account_id,
+    accrual_date,
+    balance,
+    annual_interest_rate_bps,
+    amount
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (account_id, accrual_date) DO NOTHING
+RETURNING id, account_id, accrual_date, balance, annual_interest_rate_bps, amount, created_at
+`
+
+type CreateInterestAccrualParams struct {
+	AccountID             int64     `json:"account_id"`
+	AccrualDate           time.Time `json:"accrual_date"`
+	Balance               int64     `json:"balance"`
+	AnnualInterestRateBps int32     `json:"annual_interest_rate_bps"`
+	Amount                int64     `json:"amount"`
+}
+
+func (q *Queries) CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error) {
+	row := q.db.QueryRow(ctx, createInterestAccrual,
+		arg.AccountID,
+		arg.AccrualDate,
+		arg.Balance,
+		arg.AnnualInterestRateBps,
+		arg.Amount,
+	)
+	var i InterestAccrual
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.AccrualDate,
+		&i.Balance,
+		&i.AnnualInterestRateBps,
+		&i.Amount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createInterestPosting = `-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (
+    account_id,
+    period,
+    amount,
+    transfer_id
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, account_id, period, amount, transfer_id, created_at
+`
+
+type CreateInterestPostingParams struct {
+	AccountID  int64         `json:"account_id"`
+	Period     time.Time     `json:"period"`
+	Amount     int64         `json:"amount"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+}
+
+func (q *Queries) CreateInterestPosting(ctx context.Context, arg CreateInterestPostingParams) (InterestPosting, error) {
+	row := q.db.QueryRow(ctx, createInterestPosting,
+		arg.AccountID,
+		arg.Period,
+		arg.Amount,
+		arg.TransferID,
+	)
+	var i InterestPosting
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Period,
+		&i.Amount,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getAccountType = `-- name: GetAccountType :one
+SELECT name, annual_interest_rate_bps, created_at, max_transfer_amount, daily_transfer_limit, monthly_transfer_limit FROM account_types
+WHERE name = $1 LIMIT 1
+`
+
+func (q *Queries) GetAccountType(ctx context.Context, name string) (AccountType, error) {
+	row := q.db.QueryRow(ctx, getAccountType, name)
+	var i AccountType
+	err := row.Scan(
+		&i.Name,
+		&i.AnnualInterestRateBps,
+		&i.CreatedAt,
+		&i.MaxTransferAmount,
+		&i.DailyTransferLimit,
+		&i.MonthlyTransferLimit,
+	)
+	return i, err
+}
+
+const getEntriesSumSince = `-- name: GetEntriesSumSince :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total FROM entries
+WHERE account_id = $1 AND created_at >= $2
+`
+
+type GetEntriesSumSinceParams struct {
+	AccountID int64     `json:"account_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (q *Queries) GetEntriesSumSince(ctx context.Context, arg GetEntriesSumSinceParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getEntriesSumSince, arg.AccountID, arg.CreatedAt)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
+
+const getInterestPosting = `-- name: GetInterestPosting :one
+SELECT id, account_id, period, amount, transfer_id, created_at FROM interest_postings
+WHERE account_id = $1 AND period = $2 LIMIT 1
+`
+
+type GetInterestPostingParams struct {
+	AccountID int64     `json:"account_id"`
+	Period    time.Time `json:"period"`
+}
+
+func (q *Queries) GetInterestPosting(ctx context.Context, arg GetInterestPostingParams) (InterestPosting, error) {
+	row := q.db.QueryRow(ctx, getInterestPosting, arg.AccountID, arg.Period)
+	var i InterestPosting
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Period,
+		&i.Amount,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAccountTypes = `-- name: ListAccountTypes :many
+SELECT name, annual_interest_rate_bps, created_at, max_transfer_amount, daily_transfer_limit, monthly_transfer_limit FROM account_types
+ORDER BY name
+`
+
+func (q *Queries) ListAccountTypes(ctx context.Context) ([]AccountType, error) {
+	rows, err := q.db.Query(ctx, listAccountTypes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AccountType{}
+	for rows.Next() {
+		var i AccountType
+		if err := rows.Scan(
+			&i.Name,
+			&i.AnnualInterestRateBps,
+			&i.CreatedAt,
+			&i.MaxTransferAmount,
+			&i.DailyTransferLimit,
+			&i.MonthlyTransferLimit,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsWithAccruedInterest = `-- name: ListAccountsWithAccruedInterest :many
+SELECT id, owner, balance, currency, created_at, account_type, accrued_interest, status, held_amount, available_balance FROM accounts
+WHERE
+    accrued_interest >= $1 AND
+    status = 'active' AND
+    id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ListAccountsWithAccruedInterestParams struct {
+	MinAccruedInterest int64 `json:"min_accrued_interest"`
+	AfterID            int64 `json:"after_id"`
+	PageSize           int32 `json:"page_size"`
+}
+
+func (q *Queries) ListAccountsWithAccruedInterest(ctx context.Context, arg ListAccountsWithAccruedInterestParams) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listAccountsWithAccruedInterest, arg.MinAccruedInterest, arg.AfterID, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.AccountType,
+			&i.AccruedInterest,
+			&i.Status,
+			&i.HeldAmount,
+			&i.AvailableBalance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInterestAccruals = `-- name: ListInterestAccruals :many
+SELECT id, account_id, accrual_date, balance, annual_interest_rate_bps, amount, created_at FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrual_date
+LIMIT $2
+OFFSET $3
+`
+
+type ListInterestAccrualsParams struct {
+	AccountID int64 `json:"account_id"`
+	Limit     int32 `json:"limit"`
+	Offset    int32 `json:"offset"`
+}
+
+func (q *Queries) ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error) {
+	rows, err := q.db.Query(ctx, listInterestAccruals, arg.AccountID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []InterestAccrual{}
+	for rows.Next() {
+		var i InterestAccrual
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.AccrualDate,
+			&i.Balance,
+			&i.AnnualInterestRateBps,
+			&i.Amount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInterestBearingAccounts = `-- name: ListInterestBearingAccounts :many
+SELECT accounts.id, accounts.owner, accounts.balance, accounts.currency, accounts.created_at, accounts.account_type, accounts.accrued_interest, accounts.status, accounts.held_amount, accounts.available_balance FROM accounts
+JOIN account_types ON account_types.name = accounts.account_type
+WHERE
+    account_types.annual_interest_rate_bps > 0 AND
+    accounts.status <> 'closed' AND
+    accounts.id > $1
+ORDER BY accounts.id
+LIMIT $2
+`
+
+type ListInterestBearingAccountsParams struct {
+	AfterID  int64 `json:"after_id"`
+	PageSize int32 `json:"page_size"`
+}
+
+func (q *Queries) ListInterestBearingAccounts(ctx context.Context, arg ListInterestBearingAccountsParams) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listInterestBearingAccounts, arg.AfterID, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.AccountType,
+			&i.AccruedInterest,
+			&i.Status,
+			&i.HeldAmount,
+			&i.AvailableBalance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func createRandomSavingsAccount(t *testing.T, balance int64) Account {
+	user := createRandomUser(t)
+
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:       user.Username,
+		Balance:     balance,
+		Currency:    util.USD,
+		AccountType: util.AccountTypeSavings,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, util.AccountTypeSavings, account.AccountType)
+	assert.Zero(t, account.AccruedInterest)
+
+	return account
+}
+
+func TestAccrueInterestTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomSavingsAccount(t, 100000)
+
+	accountType, err := store.GetAccountType(context.Background(), util.AccountTypeSavings)
+	assert.NoError(t, err)
+
+	day := time.Now().UTC()
+	expected := util.DailyInterest(account.Balance, accountType.AnnualInterestRateBps, day)
+
+	result, err := store.AccrueInterestTx(context.Background(), AccrueInterestTxParams{
+		AccountID:   account.ID,
+		AccrualDate: day,
+	})
+	assert.NoError(t, err)
+	assert.True(t, result.Accrued)
+	assert.Equal(t, account.ID, result.Accrual.AccountID)
+	assert.Equal(t, account.Balance, result.Accrual.Balance)
+	assert.Equal(t, accountType.AnnualInterestRateBps, result.Accrual.AnnualInterestRateBps)
+	assert.Equal(t, expected, result.Accrual.Amount)
+	assert.Equal(t, expected, result.Account.AccruedInterest)
+	assert.Equal(t, account.Balance, result.Account.Balance)
+
+	// accruing the same day again is a no-op
+	result, err = store.AccrueInterestTx(context.Background(), AccrueInterestTxParams{
+		AccountID:   account.ID,
+		AccrualDate: day,
+	})
+	assert.NoError(t, err)
+	assert.False(t, result.Accrued)
+	assert.Equal(t, expected, result.Account.AccruedInterest)
+
+	// the account did not exist the day before
+	result, err = store.AccrueInterestTx(context.Background(), AccrueInterestTxParams{
+		AccountID:   account.ID,
+		AccrualDate: day.AddDate(0, 0, -1),
+	})
+	assert.NoError(t, err)
+	assert.False(t, result.Accrued)
+	assert.Equal(t, expected, result.Account.AccruedInterest)
+}
+
+func TestPostInterestTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomSavingsAccount(t, 0)
+
+	account, err := store.AddAccountAccruedInterest(context.Background(), AddAccountAccruedInterestParams{
+		Amount: 3*util.InterestScale + 1234,
+		ID:     account.ID,
+	})
+	assert.NoError(t, err)
+
+	period := time.Now()
+	result, err := store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Period:    period,
+	})
+	assert.NoError(t, err)
+	assert.True(t, result.Posted)
+	assert.Equal(t, int64(3), result.Posting.Amount)
+	assert.True(t, result.Posting.TransferID.Valid)
+	assert.Equal(t, result.Transfer.Transfer.ID, result.Posting.TransferID.Int64)
+	assert.Equal(t, account.ID, result.Transfer.Transfer.ToAccountID)
+	assert.Equal(t, int64(3), result.Transfer.ToEntry.Amount)
+	assert.Equal(t, util.SystemUsername, result.Transfer.FromAccount.Owner)
+	assert.Equal(t, account.Currency, result.Transfer.FromAccount.Currency)
+
+	// the remainder below one unit is carried over
+	assert.Equal(t, int64(1234), result.Account.AccruedInterest)
+	assert.Equal(t, int64(3), result.Account.Balance)
+
+	// posting the same period again is a no-op
+	again, err := store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		Period:    period,
+	})
+	assert.NoError(t, err)
+	assert.False(t, again.Posted)
+	assert.Equal(t, result.Posting.ID, again.Posting.ID)
+	assert.Equal(t, int64(1234), again.Account.AccruedInterest)
+}
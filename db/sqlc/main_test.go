@@ -1,17 +1,17 @@
 package db
 
 import (
-	"database/sql"
+	"context"
 	"os"
 	"testing"
 
 	"github.com/NguyenMinhKhanhBK/simple_bank/util"
-	_ "github.com/lib/pq"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sirupsen/logrus"
 )
 
 var testQueries *Queries
-var testDB *sql.DB
+var testDB *pgxpool.Pool
 
 func TestMain(m *testing.M) {
 	config, err := util.LoadConfig("../..")
@@ -19,7 +19,7 @@ func TestMain(m *testing.M) {
 		logrus.Fatal("cannot load config:", err)
 	}
 
-	testDB, err = sql.Open(config.DBDriver, config.DBSource)
+	testDB, err = NewPool(context.Background(), config.DBSource, config)
 	if err != nil {
 		logrus.Fatal("cannot connect to db:", err)
 	}
@@ -1,31 +1,170 @@
 // Code generated by sqlc. DO NOT EDIT.
 // versions:
-//   sqlc v1.14.0
+//   sqlc v1.18.0
 
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Account struct {
-	ID        int64     `json:"id"`
-	Owner     string    `json:"owner"`
-	Balance   int64     `json:"balance"`
-	Currency  string    `json:"currency"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          int64     `json:"id"`
+	Owner       string    `json:"owner"`
+	Balance     int64     `json:"balance"`
+	Currency    string    `json:"currency"`
+	CreatedAt   time.Time `json:"created_at"`
+	AccountType string    `json:"account_type"`
+	// unposted interest in millionths of the minor currency unit
+	AccruedInterest int64 `json:"accrued_interest"`
+	// active, frozen or closed
+	Status string `json:"status"`
+	// sum of the amounts of the active holds on the account
+	HeldAmount int64 `json:"held_amount"`
+	// balance that is not reserved by holds
+	AvailableBalance int64 `json:"available_balance"`
+}
+
+type AccountStatusChange struct {
+	ID         int64     `json:"id"`
+	AccountID  int64     `json:"account_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	ChangedBy  string    `json:"changed_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AccountType struct {
+	Name string `json:"name"`
+	// annual interest rate in basis points
+	AnnualInterestRateBps int32     `json:"annual_interest_rate_bps"`
+	CreatedAt             time.Time `json:"created_at"`
+	// largest single outgoing transfer, NULL for no limit
+	MaxTransferAmount sql.NullInt64 `json:"max_transfer_amount"`
+	// outgoing transfers per UTC day, NULL for no limit
+	DailyTransferLimit sql.NullInt64 `json:"daily_transfer_limit"`
+	// outgoing transfers per UTC month, NULL for no limit
+	MonthlyTransferLimit sql.NullInt64 `json:"monthly_transfer_limit"`
+}
+
+// audit record of a manual correction of a balance
+type BalanceAdjustment struct {
+	ID         int64     `json:"id"`
+	AccountID  int64     `json:"account_id"`
+	EntryID    int64     `json:"entry_id"`
+	Amount     int64     `json:"amount"`
+	Reason     string    `json:"reason"`
+	AdjustedBy string    `json:"adjusted_by"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 type Entry struct {
 	ID        int64 `json:"id"`
 	AccountID int64 `json:"account_id"`
 	// can be positive or negative
+	Amount     int64         `json:"amount"`
+	CreatedAt  time.Time     `json:"created_at"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+}
+
+// domain events written by the transactions that made them happen, deleted once published by the worker
+type EventOutbox struct {
+	ID        int64           `json:"id"`
+	EventName string          `json:"event_name"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type Hold struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// account that receives the money when the hold is captured
+	ToAccountID int64 `json:"to_account_id"`
+	Amount      int64 `json:"amount"`
+	// held, captured, released or expired
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// transfer the hold was captured into
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+type InterestAccrual struct {
+	ID                    int64     `json:"id"`
+	AccountID             int64     `json:"account_id"`
+	AccrualDate           time.Time `json:"accrual_date"`
+	Balance               int64     `json:"balance"`
+	AnnualInterestRateBps int32     `json:"annual_interest_rate_bps"`
+	// in millionths of the minor currency unit
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type InterestPosting struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// first day of the month the interest was posted for
+	Period     time.Time     `json:"period"`
+	Amount     int64         `json:"amount"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type Payee struct {
+	ID       int64  `json:"id"`
+	Owner    string `json:"owner"`
+	Nickname string `json:"nickname"`
+	// target account, NULL when the target is a username
+	AccountID sql.NullInt64 `json:"account_id"`
+	// target user, whose account in the currency receives the transfer
+	Username sql.NullString `json:"username"`
+	Currency string         `json:"currency"`
+	// end of the cooling-off period, no transfer to the payee before it
+	AvailableAt time.Time `json:"available_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ScheduledTransfer struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	// cron expression, NULL for a one-off transfer
+	Recurrence sql.NullString `json:"recurrence"`
+	NextRunAt  time.Time      `json:"next_run_at"`
+	EndAt      sql.NullTime   `json:"end_at"`
+	Status     string         `json:"status"`
+	// consecutive failed executions, reset on success
+	FailedAttempts int32     `json:"failed_attempts"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type ScheduledTransferExecution struct {
+	ID                  int64         `json:"id"`
+	ScheduledTransferID int64         `json:"scheduled_transfer_id"`
+	ScheduledAt         time.Time     `json:"scheduled_at"`
+	Status              string        `json:"status"`
+	TransferID          sql.NullInt64 `json:"transfer_id"`
+	FailureReason       string        `json:"failure_reason"`
+	CreatedAt           time.Time     `json:"created_at"`
+}
+
+// runs of the seed command, a run that did not complete left a partly seeded database
+type SeedRun struct {
+	ID          int64        `json:"id"`
+	StartedAt   time.Time    `json:"started_at"`
+	CompletedAt sql.NullTime `json:"completed_at"`
+}
+
 type Session struct {
 	ID           uuid.UUID `json:"id"`
 	Username     string    `json:"username"`
@@ -37,6 +176,27 @@ type Session struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+type StatementExport struct {
+	ID          int64     `json:"id"`
+	Owner       string    `json:"owner"`
+	AccountID   int64     `json:"account_id"`
+	Format      string    `json:"format"`
+	PeriodStart time.Time `json:"period_start"`
+	// exclusive
+	PeriodEnd   time.Time    `json:"period_end"`
+	Status      string       `json:"status"`
+	Error       string       `json:"error"`
+	CreatedAt   time.Time    `json:"created_at"`
+	CompletedAt sql.NullTime `json:"completed_at"`
+}
+
+// files of the completed statement exports, kept in the database so that the gateway can serve what the worker generated
+type StatementFile struct {
+	ExportID  int64     `json:"export_id"`
+	Content   []byte    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type Transfer struct {
 	ID            int64 `json:"id"`
 	FromAccountID int64 `json:"from_account_id"`
@@ -44,6 +204,21 @@ type Transfer struct {
 	// must be positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// transfer this one reverses, fully or partially
+	ReversalOfID sql.NullInt64 `json:"reversal_of_id"`
+	Description  string        `json:"description"`
+	// supplied by the client, unique per source account
+	ExternalReference sql.NullString `json:"external_reference"`
+	// JSON object supplied by the client
+	Metadata json.RawMessage `json:"metadata"`
+}
+
+// audit record of the compensating transfer of a reversal
+type TransferReversal struct {
+	TransferID int64     `json:"transfer_id"`
+	Reason     string    `json:"reason"`
+	ReversedBy string    `json:"reversed_by"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 type User struct {
@@ -53,4 +228,43 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	// depositor or admin
+	Role string `json:"role"`
+	// blocked users cannot log in
+	IsBlocked bool `json:"is_blocked"`
+}
+
+// overrides the limits of the account types for all accounts of the user, a NULL column keeps the limit of the account type
+type UserTransferLimit struct {
+	Username             string        `json:"username"`
+	MaxTransferAmount    sql.NullInt64 `json:"max_transfer_amount"`
+	DailyTransferLimit   sql.NullInt64 `json:"daily_transfer_limit"`
+	MonthlyTransferLimit sql.NullInt64 `json:"monthly_transfer_limit"`
+	UpdatedBy            string        `json:"updated_by"`
+	UpdatedAt            time.Time     `json:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	// pending, succeeded or dead
+	Status           string         `json:"status"`
+	Attempts         int32          `json:"attempts"`
+	LastResponseCode sql.NullInt32  `json:"last_response_code"`
+	LastError        sql.NullString `json:"last_error"`
+	DeliveredAt      sql.NullTime   `json:"delivered_at"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+type WebhookSubscription struct {
+	ID    int64  `json:"id"`
+	Owner string `json:"owner"`
+	Url   string `json:"url"`
+	// key of the HMAC-SHA256 signature of the deliveries
+	Secret     string    `json:"secret"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
 }
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultEventRelayInterval is how often RelayEvents looks for new events when
+// no interval is configured.
+const DefaultEventRelayInterval = time.Second
+
+// eventRelayBatchSize is how many events a PublishEventsTx of RelayEvents
+// takes off the outbox.
+const eventRelayBatchSize = 100
+
+// execTxWithEvents runs fn like execTx and, when fn succeeds, writes the
+// events returned by pending to the outbox within the same transaction. The
+// events are thus published if and only if the transaction commits. See
+// WithoutEvents.
+func (s *SQLStore) execTxWithEvents(ctx context.Context, fn func(*Queries) error, pending func() []events.Event) error {
+	return s.execTx(ctx, func(q *Queries) error {
+		if err := fn(q); err != nil || s.withoutEvents {
+			return err
+		}
+		return saveEvents(ctx, q, pending()...)
+	})
+}
+
+func saveEvents(ctx context.Context, q *Queries, pending ...events.Event) error {
+	for _, event := range pending {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event %s: %w", event.EventName(), err)
+		}
+
+		err = q.CreateOutboxEvent(ctx, CreateOutboxEventParams{
+			EventName: event.EventName(),
+			Payload:   payload,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PublishEventsTx publishes the oldest events of the outbox, up to limit, and
+// deletes the ones that were published. It stops at the first event that fails
+// to publish, which stays in the outbox to be published again. It returns how
+// many events it took off the outbox.
+func (s *SQLStore) PublishEventsTx(ctx context.Context, limit int32) (int, error) {
+	var taken int
+	var publishErr error
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		outbox, err := q.ListOutboxEventsForUpdate(ctx, limit)
+		if err != nil {
+			return err
+		}
+
+		done := make([]int64, 0, len(outbox))
+		for _, row := range outbox {
+			event, err := events.Decode(row.EventName, row.Payload)
+			if err != nil {
+				// it would block the events after it forever
+				logrus.WithError(err).WithField("event_id", row.ID).Error("dropped event that cannot be decoded")
+				done = append(done, row.ID)
+				continue
+			}
+
+			if publishErr = s.publisher.Publish(ctx, event); publishErr != nil {
+				break
+			}
+			done = append(done, row.ID)
+		}
+
+		taken = len(done)
+		if taken == 0 {
+			return nil
+		}
+		return q.DeleteOutboxEvents(ctx, done)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return taken, publishErr
+}
+
+// RelayEvents publishes the events of the outbox until ctx is done, looking for
+// new ones every interval.
+func RelayEvents(ctx context.Context, store Store, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultEventRelayInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			taken, err := store.PublishEventsTx(ctx, eventRelayBatchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					logrus.WithError(err).Error("failed to publish events")
+				}
+				break
+			}
+			if taken < eventRelayBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
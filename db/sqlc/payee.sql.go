@@ -0,0 +1,166 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.18.0
+// source: payee.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createPayee = `-- name: CreatePayee :one
+INSERT INTO payees (
+    owner,
+    nickname,
+    account_id,
+    username,
+    currency,
+    available_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, owner, nickname, account_id, username, currency, available_at, created_at, updated_at
+`
+
+type CreatePayeeParams struct {
+	Owner       string         `json:"owner"`
+	Nickname    string         `json:"nickname"`
+	AccountID   sql.NullInt64  `json:"account_id"`
+	Username    sql.NullString `json:"username"`
+	Currency    string         `json:"currency"`
+	AvailableAt time.Time      `json:"available_at"`
+}
+
+func (q *Queries) CreatePayee(ctx context.Context, arg CreatePayeeParams) (Payee, error) {
+	row := q.db.QueryRow(ctx, createPayee,
+		arg.Owner,
+		arg.Nickname,
+		arg.AccountID,
+		arg.Username,
+		arg.Currency,
+		arg.AvailableAt,
+	)
+	var i Payee
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Nickname,
+		&i.AccountID,
+		&i.Username,
+		&i.Currency,
+		&i.AvailableAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deletePayee = `-- name: DeletePayee :exec
+DELETE FROM payees
+WHERE id = $1
+`
+
+func (q *Queries) DeletePayee(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deletePayee, id)
+	return err
+}
+
+const getPayee = `-- name: GetPayee :one
+SELECT id, owner, nickname, account_id, username, currency, available_at, created_at, updated_at FROM payees
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetPayee(ctx context.Context, id int64) (Payee, error) {
+	row := q.db.QueryRow(ctx, getPayee, id)
+	var i Payee
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Nickname,
+		&i.AccountID,
+		&i.Username,
+		&i.Currency,
+		&i.AvailableAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listPayees = `-- name: ListPayees :many
+SELECT id, owner, nickname, account_id, username, currency, available_at, created_at, updated_at FROM payees
+WHERE owner = $1
+ORDER BY nickname
+LIMIT $2
+OFFSET $3
+`
+
+type ListPayeesParams struct {
+	Owner  string `json:"owner"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+func (q *Queries) ListPayees(ctx context.Context, arg ListPayeesParams) ([]Payee, error) {
+	rows, err := q.db.Query(ctx, listPayees, arg.Owner, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Payee{}
+	for rows.Next() {
+		var i Payee
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Nickname,
+			&i.AccountID,
+			&i.Username,
+			&i.Currency,
+			&i.AvailableAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updatePayee = `-- name: UpdatePayee :one
+UPDATE payees
+SET
+    nickname = $1,
+    updated_at = now()
+WHERE
+    id = $2
+RETURNING id, owner, nickname, account_id, username, currency, available_at, created_at, updated_at
+`
+
+type UpdatePayeeParams struct {
+	Nickname string `json:"nickname"`
+	ID       int64  `json:"id"`
+}
+
+func (q *Queries) UpdatePayee(ctx context.Context, arg UpdatePayeeParams) (Payee, error) {
+	row := q.db.QueryRow(ctx, updatePayee, arg.Nickname, arg.ID)
+	var i Payee
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Nickname,
+		&i.AccountID,
+		&i.Username,
+		&i.Currency,
+		&i.AvailableAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func createRandomPayee(t *testing.T, owner string, target Account) Payee {
+	arg := CreatePayeeParams{
+		Owner:       owner,
+		Nickname:    util.RandomOwner(),
+		AccountID:   sql.NullInt64{Int64: target.ID, Valid: true},
+		Currency:    target.Currency,
+		AvailableAt: time.Now().Add(time.Hour),
+	}
+
+	payee, err := testQueries.CreatePayee(context.Background(), arg)
+	assert.NoError(t, err)
+	assert.Equal(t, arg.Owner, payee.Owner)
+	assert.Equal(t, arg.Nickname, payee.Nickname)
+	assert.Equal(t, arg.AccountID, payee.AccountID)
+	assert.False(t, payee.Username.Valid)
+	assert.Equal(t, arg.Currency, payee.Currency)
+	assert.WithinDuration(t, arg.AvailableAt, payee.AvailableAt, time.Second)
+
+	return payee
+}
+
+func TestCreatePayee(t *testing.T) {
+	owner := createRandomUser(t)
+	createRandomPayee(t, owner.Username, createRandomAccount(t))
+}
+
+func TestCreatePayeeDuplicateNickname(t *testing.T) {
+	owner := createRandomUser(t)
+	payee := createRandomPayee(t, owner.Username, createRandomAccount(t))
+
+	_, err := testQueries.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:     owner.Username,
+		Nickname:  payee.Nickname,
+		AccountID: payee.AccountID,
+		Currency:  payee.Currency,
+	})
+	assert.Error(t, err)
+	var pgErr *pgconn.PgError
+	assert.True(t, errors.As(err, &pgErr))
+	assert.Equal(t, uniqueViolation, pgErr.Code)
+}
+
+func TestCreatePayeeRequiresOneTarget(t *testing.T) {
+	owner := createRandomUser(t)
+	target := createRandomAccount(t)
+
+	_, err := testQueries.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:     owner.Username,
+		Nickname:  util.RandomOwner(),
+		AccountID: sql.NullInt64{Int64: target.ID, Valid: true},
+		Username:  sql.NullString{String: target.Owner, Valid: true},
+		Currency:  target.Currency,
+	})
+	assert.Error(t, err)
+}
+
+func TestUpdatePayee(t *testing.T) {
+	owner := createRandomUser(t)
+	payee := createRandomPayee(t, owner.Username, createRandomAccount(t))
+
+	nickname := util.RandomOwner()
+	updated, err := testQueries.UpdatePayee(context.Background(), UpdatePayeeParams{
+		ID:       payee.ID,
+		Nickname: nickname,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, nickname, updated.Nickname)
+	assert.Equal(t, payee.AccountID, updated.AccountID)
+	assert.WithinDuration(t, payee.AvailableAt, updated.AvailableAt, time.Second)
+}
+
+func TestListPayees(t *testing.T) {
+	owner := createRandomUser(t)
+	for i := 0; i < 3; i++ {
+		createRandomPayee(t, owner.Username, createRandomAccount(t))
+	}
+
+	payees, err := testQueries.ListPayees(context.Background(), ListPayeesParams{
+		Owner:  owner.Username,
+		Limit:  5,
+		Offset: 0,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, payees, 3)
+	for _, payee := range payees {
+		assert.Equal(t, owner.Username, payee.Owner)
+	}
+}
+
+func TestDeletePayee(t *testing.T) {
+	owner := createRandomUser(t)
+	payee := createRandomPayee(t, owner.Username, createRandomAccount(t))
+
+	err := testQueries.DeletePayee(context.Background(), payee.ID)
+	assert.NoError(t, err)
+
+	_, err = testQueries.GetPayee(context.Background(), payee.ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
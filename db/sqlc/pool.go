@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// queryExecModes are the values of DB_QUERY_EXEC_MODE. Poolers in transaction
+// mode, e.g. PgBouncer, need exec or simple_protocol, which do not use
+// prepared statements.
+var queryExecModes = map[string]pgx.QueryExecMode{
+	"cache_statement": pgx.QueryExecModeCacheStatement,
+	"cache_describe":  pgx.QueryExecModeCacheDescribe,
+	"describe_exec":   pgx.QueryExecModeDescribeExec,
+	"exec":            pgx.QueryExecModeExec,
+	"simple_protocol": pgx.QueryExecModeSimpleProtocol,
+}
+
+// PoolConfig parses the DSN and applies the pool settings of the config.
+// Settings left at zero keep the defaults of pgxpool.
+func PoolConfig(dsn string, config util.Config) (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse db source: %w", err)
+	}
+
+	if config.DBMaxConns > 0 {
+		poolConfig.MaxConns = config.DBMaxConns
+	}
+	if config.DBMinConns > 0 {
+		poolConfig.MinConns = config.DBMinConns
+	}
+	if config.DBMaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = config.DBMaxConnLifetime
+	}
+	if config.DBMaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = config.DBMaxConnIdleTime
+	}
+
+	if config.DBQueryExecMode != "" {
+		mode, ok := queryExecModes[config.DBQueryExecMode]
+		if !ok {
+			return nil, fmt.Errorf("unsupported query exec mode: %s", config.DBQueryExecMode)
+		}
+		poolConfig.ConnConfig.DefaultQueryExecMode = mode
+	}
+	if config.DBStatementCacheCapacity > 0 {
+		poolConfig.ConnConfig.StatementCacheCapacity = config.DBStatementCacheCapacity
+		poolConfig.ConnConfig.DescriptionCacheCapacity = config.DBStatementCacheCapacity
+	}
+
+	return poolConfig, nil
+}
+
+// NewPool connects to the database and checks the connection.
+func NewPool(ctx context.Context, dsn string, config util.Config) (*pgxpool.Pool, error) {
+	poolConfig, err := PoolConfig(dsn, config)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to db: %w", err)
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("cannot ping db: %w", err)
+	}
+
+	return pool, nil
+}
@@ -1,34 +1,124 @@
 // Code generated by sqlc. DO NOT EDIT.
 // versions:
-//   sqlc v1.14.0
+//   sqlc v1.18.0
 
 package db
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AddAccountAccruedInterest(ctx context.Context, arg AddAccountAccruedInterestParams) (Account, error)
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AddAccountHeldAmount(ctx context.Context, arg AddAccountHeldAmountParams) (Account, error)
+	// Blocks the sessions of the user that can still be used to renew tokens.
+	BlockUserSessions(ctx context.Context, username string) ([]Session, error)
+	CompleteSeedRun(ctx context.Context, id int64) (SeedRun, error)
+	CompleteStatementExport(ctx context.Context, arg CompleteStatementExportParams) (StatementExport, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountStatusChange(ctx context.Context, arg CreateAccountStatusChangeParams) (AccountStatusChange, error)
+	CreateBalanceAdjustment(ctx context.Context, arg CreateBalanceAdjustmentParams) (BalanceAdjustment, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error)
+	CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error)
+	CreateInterestPosting(ctx context.Context, arg CreateInterestPostingParams) (InterestPosting, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error
+	CreatePayee(ctx context.Context, arg CreatePayeeParams) (Payee, error)
+	CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error)
+	CreateScheduledTransferExecution(ctx context.Context, arg CreateScheduledTransferExecutionParams) (ScheduledTransferExecution, error)
+	CreateSeedRun(ctx context.Context) (SeedRun, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateStatementExport(ctx context.Context, arg CreateStatementExportParams) (StatementExport, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferReversal(ctx context.Context, arg CreateTransferReversalParams) (TransferReversal, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteOutboxEvents(ctx context.Context, ids []int64) error
+	DeletePayee(ctx context.Context, id int64) error
+	DeleteWebhookSubscription(ctx context.Context, id int64) error
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountByOwnerAndCurrency(ctx context.Context, arg GetAccountByOwnerAndCurrencyParams) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAccountType(ctx context.Context, name string) (AccountType, error)
+	GetEntriesSumSince(ctx context.Context, arg GetEntriesSumSinceParams) (int64, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetHold(ctx context.Context, id int64) (Hold, error)
+	GetHoldForUpdate(ctx context.Context, id int64) (Hold, error)
+	GetInterestPosting(ctx context.Context, arg GetInterestPostingParams) (InterestPosting, error)
+	GetLastEntryID(ctx context.Context, accountIds []int64) (int64, error)
+	// Entries created before created_before belong to transactions that are long
+	// committed, so the entry returned is a safe point to read new entries after.
+	GetLastEntryIDBefore(ctx context.Context, arg GetLastEntryIDBeforeParams) (int64, error)
+	GetLastSeedRun(ctx context.Context) (SeedRun, error)
+	// The day starts within the month, so both totals come from a single scan of
+	// the transfers of the month. Only the transfers made by the customer count:
+	// reversals and postings to the system accounts are made by the bank.
+	GetOutgoingTransferTotals(ctx context.Context, arg GetOutgoingTransferTotalsParams) (GetOutgoingTransferTotalsRow, error)
+	GetPayee(ctx context.Context, id int64) (Payee, error)
+	// Resolves the account of the recipient of a transfer by username or email in
+	// a single query, so that a missing user, a missing account and an account
+	// that cannot receive money all look the same to the caller.
+	GetRecipientAccount(ctx context.Context, arg GetRecipientAccountParams) (Account, error)
+	GetReversedAmount(ctx context.Context, reversalOfID sql.NullInt64) (int64, error)
+	GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
+	GetScheduledTransferForUpdate(ctx context.Context, id int64) (ScheduledTransfer, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	// Computed in one statement so that the balances and last_entry_id come from
+	// the same snapshot. Entries after last_entry_id are not part of the statement.
+	GetStatementBalances(ctx context.Context, arg GetStatementBalancesParams) (GetStatementBalancesRow, error)
+	GetStatementExport(ctx context.Context, id int64) (StatementExport, error)
+	GetStatementFile(ctx context.Context, exportID int64) (StatementFile, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error)
+	GetTransferLimits(ctx context.Context, id int64) (GetTransferLimitsRow, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserTransferLimits(ctx context.Context, username string) (UserTransferLimit, error)
+	GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+	GetWebhookSubscription(ctx context.Context, id int64) (WebhookSubscription, error)
+	ListAccountEntries(ctx context.Context, arg ListAccountEntriesParams) ([]ListAccountEntriesRow, error)
+	ListAccountIDs(ctx context.Context, owner string) ([]int64, error)
+	ListAccountStatusChanges(ctx context.Context, arg ListAccountStatusChangesParams) ([]AccountStatusChange, error)
+	ListAccountTransfers(ctx context.Context, arg ListAccountTransfersParams) ([]Transfer, error)
+	ListAccountTypes(ctx context.Context) ([]AccountType, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListAccountsWithAccruedInterest(ctx context.Context, arg ListAccountsWithAccruedInterestParams) ([]Account, error)
+	ListDueScheduledTransfers(ctx context.Context, arg ListDueScheduledTransfersParams) ([]ScheduledTransfer, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListEntriesAfter(ctx context.Context, arg ListEntriesAfterParams) ([]ListEntriesAfterRow, error)
+	ListExpiredHolds(ctx context.Context, arg ListExpiredHoldsParams) ([]Hold, error)
+	ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error)
+	ListInterestBearingAccounts(ctx context.Context, arg ListInterestBearingAccountsParams) ([]Account, error)
+	// Locked events are being published by another worker and are skipped.
+	ListOutboxEventsForUpdate(ctx context.Context, limit int32) ([]EventOutbox, error)
+	ListPayees(ctx context.Context, arg ListPayeesParams) ([]Payee, error)
+	ListScheduledTransferExecutions(ctx context.Context, arg ListScheduledTransferExecutionsParams) ([]ScheduledTransferExecution, error)
+	ListScheduledTransfers(ctx context.Context, arg ListScheduledTransfersParams) ([]ScheduledTransfer, error)
+	ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	ListUserSessions(ctx context.Context, arg ListUserSessionsParams) ([]Session, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookSubscriptions(ctx context.Context, owner string) ([]WebhookSubscription, error)
+	ListWebhookSubscriptionsForEvent(ctx context.Context, arg ListWebhookSubscriptionsForEventParams) ([]WebhookSubscription, error)
+	// Replaces the file of an earlier attempt whose export was not completed.
+	SaveStatementFile(ctx context.Context, arg SaveStatementFileParams) error
+	// Matches the pattern against the username, full name and email of users.
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
+	UpdateHoldStatus(ctx context.Context, arg UpdateHoldStatusParams) (Hold, error)
+	UpdatePayee(ctx context.Context, arg UpdatePayeeParams) (Payee, error)
+	UpdateScheduledTransfer(ctx context.Context, arg UpdateScheduledTransferParams) (ScheduledTransfer, error)
+	UpdateSessionBlocked(ctx context.Context, arg UpdateSessionBlockedParams) (Session, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserBlocked(ctx context.Context, arg UpdateUserBlockedParams) (User, error)
+	UpdateWebhookDeliveryAttempt(ctx context.Context, arg UpdateWebhookDeliveryAttemptParams) (WebhookDelivery, error)
+	UpsertUserTransferLimits(ctx context.Context, arg UpsertUserTransferLimitsParams) (UserTransferLimit, error)
 }
 
 var _ Querier = (*Queries)(nil)
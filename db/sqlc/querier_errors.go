@@ -0,0 +1,477 @@
+// Code generated by errwrap from querier.go. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// errorQuerier runs the queries and translates their errors with
+// translateError.
+type errorQuerier struct {
+	q *Queries
+}
+
+var _ Querier = errorQuerier{}
+
+func (w errorQuerier) AddAccountAccruedInterest(ctx context.Context, arg AddAccountAccruedInterestParams) (Account, error) {
+	r, err := w.q.AddAccountAccruedInterest(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error) {
+	r, err := w.q.AddAccountBalance(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) AddAccountHeldAmount(ctx context.Context, arg AddAccountHeldAmountParams) (Account, error) {
+	r, err := w.q.AddAccountHeldAmount(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) BlockUserSessions(ctx context.Context, username string) ([]Session, error) {
+	r, err := w.q.BlockUserSessions(ctx, username)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CompleteSeedRun(ctx context.Context, id int64) (SeedRun, error) {
+	r, err := w.q.CompleteSeedRun(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CompleteStatementExport(ctx context.Context, arg CompleteStatementExportParams) (StatementExport, error) {
+	r, err := w.q.CompleteStatementExport(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	r, err := w.q.CreateAccount(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateAccountStatusChange(ctx context.Context, arg CreateAccountStatusChangeParams) (AccountStatusChange, error) {
+	r, err := w.q.CreateAccountStatusChange(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateBalanceAdjustment(ctx context.Context, arg CreateBalanceAdjustmentParams) (BalanceAdjustment, error) {
+	r, err := w.q.CreateBalanceAdjustment(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
+	r, err := w.q.CreateEntry(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error) {
+	r, err := w.q.CreateHold(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error) {
+	r, err := w.q.CreateInterestAccrual(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateInterestPosting(ctx context.Context, arg CreateInterestPostingParams) (InterestPosting, error) {
+	r, err := w.q.CreateInterestPosting(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error {
+	return translateError(w.q.CreateOutboxEvent(ctx, arg))
+}
+
+func (w errorQuerier) CreatePayee(ctx context.Context, arg CreatePayeeParams) (Payee, error) {
+	r, err := w.q.CreatePayee(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error) {
+	r, err := w.q.CreateScheduledTransfer(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateScheduledTransferExecution(ctx context.Context, arg CreateScheduledTransferExecutionParams) (ScheduledTransferExecution, error) {
+	r, err := w.q.CreateScheduledTransferExecution(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateSeedRun(ctx context.Context) (SeedRun, error) {
+	r, err := w.q.CreateSeedRun(ctx)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
+	r, err := w.q.CreateSession(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateStatementExport(ctx context.Context, arg CreateStatementExportParams) (StatementExport, error) {
+	r, err := w.q.CreateStatementExport(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
+	r, err := w.q.CreateTransfer(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateTransferReversal(ctx context.Context, arg CreateTransferReversalParams) (TransferReversal, error) {
+	r, err := w.q.CreateTransferReversal(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	r, err := w.q.CreateUser(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error) {
+	r, err := w.q.CreateWebhookDelivery(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	r, err := w.q.CreateWebhookSubscription(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) DeleteAccount(ctx context.Context, id int64) error {
+	return translateError(w.q.DeleteAccount(ctx, id))
+}
+
+func (w errorQuerier) DeleteOutboxEvents(ctx context.Context, ids []int64) error {
+	return translateError(w.q.DeleteOutboxEvents(ctx, ids))
+}
+
+func (w errorQuerier) DeletePayee(ctx context.Context, id int64) error {
+	return translateError(w.q.DeletePayee(ctx, id))
+}
+
+func (w errorQuerier) DeleteWebhookSubscription(ctx context.Context, id int64) error {
+	return translateError(w.q.DeleteWebhookSubscription(ctx, id))
+}
+
+func (w errorQuerier) GetAccount(ctx context.Context, id int64) (Account, error) {
+	r, err := w.q.GetAccount(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetAccountByOwnerAndCurrency(ctx context.Context, arg GetAccountByOwnerAndCurrencyParams) (Account, error) {
+	r, err := w.q.GetAccountByOwnerAndCurrency(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetAccountForUpdate(ctx context.Context, id int64) (Account, error) {
+	r, err := w.q.GetAccountForUpdate(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetAccountType(ctx context.Context, name string) (AccountType, error) {
+	r, err := w.q.GetAccountType(ctx, name)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetEntriesSumSince(ctx context.Context, arg GetEntriesSumSinceParams) (int64, error) {
+	r, err := w.q.GetEntriesSumSince(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetEntry(ctx context.Context, id int64) (Entry, error) {
+	r, err := w.q.GetEntry(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetHold(ctx context.Context, id int64) (Hold, error) {
+	r, err := w.q.GetHold(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetHoldForUpdate(ctx context.Context, id int64) (Hold, error) {
+	r, err := w.q.GetHoldForUpdate(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetInterestPosting(ctx context.Context, arg GetInterestPostingParams) (InterestPosting, error) {
+	r, err := w.q.GetInterestPosting(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetLastEntryID(ctx context.Context, accountIds []int64) (int64, error) {
+	r, err := w.q.GetLastEntryID(ctx, accountIds)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetLastEntryIDBefore(ctx context.Context, arg GetLastEntryIDBeforeParams) (int64, error) {
+	r, err := w.q.GetLastEntryIDBefore(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetLastSeedRun(ctx context.Context) (SeedRun, error) {
+	r, err := w.q.GetLastSeedRun(ctx)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetOutgoingTransferTotals(ctx context.Context, arg GetOutgoingTransferTotalsParams) (GetOutgoingTransferTotalsRow, error) {
+	r, err := w.q.GetOutgoingTransferTotals(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetPayee(ctx context.Context, id int64) (Payee, error) {
+	r, err := w.q.GetPayee(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetRecipientAccount(ctx context.Context, arg GetRecipientAccountParams) (Account, error) {
+	r, err := w.q.GetRecipientAccount(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetReversedAmount(ctx context.Context, reversalOfID sql.NullInt64) (int64, error) {
+	r, err := w.q.GetReversedAmount(ctx, reversalOfID)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error) {
+	r, err := w.q.GetScheduledTransfer(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetScheduledTransferForUpdate(ctx context.Context, id int64) (ScheduledTransfer, error) {
+	r, err := w.q.GetScheduledTransferForUpdate(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetSession(ctx context.Context, id uuid.UUID) (Session, error) {
+	r, err := w.q.GetSession(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetStatementBalances(ctx context.Context, arg GetStatementBalancesParams) (GetStatementBalancesRow, error) {
+	r, err := w.q.GetStatementBalances(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetStatementExport(ctx context.Context, id int64) (StatementExport, error) {
+	r, err := w.q.GetStatementExport(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetStatementFile(ctx context.Context, exportID int64) (StatementFile, error) {
+	r, err := w.q.GetStatementFile(ctx, exportID)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
+	r, err := w.q.GetTransfer(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error) {
+	r, err := w.q.GetTransferForUpdate(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetTransferLimits(ctx context.Context, id int64) (GetTransferLimitsRow, error) {
+	r, err := w.q.GetTransferLimits(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetUser(ctx context.Context, username string) (User, error) {
+	r, err := w.q.GetUser(ctx, username)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetUserTransferLimits(ctx context.Context, username string) (UserTransferLimit, error) {
+	r, err := w.q.GetUserTransferLimits(ctx, username)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error) {
+	r, err := w.q.GetWebhookDelivery(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) GetWebhookSubscription(ctx context.Context, id int64) (WebhookSubscription, error) {
+	r, err := w.q.GetWebhookSubscription(ctx, id)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListAccountEntries(ctx context.Context, arg ListAccountEntriesParams) ([]ListAccountEntriesRow, error) {
+	r, err := w.q.ListAccountEntries(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListAccountIDs(ctx context.Context, owner string) ([]int64, error) {
+	r, err := w.q.ListAccountIDs(ctx, owner)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListAccountStatusChanges(ctx context.Context, arg ListAccountStatusChangesParams) ([]AccountStatusChange, error) {
+	r, err := w.q.ListAccountStatusChanges(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListAccountTransfers(ctx context.Context, arg ListAccountTransfersParams) ([]Transfer, error) {
+	r, err := w.q.ListAccountTransfers(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListAccountTypes(ctx context.Context) ([]AccountType, error) {
+	r, err := w.q.ListAccountTypes(ctx)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
+	r, err := w.q.ListAccounts(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListAccountsWithAccruedInterest(ctx context.Context, arg ListAccountsWithAccruedInterestParams) ([]Account, error) {
+	r, err := w.q.ListAccountsWithAccruedInterest(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListDueScheduledTransfers(ctx context.Context, arg ListDueScheduledTransfersParams) ([]ScheduledTransfer, error) {
+	r, err := w.q.ListDueScheduledTransfers(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error) {
+	r, err := w.q.ListEntries(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListEntriesAfter(ctx context.Context, arg ListEntriesAfterParams) ([]ListEntriesAfterRow, error) {
+	r, err := w.q.ListEntriesAfter(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListExpiredHolds(ctx context.Context, arg ListExpiredHoldsParams) ([]Hold, error) {
+	r, err := w.q.ListExpiredHolds(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error) {
+	r, err := w.q.ListInterestAccruals(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListInterestBearingAccounts(ctx context.Context, arg ListInterestBearingAccountsParams) ([]Account, error) {
+	r, err := w.q.ListInterestBearingAccounts(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListOutboxEventsForUpdate(ctx context.Context, limit int32) ([]EventOutbox, error) {
+	r, err := w.q.ListOutboxEventsForUpdate(ctx, limit)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListPayees(ctx context.Context, arg ListPayeesParams) ([]Payee, error) {
+	r, err := w.q.ListPayees(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListScheduledTransferExecutions(ctx context.Context, arg ListScheduledTransferExecutionsParams) ([]ScheduledTransferExecution, error) {
+	r, err := w.q.ListScheduledTransferExecutions(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListScheduledTransfers(ctx context.Context, arg ListScheduledTransfersParams) ([]ScheduledTransfer, error) {
+	r, err := w.q.ListScheduledTransfers(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error) {
+	r, err := w.q.ListStatementEntries(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
+	r, err := w.q.ListTransfers(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListUserSessions(ctx context.Context, arg ListUserSessionsParams) ([]Session, error) {
+	r, err := w.q.ListUserSessions(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	r, err := w.q.ListWebhookDeliveries(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListWebhookSubscriptions(ctx context.Context, owner string) ([]WebhookSubscription, error) {
+	r, err := w.q.ListWebhookSubscriptions(ctx, owner)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) ListWebhookSubscriptionsForEvent(ctx context.Context, arg ListWebhookSubscriptionsForEventParams) ([]WebhookSubscription, error) {
+	r, err := w.q.ListWebhookSubscriptionsForEvent(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) SaveStatementFile(ctx context.Context, arg SaveStatementFileParams) error {
+	return translateError(w.q.SaveStatementFile(ctx, arg))
+}
+
+func (w errorQuerier) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error) {
+	r, err := w.q.SearchUsers(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error) {
+	r, err := w.q.UpdateAccount(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	r, err := w.q.UpdateAccountStatus(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) UpdateHoldStatus(ctx context.Context, arg UpdateHoldStatusParams) (Hold, error) {
+	r, err := w.q.UpdateHoldStatus(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) UpdatePayee(ctx context.Context, arg UpdatePayeeParams) (Payee, error) {
+	r, err := w.q.UpdatePayee(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) UpdateScheduledTransfer(ctx context.Context, arg UpdateScheduledTransferParams) (ScheduledTransfer, error) {
+	r, err := w.q.UpdateScheduledTransfer(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) UpdateSessionBlocked(ctx context.Context, arg UpdateSessionBlockedParams) (Session, error) {
+	r, err := w.q.UpdateSessionBlocked(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
+	r, err := w.q.UpdateUser(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) UpdateUserBlocked(ctx context.Context, arg UpdateUserBlockedParams) (User, error) {
+	r, err := w.q.UpdateUserBlocked(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) UpdateWebhookDeliveryAttempt(ctx context.Context, arg UpdateWebhookDeliveryAttemptParams) (WebhookDelivery, error) {
+	r, err := w.q.UpdateWebhookDeliveryAttempt(ctx, arg)
+	return r, translateError(err)
+}
+
+func (w errorQuerier) UpsertUserTransferLimits(ctx context.Context, arg UpsertUserTransferLimitsParams) (UserTransferLimit, error) {
+	r, err := w.q.UpsertUserTransferLimits(ctx, arg)
+	return r, translateError(err)
+}
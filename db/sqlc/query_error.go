@@ -0,0 +1,98 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+//go:generate go run ../../tools/errwrap -o querier_errors.go
+
+// The kinds of failed queries. The Store returns them as a *QueryError, so
+// that callers never inspect driver errors.
+var (
+	ErrNotFound             = errors.New("record not found")
+	ErrUniqueViolation      = errors.New("unique violation")
+	ErrForeignKeyViolation  = errors.New("foreign key violation")
+	ErrCheckViolation       = errors.New("check violation")
+	ErrSerializationFailure = errors.New("serialization failure")
+)
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	foreignKeyViolation  = "23503"
+	uniqueViolation      = "23505"
+	checkViolation       = "23514"
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// QueryError is a query that failed in a way callers can handle. It matches
+// its Kind with errors.Is and unwraps to the driver error.
+type QueryError struct {
+	Kind error
+	// the violated constraint, e.g. "users_email_key", for the violations
+	Constraint string
+	Table      string
+	Err        error
+}
+
+func (e *QueryError) Error() string {
+	if e.Constraint != "" {
+		return fmt.Sprintf("%s of constraint %s", e.Kind, e.Constraint)
+	}
+	return e.Kind.Error()
+}
+
+func (e *QueryError) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+// translateError turns the errors of the driver into a *QueryError. Other
+// errors are returned as they are.
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var queryErr *QueryError
+	if errors.As(err, &queryErr) {
+		return err
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &QueryError{Kind: ErrNotFound, Err: err}
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	var kind error
+	switch pgErr.Code {
+	case uniqueViolation:
+		kind = ErrUniqueViolation
+	case foreignKeyViolation:
+		kind = ErrForeignKeyViolation
+	case checkViolation:
+		kind = ErrCheckViolation
+	case serializationFailure, deadlockDetected:
+		kind = ErrSerializationFailure
+	default:
+		return err
+	}
+
+	return &QueryError{
+		Kind:       kind,
+		Constraint: pgErr.ConstraintName,
+		Table:      pgErr.TableName,
+		Err:        err,
+	}
+}
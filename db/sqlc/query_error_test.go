@@ -0,0 +1,45 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslateError(t *testing.T) {
+	require.NoError(t, translateError(nil))
+
+	other := errors.New("connection refused")
+	require.Equal(t, other, translateError(other))
+	require.Equal(t, error(&pgconn.PgError{Code: "22001"}), translateError(&pgconn.PgError{Code: "22001"}))
+
+	err := translateError(pgx.ErrNoRows)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	testCases := []struct {
+		code string
+		kind error
+	}{
+		{code: uniqueViolation, kind: ErrUniqueViolation},
+		{code: foreignKeyViolation, kind: ErrForeignKeyViolation},
+		{code: checkViolation, kind: ErrCheckViolation},
+		{code: serializationFailure, kind: ErrSerializationFailure},
+		{code: deadlockDetected, kind: ErrSerializationFailure},
+	}
+	for _, tc := range testCases {
+		pgErr := &pgconn.PgError{Code: tc.code, ConstraintName: "users_email_key", TableName: "users"}
+		err := translateError(fmt.Errorf("insert user: %w", pgErr))
+		require.ErrorIs(t, err, tc.kind)
+
+		var queryErr *QueryError
+		require.True(t, errors.As(err, &queryErr))
+		require.Equal(t, "users_email_key", queryErr.Constraint)
+		require.Equal(t, "users", queryErr.Table)
+		require.Equal(t, err, translateError(err))
+	}
+}
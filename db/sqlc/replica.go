@@ -0,0 +1,191 @@
+package db
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultReplicaMaxLag           = 5 * time.Second
+	DefaultReplicaLagCheckInterval = time.Second
+)
+
+// replicaLagQuery returns how far behind the primary the replica is, in
+// seconds. A replica that replayed everything it received is not behind, even
+// if the primary has not committed anything for a while.
+const replicaLagQuery = `SELECT (CASE
+	WHEN NOT pg_is_in_recovery() THEN 0
+	WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END)::float8`
+
+// Replica is a read replica of the database. It takes the read-only queries
+// of the store as long as it is no further behind the primary than its max
+// lag.
+type Replica struct {
+	pool   *pgxpool.Pool
+	maxLag time.Duration
+	// the replica is not used until its lag has been checked
+	available atomic.Bool
+}
+
+// NewReplica creates a replica that is used while its lag is at most maxLag,
+// DefaultReplicaMaxLag when zero. Its lag is only checked by Run.
+func NewReplica(pool *pgxpool.Pool, maxLag time.Duration) *Replica {
+	if maxLag <= 0 {
+		maxLag = DefaultReplicaMaxLag
+	}
+	return &Replica{pool: pool, maxLag: maxLag}
+}
+
+// Available reports whether the queries can go to the replica.
+func (r *Replica) Available() bool {
+	return r != nil && r.available.Load()
+}
+
+// Run checks the lag of the replica every interval, DefaultReplicaLagCheckInterval
+// when zero, until ctx is done. The queries go to the primary while the
+// replica lags too far behind or cannot be reached.
+func (r *Replica) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultReplicaLagCheckInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		r.checkLag(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Replica) checkLag(ctx context.Context) {
+	var seconds float64
+	err := r.pool.QueryRow(ctx, replicaLagQuery).Scan(&seconds)
+	if ctx.Err() != nil {
+		return
+	}
+
+	lag := time.Duration(seconds * float64(time.Second))
+	available := err == nil && lag <= r.maxLag
+	if r.available.Swap(available) == available {
+		return
+	}
+
+	entry := logrus.WithField("lag", lag)
+	switch {
+	case err != nil:
+		entry.WithError(err).Warn("cannot check replica lag, reading from primary")
+	case !available:
+		entry.Warn("replica lags behind, reading from primary")
+	default:
+		entry.Info("replica caught up, reading from replica")
+	}
+}
+
+type routingKey int
+
+const (
+	primaryKey routingKey = iota
+	writesKey
+)
+
+// WithPrimary makes the queries made with ctx read from the primary.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey, true)
+}
+
+// WithReadYourWrites makes the queries made with ctx read from the primary
+// once a write was made with it, so that a request sees its own writes.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, writesKey, new(atomic.Bool))
+}
+
+func markWrite(ctx context.Context) {
+	if wrote, ok := ctx.Value(writesKey).(*atomic.Bool); ok {
+		wrote.Store(true)
+	}
+}
+
+func readsFromPrimary(ctx context.Context) bool {
+	if primary, _ := ctx.Value(primaryKey).(bool); primary {
+		return true
+	}
+	wrote, ok := ctx.Value(writesKey).(*atomic.Bool)
+	return ok && wrote.Load()
+}
+
+var lockingClause = regexp.MustCompile(`(?i)\bFOR\s+(NO\s+KEY\s+UPDATE|UPDATE|KEY\s+SHARE|SHARE)\b`)
+
+// readOnlyQueries caches isReadOnly by query.
+var readOnlyQueries sync.Map
+
+// isReadOnly reports whether a query is a plain SELECT, i.e. it neither
+// writes nor locks rows.
+func isReadOnly(query string) bool {
+	if readOnly, ok := readOnlyQueries.Load(query); ok {
+		return readOnly.(bool)
+	}
+
+	// skip the "-- name: GetAccount :one" header of sqlc
+	statement := query
+	for strings.HasPrefix(statement, "--") {
+		end := strings.IndexByte(statement, '\n')
+		if end < 0 {
+			statement = ""
+			break
+		}
+		statement = strings.TrimSpace(statement[end+1:])
+	}
+
+	fields := strings.Fields(statement)
+	readOnly := len(fields) > 0 && strings.EqualFold(fields[0], "SELECT") && !lockingClause.MatchString(statement)
+	readOnlyQueries.Store(query, readOnly)
+	return readOnly
+}
+
+// routedDBTX sends the read-only queries to the replica while it is
+// available and ctx allows it, and everything else to the primary.
+type routedDBTX struct {
+	primary DBTX
+	replica *Replica
+}
+
+func (r *routedDBTX) route(ctx context.Context, query string) DBTX {
+	if !isReadOnly(query) {
+		markWrite(ctx)
+		return r.primary
+	}
+	if readsFromPrimary(ctx) || !r.replica.Available() {
+		return r.primary
+	}
+	return r.replica.pool
+}
+
+func (r *routedDBTX) Exec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
+	markWrite(ctx)
+	return r.primary.Exec(ctx, query, args...)
+}
+
+func (r *routedDBTX) Query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	return r.route(ctx, query).Query(ctx, query, args...)
+}
+
+func (r *routedDBTX) QueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
+	return r.route(ctx, query).QueryRow(ctx, query, args...)
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsReadOnly(t *testing.T) {
+	require.True(t, isReadOnly(getAccount))
+	require.True(t, isReadOnly(listAccounts))
+	require.True(t, isReadOnly("SELECT 1"))
+	require.False(t, isReadOnly(getAccountForUpdate))
+	require.False(t, isReadOnly(createAccount))
+	require.False(t, isReadOnly(addAccountBalance))
+	require.False(t, isReadOnly("-- name: LockAccount :one\nSELECT * FROM accounts WHERE id = $1 FOR NO KEY UPDATE"))
+	require.False(t, isReadOnly("-- name: Nothing :exec"))
+}
+
+func TestRoutedDBTX(t *testing.T) {
+	primary := &pgxpool.Pool{}
+	replica := NewReplica(&pgxpool.Pool{}, 0)
+	router := &routedDBTX{primary: primary, replica: replica}
+
+	ctx := context.Background()
+	require.Equal(t, DBTX(primary), router.route(ctx, getAccount), "replica not checked yet")
+
+	replica.available.Store(true)
+	require.Equal(t, DBTX(replica.pool), router.route(ctx, getAccount))
+	require.Equal(t, DBTX(primary), router.route(ctx, getAccountForUpdate))
+	require.Equal(t, DBTX(primary), router.route(WithPrimary(ctx), getAccount))
+
+	ctx = WithReadYourWrites(ctx)
+	require.Equal(t, DBTX(replica.pool), router.route(ctx, getAccount))
+	require.Equal(t, DBTX(primary), router.route(ctx, createAccount))
+	require.Equal(t, DBTX(primary), router.route(ctx, getAccount), "reads after a write")
+
+	replica.available.Store(false)
+	require.Equal(t, DBTX(primary), router.route(context.Background(), getAccount))
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createRandomTransferTx(t *testing.T, store Store, amount int64) TransferTxResult {
+	from := fundAccount(t, createRandomAccount(t), amount)
+	to := createRandomAccount(t)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        amount,
+	})
+	assert.NoError(t, err)
+	return result
+}
+
+func TestReverseTransferTxPartial(t *testing.T) {
+	store := NewStore(testDB)
+	original := createRandomTransferTx(t, store, 10)
+	admin := createRandomUser(t)
+
+	arg := ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+		Amount:     4,
+		Reason:     "partial refund",
+		ReversedBy: admin.Username,
+	}
+
+	result, err := store.ReverseTransferTx(context.Background(), arg)
+	assert.NoError(t, err)
+	assert.Equal(t, original.Transfer.ID, result.Transfer.Transfer.ReversalOfID.Int64)
+	assert.Equal(t, original.Transfer.ToAccountID, result.Transfer.Transfer.FromAccountID)
+	assert.Equal(t, original.Transfer.FromAccountID, result.Transfer.Transfer.ToAccountID)
+	assert.Equal(t, int64(4), result.Transfer.Transfer.Amount)
+	assert.Equal(t, original.FromAccount.Balance+4, result.Transfer.ToAccount.Balance)
+	assert.Equal(t, original.ToAccount.Balance-4, result.Transfer.FromAccount.Balance)
+	assert.Equal(t, result.Transfer.Transfer.ID, result.Reversal.TransferID)
+	assert.Equal(t, arg.Reason, result.Reversal.Reason)
+	assert.Equal(t, admin.Username, result.Reversal.ReversedBy)
+	assert.Equal(t, int64(4), result.ReversedAmount)
+
+	// only 6 is left to reverse
+	arg.Amount = 7
+	_, err = store.ReverseTransferTx(context.Background(), arg)
+	assert.ErrorIs(t, err, ErrReversalExceedsTransfer)
+
+	arg.Amount = 6
+	result, err = store.ReverseTransferTx(context.Background(), arg)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), result.ReversedAmount)
+
+	arg.Amount = 1
+	_, err = store.ReverseTransferTx(context.Background(), arg)
+	assert.ErrorIs(t, err, ErrReversalExceedsTransfer)
+
+	// a reversal cannot be reversed
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: result.Transfer.Transfer.ID,
+		Amount:     1,
+		Reason:     "reverse the reversal",
+		ReversedBy: admin.Username,
+	})
+	assert.ErrorIs(t, err, ErrTransferIsReversal)
+}
+
+func TestReverseTransferTxConcurrent(t *testing.T) {
+	store := NewStore(testDB)
+	original := createRandomTransferTx(t, store, 10)
+	admin := createRandomUser(t)
+
+	n := 3
+	errsCh := make(chan error)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+				TransferID: original.Transfer.ID,
+				Amount:     10,
+				Reason:     "double click",
+				ReversedBy: admin.Username,
+			})
+			errsCh <- err
+		}()
+	}
+
+	succeeded := 0
+	for i := 0; i < n; i++ {
+		err := <-errsCh
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, ErrReversalExceedsTransfer)
+	}
+	assert.Equal(t, 1, succeeded)
+
+	account, err := store.GetAccount(context.Background(), original.FromAccount.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, original.FromAccount.Balance+10, account.Balance)
+}
+
+func TestReverseTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+	original := createRandomTransferTx(t, store, 10)
+	admin := createRandomUser(t)
+
+	// the recipient already spent the money
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: original.ToAccount.ID,
+		ToAccountID:   original.FromAccount.ID,
+		Amount:        original.ToAccount.Balance,
+	})
+	assert.NoError(t, err)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+		Amount:     10,
+		Reason:     "too late",
+		ReversedBy: admin.Username,
+	})
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+}
@@ -0,0 +1,346 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.18.0
+// source: scheduled_transfer.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createScheduledTransfer = `-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+    owner,
+    from_account_id,
+    to_account_id,
+    amount,
+    recurrence,
+    next_run_at,
+    end_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, owner, from_account_id, to_account_id, amount, recurrence, next_run_at, end_at, status, failed_attempts, created_at, updated_at
+`
+
+type CreateScheduledTransferParams struct {
+	Owner         string         `json:"owner"`
+	FromAccountID int64          `json:"from_account_id"`
+	ToAccountID   int64          `json:"to_account_id"`
+	Amount        int64          `json:"amount"`
+	Recurrence    sql.NullString `json:"recurrence"`
+	NextRunAt     time.Time      `json:"next_run_at"`
+	EndAt         sql.NullTime   `json:"end_at"`
+}
+
+func (q *Queries) CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, createScheduledTransfer,
+		arg.Owner,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Recurrence,
+		arg.NextRunAt,
+		arg.EndAt,
+	)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Recurrence,
+		&i.NextRunAt,
+		&i.EndAt,
+		&i.Status,
+		&i.FailedAttempts,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createScheduledTransferExecution = `-- name: CreateScheduledTransferExecution :one
+INSERT INTO scheduled_transfer_executions (
+    scheduled_transfer_id,
+    scheduled_at,
+    status,
+    transfer_id,
+    failure_reason
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, scheduled_transfer_id, scheduled_at, status, transfer_id, failure_reason, created_at
+`
+
+type CreateScheduledTransferExecutionParams struct {
+	ScheduledTransferID int64         `json:"scheduled_transfer_id"`
+	ScheduledAt         time.Time     `json:"scheduled_at"`
+	Status              string        `json:"status"`
+	TransferID          sql.NullInt64 `json:"transfer_id"`
+	FailureReason       string        `json:"failure_reason"`
+}
+
+func (q *Queries) CreateScheduledTransferExecution(ctx context.Context, arg CreateScheduledTransferExecutionParams) (ScheduledTransferExecution, error) {
+	row := q.db.QueryRow(ctx, createScheduledTransferExecution,
+		arg.ScheduledTransferID,
+		arg.ScheduledAt,
+		arg.Status,
+		arg.TransferID,
+		arg.FailureReason,
+	)
+	var i ScheduledTransferExecution
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduledTransferID,
+		&i.ScheduledAt,
+		&i.Status,
+		&i.TransferID,
+		&i.FailureReason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getScheduledTransfer = `-- name: GetScheduledTransfer :one
+SELECT id, owner, from_account_id, to_account_id, amount, recurrence, next_run_at, end_at, status, failed_attempts, created_at, updated_at FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, getScheduledTransfer, id)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Recurrence,
+		&i.NextRunAt,
+		&i.EndAt,
+		&i.Status,
+		&i.FailedAttempts,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getScheduledTransferForUpdate = `-- name: GetScheduledTransferForUpdate :one
+SELECT id, owner, from_account_id, to_account_id, amount, recurrence, next_run_at, end_at, status, failed_attempts, created_at, updated_at FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetScheduledTransferForUpdate(ctx context.Context, id int64) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, getScheduledTransferForUpdate, id)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Recurrence,
+		&i.NextRunAt,
+		&i.EndAt,
+		&i.Status,
+		&i.FailedAttempts,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listDueScheduledTransfers = `-- name: ListDueScheduledTransfers :many
+SELECT id, owner, from_account_id, to_account_id, amount, recurrence, next_run_at, end_at, status, failed_attempts, created_at, updated_at FROM scheduled_transfers
+WHERE status = 'active'
+    AND next_run_at <= $1
+    AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ListDueScheduledTransfersParams struct {
+	Now      time.Time `json:"now"`
+	AfterID  int64     `json:"after_id"`
+	PageSize int32     `json:"page_size"`
+}
+
+func (q *Queries) ListDueScheduledTransfers(ctx context.Context, arg ListDueScheduledTransfersParams) ([]ScheduledTransfer, error) {
+	rows, err := q.db.Query(ctx, listDueScheduledTransfers, arg.Now, arg.AfterID, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScheduledTransfer{}
+	for rows.Next() {
+		var i ScheduledTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Recurrence,
+			&i.NextRunAt,
+			&i.EndAt,
+			&i.Status,
+			&i.FailedAttempts,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScheduledTransferExecutions = `-- name: ListScheduledTransferExecutions :many
+SELECT id, scheduled_transfer_id, scheduled_at, status, transfer_id, failure_reason, created_at FROM scheduled_transfer_executions
+WHERE scheduled_transfer_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3
+`
+
+type ListScheduledTransferExecutionsParams struct {
+	ScheduledTransferID int64 `json:"scheduled_transfer_id"`
+	Limit               int32 `json:"limit"`
+	Offset              int32 `json:"offset"`
+}
+
+func (q *Queries) ListScheduledTransferExecutions(ctx context.Context, arg ListScheduledTransferExecutionsParams) ([]ScheduledTransferExecution, error) {
+	rows, err := q.db.Query(ctx, listScheduledTransferExecutions, arg.ScheduledTransferID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScheduledTransferExecution{}
+	for rows.Next() {
+		var i ScheduledTransferExecution
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScheduledTransferID,
+			&i.ScheduledAt,
+			&i.Status,
+			&i.TransferID,
+			&i.FailureReason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScheduledTransfers = `-- name: ListScheduledTransfers :many
+SELECT id, owner, from_account_id, to_account_id, amount, recurrence, next_run_at, end_at, status, failed_attempts, created_at, updated_at FROM scheduled_transfers
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3
+`
+
+type ListScheduledTransfersParams struct {
+	Owner  string `json:"owner"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+func (q *Queries) ListScheduledTransfers(ctx context.Context, arg ListScheduledTransfersParams) ([]ScheduledTransfer, error) {
+	rows, err := q.db.Query(ctx, listScheduledTransfers, arg.Owner, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScheduledTransfer{}
+	for rows.Next() {
+		var i ScheduledTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Recurrence,
+			&i.NextRunAt,
+			&i.EndAt,
+			&i.Status,
+			&i.FailedAttempts,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateScheduledTransfer = `-- name: UpdateScheduledTransfer :one
+UPDATE scheduled_transfers
+SET
+    amount = COALESCE($1, amount),
+    recurrence = COALESCE($2, recurrence),
+    next_run_at = COALESCE($3, next_run_at),
+    end_at = COALESCE($4, end_at),
+    status = COALESCE($5, status),
+    failed_attempts = COALESCE($6, failed_attempts),
+    updated_at = now()
+WHERE
+    id = $7
+RETURNING id, owner, from_account_id, to_account_id, amount, recurrence, next_run_at, end_at, status, failed_attempts, created_at, updated_at
+`
+
+type UpdateScheduledTransferParams struct {
+	Amount         sql.NullInt64  `json:"amount"`
+	Recurrence     sql.NullString `json:"recurrence"`
+	NextRunAt      sql.NullTime   `json:"next_run_at"`
+	EndAt          sql.NullTime   `json:"end_at"`
+	Status         sql.NullString `json:"status"`
+	FailedAttempts sql.NullInt32  `json:"failed_attempts"`
+	ID             int64          `json:"id"`
+}
+
+func (q *Queries) UpdateScheduledTransfer(ctx context.Context, arg UpdateScheduledTransferParams) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, updateScheduledTransfer,
+		arg.Amount,
+		arg.Recurrence,
+		arg.NextRunAt,
+		arg.EndAt,
+		arg.Status,
+		arg.FailedAttempts,
+		arg.ID,
+	)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Recurrence,
+		&i.NextRunAt,
+		&i.EndAt,
+		&i.Status,
+		&i.FailedAttempts,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func createRandomScheduledTransfer(t *testing.T, from Account, to Account, amount int64, recurrence string) ScheduledTransfer {
+	arg := CreateScheduledTransferParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        amount,
+		Recurrence:    sql.NullString{String: recurrence, Valid: recurrence != ""},
+		NextRunAt:     time.Now().Add(-time.Minute),
+	}
+
+	scheduledTransfer, err := testQueries.CreateScheduledTransfer(context.Background(), arg)
+	assert.NoError(t, err)
+	assert.Equal(t, arg.Owner, scheduledTransfer.Owner)
+	assert.Equal(t, arg.FromAccountID, scheduledTransfer.FromAccountID)
+	assert.Equal(t, arg.ToAccountID, scheduledTransfer.ToAccountID)
+	assert.Equal(t, arg.Amount, scheduledTransfer.Amount)
+	assert.Equal(t, arg.Recurrence, scheduledTransfer.Recurrence)
+	assert.Equal(t, util.ScheduledTransferActive, scheduledTransfer.Status)
+	assert.Zero(t, scheduledTransfer.FailedAttempts)
+
+	return scheduledTransfer
+}
+
+func TestExecuteScheduledTransferTxOneOff(t *testing.T) {
+	store := NewStore(testDB)
+	from := createRandomAccount(t)
+	to := createRandomAccount(t)
+	scheduledTransfer := createRandomScheduledTransfer(t, from, to, from.Balance, "")
+
+	arg := ExecuteScheduledTransferTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		Now:                 time.Now(),
+		RetryDelay:          time.Hour,
+		MaxAttempts:         3,
+	}
+
+	result, err := store.ExecuteScheduledTransferTx(context.Background(), arg)
+	assert.NoError(t, err)
+	assert.True(t, result.Executed)
+	assert.False(t, result.Suspended)
+	assert.Equal(t, util.ScheduledTransferCompleted, result.ScheduledTransfer.Status)
+	assert.Equal(t, util.ExecutionSucceeded, result.Execution.Status)
+	assert.Equal(t, result.Transfer.Transfer.ID, result.Execution.TransferID.Int64)
+	assert.Equal(t, from.Balance, result.Transfer.Transfer.Amount)
+	assert.Zero(t, result.Transfer.FromAccount.Balance)
+	assert.Equal(t, to.Balance+from.Balance, result.Transfer.ToAccount.Balance)
+
+	// a completed transfer is not executed again
+	result, err = store.ExecuteScheduledTransferTx(context.Background(), arg)
+	assert.NoError(t, err)
+	assert.False(t, result.Executed)
+}
+
+func TestExecuteScheduledTransferTxRecurring(t *testing.T) {
+	store := NewStore(testDB)
+	from := fundAccount(t, createRandomAccount(t), 1)
+	to := createRandomAccount(t)
+	scheduledTransfer := createRandomScheduledTransfer(t, from, to, 1, "0 9 * * *")
+
+	now := time.Now()
+	result, err := store.ExecuteScheduledTransferTx(context.Background(), ExecuteScheduledTransferTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		Now:                 now,
+		RetryDelay:          time.Hour,
+		MaxAttempts:         3,
+	})
+	assert.NoError(t, err)
+	assert.True(t, result.Executed)
+	assert.Equal(t, util.ScheduledTransferActive, result.ScheduledTransfer.Status)
+
+	expected, err := util.NextRecurrence("0 9 * * *", now)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, expected, result.ScheduledTransfer.NextRunAt, time.Second)
+}
+
+func TestExecuteScheduledTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+	from := createRandomAccount(t)
+	to := createRandomAccount(t)
+	scheduledTransfer := createRandomScheduledTransfer(t, from, to, from.Balance+1, "")
+
+	now := time.Now()
+	arg := ExecuteScheduledTransferTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		Now:                 now,
+		RetryDelay:          time.Hour,
+		MaxAttempts:         2,
+	}
+
+	result, err := store.ExecuteScheduledTransferTx(context.Background(), arg)
+	assert.NoError(t, err)
+	assert.True(t, result.Executed)
+	assert.False(t, result.Suspended)
+	assert.Equal(t, util.ExecutionFailed, result.Execution.Status)
+	assert.Equal(t, FailureInsufficientFunds, result.Execution.FailureReason)
+	assert.False(t, result.Execution.TransferID.Valid)
+	assert.Equal(t, int32(1), result.ScheduledTransfer.FailedAttempts)
+	assert.Equal(t, util.ScheduledTransferActive, result.ScheduledTransfer.Status)
+	assert.WithinDuration(t, now.Add(time.Hour), result.ScheduledTransfer.NextRunAt, time.Second)
+
+	// the retry is not due yet
+	result, err = store.ExecuteScheduledTransferTx(context.Background(), arg)
+	assert.NoError(t, err)
+	assert.False(t, result.Executed)
+
+	arg.Now = now.Add(time.Hour)
+	result, err = store.ExecuteScheduledTransferTx(context.Background(), arg)
+	assert.NoError(t, err)
+	assert.True(t, result.Executed)
+	assert.True(t, result.Suspended)
+	assert.Equal(t, int32(2), result.ScheduledTransfer.FailedAttempts)
+	assert.Equal(t, util.ScheduledTransferSuspended, result.ScheduledTransfer.Status)
+
+	account, err := store.GetAccount(context.Background(), from.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, from.Balance, account.Balance)
+
+	executions, err := store.ListScheduledTransferExecutions(context.Background(), ListScheduledTransferExecutionsParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		Limit:               5,
+		Offset:              0,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, executions, 2)
+}
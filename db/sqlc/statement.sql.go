@@ -0,0 +1,253 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.18.0
+// source: statement.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const completeStatementExport = `-- name: CompleteStatementExport :one
+UPDATE statement_exports
+SET
+    status = $1,
+    error = $2,
+    completed_at = now()
+WHERE id = $3
+RETURNING id, owner, account_id, format, period_start, period_end, status, error, created_at, completed_at
+`
+
+type CompleteStatementExportParams struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	ID     int64  `json:"id"`
+}
+
+func (q *Queries) CompleteStatementExport(ctx context.Context, arg CompleteStatementExportParams) (StatementExport, error) {
+	row := q.db.QueryRow(ctx, completeStatementExport, arg.Status, arg.Error, arg.ID)
+	var i StatementExport
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.AccountID,
+		&i.Format,
+		&i.PeriodStart,
+		&i.PeriodEnd,
+		&i.Status,
+		&i.Error,
+		&i.CreatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const createStatementExport = `-- name: CreateStatementExport :one
+INSERT INTO statement_exports (
+    owner,
+    account_id,
+    format,
+    period_start,
+    period_end
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, owner, account_id, format, period_start, period_end, status, error, created_at, completed_at
+`
+
+type CreateStatementExportParams struct {
+	Owner       string    `json:"owner"`
+	AccountID   int64     `json:"account_id"`
+	Format      string    `json:"format"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+}
+
+func (q *Queries) CreateStatementExport(ctx context.Context, arg CreateStatementExportParams) (StatementExport, error) {
+	row := q.db.QueryRow(ctx, createStatementExport,
+		arg.Owner,
+		arg.AccountID,
+		arg.Format,
+		arg.PeriodStart,
+		arg.PeriodEnd,
+	)
+	var i StatementExport
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.AccountID,
+		&i.Format,
+		&i.PeriodStart,
+		&i.PeriodEnd,
+		&i.Status,
+		&i.Error,
+		&i.CreatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const getStatementBalances = `-- name: GetStatementBalances :one
+SELECT
+    (a.balance - COALESCE(SUM(e.amount), 0))::bigint AS opening_balance,
+    (a.balance - COALESCE(SUM(e.amount) FILTER (WHERE e.created_at >= $1), 0))::bigint AS closing_balance,
+    COALESCE(MAX(e.id), 0)::bigint AS last_entry_id
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id AND e.created_at >= $2
+WHERE a.id = $3
+GROUP BY a.id
+`
+
+type GetStatementBalancesParams struct {
+	PeriodEnd   time.Time `json:"period_end"`
+	PeriodStart time.Time `json:"period_start"`
+	AccountID   int64     `json:"account_id"`
+}
+
+type GetStatementBalancesRow struct {
+	OpeningBalance int64 `json:"opening_balance"`
+	ClosingBalance int64 `json:"closing_balance"`
+	LastEntryID    int64 `json:"last_entry_id"`
+}
+
+// Computed in one statement so that the balances and last_entry_id come from
+// the same snapshot. Entries after last_entry_id are not part of the statement.
+func (q *Queries) GetStatementBalances(ctx context.Context, arg GetStatementBalancesParams) (GetStatementBalancesRow, error) {
+	row := q.db.QueryRow(ctx, getStatementBalances, arg.PeriodEnd, arg.PeriodStart, arg.AccountID)
+	var i GetStatementBalancesRow
+	err := row.Scan(&i.OpeningBalance, &i.ClosingBalance, &i.LastEntryID)
+	return i, err
+}
+
+const getStatementExport = `-- name: GetStatementExport :one
+SELECT id, owner, account_id, format, period_start, period_end, status, error, created_at, completed_at FROM statement_exports
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetStatementExport(ctx context.Context, id int64) (StatementExport, error) {
+	row := q.db.QueryRow(ctx, getStatementExport, id)
+	var i StatementExport
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.AccountID,
+		&i.Format,
+		&i.PeriodStart,
+		&i.PeriodEnd,
+		&i.Status,
+		&i.Error,
+		&i.CreatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const getStatementFile = `-- name: GetStatementFile :one
+SELECT export_id, content, created_at FROM statement_files
+WHERE export_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetStatementFile(ctx context.Context, exportID int64) (StatementFile, error) {
+	row := q.db.QueryRow(ctx, getStatementFile, exportID)
+	var i StatementFile
+	err := row.Scan(&i.ExportID, &i.Content, &i.CreatedAt)
+	return i, err
+}
+
+const listStatementEntries = `-- name: ListStatementEntries :many
+SELECT
+    e.id,
+    e.amount,
+    e.created_at,
+    e.transfer_id,
+    COALESCE(c.id, 0)::bigint AS counterparty_account_id,
+    COALESCE(c.owner, '')::varchar AS counterparty_owner
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+LEFT JOIN accounts c ON c.id = (
+    CASE WHEN t.from_account_id = e.account_id THEN t.to_account_id ELSE t.from_account_id END
+)
+WHERE e.account_id = $1
+    AND e.created_at >= $2
+    AND e.created_at < $3
+    AND e.id > $4
+    AND e.id <= $5
+ORDER BY e.id
+LIMIT $6
+`
+
+type ListStatementEntriesParams struct {
+	AccountID   int64     `json:"account_id"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	AfterID     int64     `json:"after_id"`
+	LastEntryID int64     `json:"last_entry_id"`
+	PageSize    int32     `json:"page_size"`
+}
+
+type ListStatementEntriesRow struct {
+	ID                    int64         `json:"id"`
+	Amount                int64         `json:"amount"`
+	CreatedAt             time.Time     `json:"created_at"`
+	TransferID            sql.NullInt64 `json:"transfer_id"`
+	CounterpartyAccountID int64         `json:"counterparty_account_id"`
+	CounterpartyOwner     string        `json:"counterparty_owner"`
+}
+
+func (q *Queries) ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error) {
+	rows, err := q.db.Query(ctx, listStatementEntries,
+		arg.AccountID,
+		arg.PeriodStart,
+		arg.PeriodEnd,
+		arg.AfterID,
+		arg.LastEntryID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStatementEntriesRow{}
+	for rows.Next() {
+		var i ListStatementEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+			&i.CounterpartyAccountID,
+			&i.CounterpartyOwner,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const saveStatementFile = `-- name: SaveStatementFile :exec
+INSERT INTO statement_files (
+    export_id,
+    content
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (export_id) DO UPDATE
+SET content = EXCLUDED.content, created_at = now()
+`
+
+type SaveStatementFileParams struct {
+	ExportID int64  `json:"export_id"`
+	Content  []byte `json:"content"`
+}
+
+// Replaces the file of an earlier attempt whose export was not completed.
+func (q *Queries) SaveStatementFile(ctx context.Context, arg SaveStatementFileParams) error {
+	_, err := q.db.Exec(ctx, saveStatementFile, arg.ExportID, arg.Content)
+	return err
+}
@@ -3,58 +3,136 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sirupsen/logrus"
 )
 
 //go:generate mockgen -package=$GOPACKAG -destination=mock/mock_$GOFILE github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc Store
+//go:generate mockgen -package=mockdb -destination=../mock/store.go github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc Store
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	AccrueInterestTx(ctx context.Context, arg AccrueInterestTxParams) (AccrueInterestTxResult, error)
+	PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error)
+	ExecuteScheduledTransferTx(ctx context.Context, arg ExecuteScheduledTransferTxParams) (ExecuteScheduledTransferTxResult, error)
+	UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (UpdateAccountStatusTxResult, error)
+	CreateHoldTx(ctx context.Context, arg CreateHoldTxParams) (CreateHoldTxResult, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	ReleaseHoldTx(ctx context.Context, arg ReleaseHoldTxParams) (ReleaseHoldTxResult, error)
+	ExpireHoldTx(ctx context.Context, arg ExpireHoldTxParams) (ExpireHoldTxResult, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	BulkTransferTx(ctx context.Context, arg BulkTransferTxParams) (BulkTransferTxResult, error)
+	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (CreateAccountTxResult, error)
+	BlockUserTx(ctx context.Context, arg BlockUserTxParams) (BlockUserTxResult, error)
+	AdjustBalanceTx(ctx context.Context, arg AdjustBalanceTxParams) (AdjustBalanceTxResult, error)
+	RevokeSessionTx(ctx context.Context, arg RevokeSessionTxParams) (RevokeSessionTxResult, error)
+	PublishEventsTx(ctx context.Context, limit int32) (int, error)
 }
 
 var txKey = struct{}{}
 
 type SQLStore struct {
-	*Queries
-	db *sql.DB
+	errorQuerier
+	db        *pgxpool.Pool
+	replica   *Replica
+	publisher events.Publisher
+	// the transactional methods write no events to the outbox
+	withoutEvents bool
 }
 
-func NewStore(dbObj *sql.DB) Store {
-	if dbObj == nil {
+type StoreOption func(*SQLStore)
+
+// WithPublisher sets where PublishEventsTx publishes the domain events that the
+// transactional methods wrote to the outbox.
+func WithPublisher(publisher events.Publisher) StoreOption {
+	return func(s *SQLStore) {
+		s.publisher = publisher
+	}
+}
+
+// WithoutEvents makes the transactional methods write no domain events, so
+// that what they do has no side effects like emails or webhooks.
+func WithoutEvents() StoreOption {
+	return func(s *SQLStore) {
+		s.withoutEvents = true
+	}
+}
+
+// WithReplica sends the read-only queries made outside of transactions to the
+// replica, see routedDBTX.
+func WithReplica(replica *Replica) StoreOption {
+	return func(s *SQLStore) {
+		s.replica = replica
+	}
+}
+
+func NewStore(pool *pgxpool.Pool, opts ...StoreOption) Store {
+	if pool == nil {
 		return nil
 	}
 
-	return &SQLStore{
-		db:      dbObj,
-		Queries: New(dbObj),
+	store := &SQLStore{
+		db:        pool,
+		publisher: events.NopPublisher{},
+	}
+	for _, opt := range opts {
+		opt(store)
 	}
+
+	var dbtx DBTX = pool
+	if store.replica != nil {
+		dbtx = &routedDBTX{primary: pool, replica: store.replica}
+	}
+	store.errorQuerier = errorQuerier{q: New(newTracedDBTX(dbtx))}
+
+	return store
 }
 
+// execTx runs fn in a transaction. The errors of fn and of the commit are
+// translated like the ones of the queries.
 func (s *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	ctx, span := startTxSpan(ctx)
+	defer span.End()
+
+	markWrite(ctx)
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
-		return err
+		recordSpanError(span, err)
+		return translateError(err)
 	}
 
-	q := New(tx)
+	q := New(newTracedTx(tx, span))
 	err = fn(q)
 	if err == nil {
-		return tx.Commit()
+		err = tx.Commit(ctx)
+		span.SetAttributes(txOutcome("commit"))
+		recordSpanError(span, err)
+		return translateError(err)
 	}
 
-	if rbErr := tx.Rollback(); rbErr != nil {
-		return fmt.Errorf("tx err: %v, rollback err: %v", err, rbErr)
+	span.SetAttributes(txOutcome("rollback"))
+	recordSpanError(span, err)
+	if rbErr := tx.Rollback(ctx); rbErr != nil {
+		return fmt.Errorf("tx err: %w, rollback err: %v", translateError(err), rbErr)
 	}
 
-	return err
+	return translateError(err)
 }
 
 type TransferTxParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID     int64          `json:"from_account_id"`
+	ToAccountID       int64          `json:"to_account_id"`
+	Amount            int64          `json:"amount"`
+	Description       string         `json:"description"`
+	ExternalReference sql.NullString `json:"external_reference"`
+	// JSON object, stored as an empty object when not set
+	Metadata json.RawMessage `json:"metadata"`
 }
 
 type TransferTxResult struct {
@@ -65,50 +143,123 @@ type TransferTxResult struct {
 	ToEntry     Entry    `json:"to_entry"`
 }
 
+// TransferTx moves money between two accounts of customers. The transfer
+// must be covered by the available balance of the source account and stay
+// within its limits.
 func (s *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
-	err := s.execTx(ctx, func(q *Queries) error {
+	err := s.execTxWithEvents(ctx, func(q *Queries) error {
 		var err error
+		result, err = customerTransfer(ctx, q, arg, time.Now())
+		return err
+	}, func() []events.Event {
+		return []events.Event{transferCompleted(result)}
+	})
 
-		txName := ctx.Value(txKey)
-
-		logrus.Infof("[%v] transfer created", txName)
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
-		})
-		if err != nil {
-			return err
-		}
-
-		logrus.Infof("[%v] entry 1 created", txName)
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
-		if err != nil {
-			return err
-		}
-
-		logrus.Infof("[%v] entry 2 created", txName)
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
-		if err != nil {
-			return err
-		}
-
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-		} else {
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
-		}
+	return result, err
+}
 
-		return nil
+// customerTransfer moves money between two accounts of customers, within the
+// transaction of the caller. The transfer must be covered by the available
+// balance of the source account and stay within its limits.
+func customerTransfer(ctx context.Context, q *Queries, arg TransferTxParams, now time.Time) (TransferTxResult, error) {
+	fromAccount, toAccount, err := lockAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+
+	if err := checkAccountsActive(fromAccount, toAccount); err != nil {
+		return TransferTxResult{}, err
+	}
+
+	if err := checkAvailableFunds(fromAccount, arg.Amount); err != nil {
+		return TransferTxResult{}, err
+	}
+
+	if err := checkTransferLimits(ctx, q, arg.FromAccountID, arg.Amount, now); err != nil {
+		return TransferTxResult{}, err
+	}
+
+	return recordTransfer(ctx, q, arg, sql.NullInt64{})
+}
+
+// transfer locks both accounts, which must be active, then records the
+// transfer and its entries and moves the money, within the transaction of the
+// caller. Transfer limits are left to the caller, as they do not apply to
+// system transfers.
+func transfer(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	fromAccount, toAccount, err := lockAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+
+	if err := checkAccountsActive(fromAccount, toAccount); err != nil {
+		return TransferTxResult{}, err
+	}
+
+	return recordTransfer(ctx, q, arg, sql.NullInt64{})
+}
+
+// recordTransfer records a transfer, which may reverse another one, and its
+// entries and moves the money. The caller must have locked both accounts with
+// lockAccounts and checked that they are active.
+func recordTransfer(ctx context.Context, q *Queries, arg TransferTxParams, reversalOfID sql.NullInt64) (TransferTxResult, error) {
+	var result TransferTxResult
+	var err error
+
+	metadata := arg.Metadata
+	if len(metadata) == 0 {
+		metadata = json.RawMessage("{}")
+	}
+
+	txName := ctx.Value(txKey)
+
+	logrus.Infof("[%v] transfer created", txName)
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID:     arg.FromAccountID,
+		ToAccountID:       arg.ToAccountID,
+		Amount:            arg.Amount,
+		ReversalOfID:      reversalOfID,
+		Description:       arg.Description,
+		ExternalReference: arg.ExternalReference,
+		Metadata:          metadata,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	logrus.Infof("[%v] entry 1 created", txName)
+	result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: arg.FromAccountID,
+		Amount:    -arg.Amount,
+		TransferID: sql.NullInt64{
+			Int64: result.Transfer.ID,
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return result, err
+	}
+
+	logrus.Infof("[%v] entry 2 created", txName)
+	result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: arg.ToAccountID,
+		Amount:    arg.Amount,
+		TransferID: sql.NullInt64{
+			Int64: result.Transfer.ID,
+			Valid: true,
+		},
 	})
+	if err != nil {
+		return result, err
+	}
+
+	if arg.FromAccountID < arg.ToAccountID {
+		result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
+	} else {
+		result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
+	}
 
 	return result, err
 }
@@ -129,3 +280,27 @@ func addMoney(ctx context.Context, q *Queries, accID1 int64, amount1 int64, accI
 
 	return
 }
+
+// lockAccounts locks both accounts of a transfer in id order, the same order
+// transfer updates them in, so that concurrent transfers cannot deadlock.
+func lockAccounts(ctx context.Context, q *Queries, fromAccountID int64, toAccountID int64) (fromAccount Account, toAccount Account, err error) {
+	firstID, secondID := fromAccountID, toAccountID
+	if secondID < firstID {
+		firstID, secondID = secondID, firstID
+	}
+
+	first, err := q.GetAccountForUpdate(ctx, firstID)
+	if err != nil {
+		return
+	}
+
+	second, err := q.GetAccountForUpdate(ctx, secondID)
+	if err != nil {
+		return
+	}
+
+	if first.ID == fromAccountID {
+		return first, second, nil
+	}
+	return second, first, nil
+}
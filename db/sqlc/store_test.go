@@ -2,9 +2,12 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
@@ -15,6 +18,7 @@ func TestTransferTx(t *testing.T) {
 
 	acc1 := createRandomAccount(t)
 	acc2 := createRandomAccount(t)
+	acc1 = fundAccount(t, acc1, 100)
 
 	logrus.WithFields(logrus.Fields{
 		"acc1.Balance": acc1.Balance,
@@ -68,6 +72,7 @@ func TestTransferTx(t *testing.T) {
 		assert.NotEmpty(t, fromEntry)
 		assert.Equal(t, acc1.ID, fromEntry.AccountID)
 		assert.Equal(t, -amount, fromEntry.Amount)
+		assert.Equal(t, transfer.ID, fromEntry.TransferID.Int64)
 		assert.NotZero(t, fromEntry.ID)
 		assert.NotZero(t, fromEntry.CreatedAt)
 
@@ -78,6 +83,7 @@ func TestTransferTx(t *testing.T) {
 		toEntry := result.ToEntry
 		assert.NotEmpty(t, toEntry)
 		assert.Equal(t, acc2.ID, toEntry.AccountID)
+		assert.Equal(t, transfer.ID, toEntry.TransferID.Int64)
 		assert.Equal(t, amount, toEntry.Amount)
 		assert.NotZero(t, toEntry.ID)
 		assert.NotZero(t, toEntry.CreatedAt)
@@ -135,6 +141,8 @@ func TestTransferTxDeadlock(t *testing.T) {
 
 	acc1 := createRandomAccount(t)
 	acc2 := createRandomAccount(t)
+	acc1 = fundAccount(t, acc1, 100)
+	acc2 = fundAccount(t, acc2, 100)
 
 	logrus.WithFields(logrus.Fields{
 		"acc1.Balance": acc1.Balance,
@@ -180,3 +188,96 @@ func TestTransferTxDeadlock(t *testing.T) {
 	assert.Equal(t, acc1.Balance, updatedAcc1.Balance)
 	assert.Equal(t, acc2.Balance, updatedAcc2.Balance)
 }
+
+func TestTransferTxDetails(t *testing.T) {
+	store := NewStore(testDB)
+	acc1 := fundAccount(t, createRandomAccount(t), 20)
+	acc2 := createRandomAccount(t)
+
+	arg := TransferTxParams{
+		FromAccountID:     acc1.ID,
+		ToAccountID:       acc2.ID,
+		Amount:            10,
+		Description:       "rent",
+		ExternalReference: sql.NullString{String: "INV-" + util.RandomString(8), Valid: true},
+		Metadata:          json.RawMessage(`{"invoice": 42}`),
+	}
+
+	result, err := store.TransferTx(context.Background(), arg)
+	assert.NoError(t, err)
+	assert.Equal(t, arg.Description, result.Transfer.Description)
+	assert.Equal(t, arg.ExternalReference, result.Transfer.ExternalReference)
+	assert.JSONEq(t, string(arg.Metadata), string(result.Transfer.Metadata))
+
+	// the reference is unique per source account
+	_, err = store.TransferTx(context.Background(), arg)
+	assert.Error(t, err)
+
+	// both sides can search by reference
+	for _, account := range []Account{acc1, acc2} {
+		transfers, err := store.ListAccountTransfers(context.Background(), ListAccountTransfersParams{
+			AccountID:         account.ID,
+			ExternalReference: arg.ExternalReference,
+			PageSize:          5,
+			PageOffset:        0,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, transfers, 1)
+		assert.Equal(t, result.Transfer.ID, transfers[0].ID)
+	}
+
+	entries, err := store.ListAccountEntries(context.Background(), ListAccountEntriesParams{
+		AccountID: acc2.ID,
+		Limit:     5,
+		Offset:    0,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, result.ToEntry.ID, entries[0].ID)
+	assert.Equal(t, arg.Description, entries[0].Description)
+	assert.Equal(t, arg.ExternalReference, entries[0].ExternalReference)
+	assert.JSONEq(t, string(arg.Metadata), string(entries[0].Metadata))
+
+	// without metadata the transfer gets an empty object
+	result, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        10,
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(result.Transfer.Metadata))
+	assert.False(t, result.Transfer.ExternalReference.Valid)
+}
+
+func TestListEntriesAfter(t *testing.T) {
+	store := NewStore(testDB)
+	acc1 := fundAccount(t, createRandomAccount(t), 20)
+	acc2 := createRandomAccount(t)
+	accountIDs := []int64{acc1.ID, acc2.ID}
+
+	lastEntryID, err := store.GetLastEntryID(context.Background(), accountIDs)
+	assert.NoError(t, err)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        10,
+		Description:   "lunch",
+	})
+	assert.NoError(t, err)
+
+	entries, err := store.ListEntriesAfter(context.Background(), ListEntriesAfterParams{
+		AccountIds: accountIDs,
+		AfterID:    lastEntryID,
+		PageSize:   5,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, result.FromEntry.ID, entries[0].ID)
+	assert.Equal(t, result.ToEntry.ID, entries[1].ID)
+	assert.Equal(t, "lunch", entries[1].Description)
+
+	lastEntryID, err = store.GetLastEntryID(context.Background(), accountIDs)
+	assert.NoError(t, err)
+	assert.Equal(t, result.ToEntry.ID, lastEntryID)
+}
@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/tracing"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedDBTX wraps a DBTX and records a client span for every query
+// executed through it, named after the sqlc query. Queries run inside a
+// transaction are parented to the span of that transaction.
+type tracedDBTX struct {
+	db     DBTX
+	txSpan trace.Span
+}
+
+func newTracedDBTX(db DBTX) DBTX {
+	return &tracedDBTX{db: db}
+}
+
+func newTracedTx(tx DBTX, txSpan trace.Span) DBTX {
+	return &tracedDBTX{db: tx, txSpan: txSpan}
+}
+
+func (t *tracedDBTX) Exec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
+	ctx, span := t.startQuerySpan(ctx, query)
+	defer span.End()
+
+	tag, err := t.db.Exec(ctx, query, args...)
+	recordSpanError(span, err)
+	return tag, err
+}
+
+// Query returns rows whose span ends when they are closed, as pgx reads the
+// result of the query while the rows are iterated.
+func (t *tracedDBTX) Query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	ctx, span := t.startQuerySpan(ctx, query)
+
+	rows, err := t.db.Query(ctx, query, args...)
+	if err != nil {
+		recordSpanError(span, err)
+		span.End()
+		return rows, err
+	}
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+type tracedRows struct {
+	pgx.Rows
+	span   trace.Span
+	closed bool
+}
+
+// Close ends the span with the error that stopped the iteration, if any. The
+// rows may be closed more than once.
+func (r *tracedRows) Close() {
+	r.Rows.Close()
+	if r.closed {
+		return
+	}
+	r.closed = true
+
+	recordSpanError(r.span, r.Rows.Err())
+	r.span.End()
+}
+
+// QueryRow returns a row whose span ends when it is scanned, as pgx only
+// reads the result of the query then.
+func (t *tracedDBTX) QueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
+	ctx, span := t.startQuerySpan(ctx, query)
+	return &tracedRow{row: t.db.QueryRow(ctx, query, args...), span: span}
+}
+
+type tracedRow struct {
+	row  pgx.Row
+	span trace.Span
+}
+
+func (r *tracedRow) Scan(dest ...interface{}) error {
+	defer r.span.End()
+
+	err := r.row.Scan(dest...)
+	recordSpanError(r.span, err)
+	return err
+}
+
+func (t *tracedDBTX) startQuerySpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	if t.txSpan != nil {
+		ctx = trace.ContextWithSpan(ctx, t.txSpan)
+	}
+
+	name := queryName(query)
+	return tracing.Tracer().Start(ctx, "db."+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperation(name),
+			semconv.DBStatement(query),
+		),
+	)
+}
+
+// queryName extracts the query name from the "-- name: GetAccount :one"
+// header that sqlc puts at the top of every generated query.
+func queryName(query string) string {
+	const prefix = "-- name: "
+	if !strings.HasPrefix(query, prefix) {
+		return "query"
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(query, prefix))
+	if len(fields) == 0 {
+		return "query"
+	}
+	return fields[0]
+}
+
+func recordSpanError(span trace.Span, err error) {
+	if err == nil || errors.Is(err, pgx.ErrNoRows) {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+func startTxSpan(ctx context.Context) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "db.tx",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+}
+
+func txOutcome(outcome string) attribute.KeyValue {
+	return attribute.String("db.tx.outcome", outcome)
+}
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestQueryName(t *testing.T) {
+	assert.Equal(t, "GetAccount", queryName(getAccount))
+	assert.Equal(t, "TransferTx", queryName("-- name: TransferTx :one\nSELECT 1"))
+	assert.Equal(t, "query", queryName("SELECT 1"))
+	assert.Equal(t, "query", queryName("-- name: "))
+}
+
+// stubRows yields n rows and then fails with err.
+type stubRows struct {
+	pgx.Rows
+	n   int
+	err error
+}
+
+func (r *stubRows) Next() bool {
+	if r.n == 0 {
+		return false
+	}
+	r.n--
+	return true
+}
+
+func (r *stubRows) Err() error {
+	if r.n > 0 {
+		return nil
+	}
+	return r.err
+}
+
+func (r *stubRows) Close() {}
+
+type stubDBTX struct {
+	DBTX
+	rows *stubRows
+}
+
+func (db *stubDBTX) Query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	return db.rows, nil
+}
+
+func TestTracedQueryEndsSpanOnClose(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	readErr := errors.New("connection reset")
+	traced := newTracedDBTX(&stubDBTX{rows: &stubRows{n: 2, err: readErr}})
+
+	rows, err := traced.Query(context.Background(), listAccounts)
+	require.NoError(t, err)
+
+	// the span covers the reading of the rows
+	for rows.Next() {
+		require.Empty(t, recorder.Ended())
+	}
+	require.Empty(t, recorder.Ended())
+
+	rows.Close()
+	rows.Close()
+	require.Len(t, recorder.Ended(), 1)
+
+	span := recorder.Ended()[0]
+	assert.Equal(t, "db.ListAccounts", span.Name())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, readErr.Error(), span.Status().Description)
+}
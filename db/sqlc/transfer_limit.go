@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const (
+	TransferLimitPerTransfer = "per_transfer"
+	TransferLimitDaily       = "daily"
+	TransferLimitMonthly     = "monthly"
+)
+
+// checkTransferLimits returns a *TransferLimitError when transferring amount
+// out of the account would exceed one of its limits. The limits of a user
+// override the ones of the account type, and the windows are the UTC day and
+// month of now. The caller must hold the lock on the account, so that
+// concurrent transfers cannot both pass the check.
+func checkTransferLimits(ctx context.Context, q *Queries, accountID int64, amount int64, now time.Time) error {
+	limits, err := q.GetTransferLimits(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	now = now.UTC()
+	totals, err := q.GetOutgoingTransferTotals(ctx, GetOutgoingTransferTotalsParams{
+		AccountID:  accountID,
+		DayStart:   time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		MonthStart: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		return err
+	}
+
+	checks := []struct {
+		limit string
+		max   sql.NullInt64
+		used  int64
+	}{
+		{TransferLimitPerTransfer, overrideLimit(limits.UserMaxTransferAmount, limits.MaxTransferAmount), 0},
+		{TransferLimitDaily, overrideLimit(limits.UserDailyTransferLimit, limits.DailyTransferLimit), totals.DailyTotal},
+		{TransferLimitMonthly, overrideLimit(limits.UserMonthlyTransferLimit, limits.MonthlyTransferLimit), totals.MonthlyTotal},
+	}
+
+	var exceeded *TransferLimitError
+	remaining := int64(-1)
+	for _, check := range checks {
+		if !check.max.Valid {
+			continue
+		}
+
+		left := check.max.Int64 - check.used
+		if left < 0 {
+			left = 0
+		}
+		if remaining < 0 || left < remaining {
+			remaining = left
+		}
+
+		if exceeded == nil && amount > left {
+			exceeded = &TransferLimitError{
+				AccountID: accountID,
+				Limit:     check.limit,
+				Max:       check.max.Int64,
+			}
+		}
+	}
+
+	if exceeded == nil {
+		return nil
+	}
+	exceeded.Remaining = remaining
+	return exceeded
+}
+
+func overrideLimit(override sql.NullInt64, limit sql.NullInt64) sql.NullInt64 {
+	if override.Valid {
+		return override
+	}
+	return limit
+}
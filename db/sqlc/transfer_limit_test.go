@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setRandomUserTransferLimits(t *testing.T, username string, maxAmount int64, daily int64, monthly int64) UserTransferLimit {
+	arg := UpsertUserTransferLimitsParams{
+		Username:             username,
+		MaxTransferAmount:    sql.NullInt64{Int64: maxAmount, Valid: maxAmount > 0},
+		DailyTransferLimit:   sql.NullInt64{Int64: daily, Valid: daily > 0},
+		MonthlyTransferLimit: sql.NullInt64{Int64: monthly, Valid: monthly > 0},
+		UpdatedBy:            username,
+	}
+
+	limits, err := testQueries.UpsertUserTransferLimits(context.Background(), arg)
+	assert.NoError(t, err)
+	assert.Equal(t, arg.Username, limits.Username)
+	assert.Equal(t, arg.MaxTransferAmount, limits.MaxTransferAmount)
+	assert.Equal(t, arg.DailyTransferLimit, limits.DailyTransferLimit)
+	assert.Equal(t, arg.MonthlyTransferLimit, limits.MonthlyTransferLimit)
+
+	return limits
+}
+
+func TestTransferTxPerTransferLimit(t *testing.T) {
+	store := NewStore(testDB)
+	from := createRandomAccount(t)
+	to := createRandomAccount(t)
+	from = fundAccount(t, from, 10)
+	setRandomUserTransferLimits(t, from.Owner, 5, 0, 0)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        6,
+	})
+	assert.ErrorIs(t, err, ErrTransferLimitExceeded)
+
+	var limitErr *TransferLimitError
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, TransferLimitPerTransfer, limitErr.Limit)
+	assert.Equal(t, int64(5), limitErr.Max)
+	assert.Equal(t, int64(5), limitErr.Remaining)
+}
+
+func TestTransferTxDailyLimitConcurrent(t *testing.T) {
+	store := NewStore(testDB)
+	from := createRandomAccount(t)
+	to := createRandomAccount(t)
+	setRandomUserTransferLimits(t, from.Owner, 0, 30, 0)
+
+	// the balance covers all transfers, only the limit stops them
+	from = fundAccount(t, from, 100)
+
+	n := 5
+	amount := int64(10)
+	errsCh := make(chan error)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: from.ID,
+				ToAccountID:   to.ID,
+				Amount:        amount,
+			})
+			errsCh <- err
+		}()
+	}
+
+	succeeded := 0
+	for i := 0; i < n; i++ {
+		err := <-errsCh
+		if err == nil {
+			succeeded++
+			continue
+		}
+
+		var limitErr *TransferLimitError
+		assert.True(t, errors.As(err, &limitErr))
+		assert.Equal(t, TransferLimitDaily, limitErr.Limit)
+		assert.Zero(t, limitErr.Remaining)
+	}
+	assert.Equal(t, 3, succeeded)
+}
+
+func TestTransferTxUserLimitOverridesAccountType(t *testing.T) {
+	store := NewStore(testDB)
+	from := createRandomAccount(t)
+	to := createRandomAccount(t)
+
+	limits, err := testQueries.GetTransferLimits(context.Background(), from.ID)
+	assert.NoError(t, err)
+	assert.True(t, limits.DailyTransferLimit.Valid)
+	assert.False(t, limits.UserDailyTransferLimit.Valid)
+
+	// only the monthly limit is overridden, the others are the account type's
+	from = fundAccount(t, from, 10)
+	setRandomUserTransferLimits(t, from.Owner, 0, 0, 1)
+
+	limits, err = testQueries.GetTransferLimits(context.Background(), from.ID)
+	assert.NoError(t, err)
+	assert.True(t, limits.DailyTransferLimit.Valid)
+	assert.Equal(t, int64(1), limits.UserMonthlyTransferLimit.Int64)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        2,
+	})
+	var limitErr *TransferLimitError
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, TransferLimitMonthly, limitErr.Limit)
+	assert.Equal(t, int64(1), limitErr.Remaining)
+}
+
+func TestTransferTxLimitIgnoresReversals(t *testing.T) {
+	store := NewStore(testDB)
+	original := createRandomTransferTx(t, store, 10)
+	admin := createRandomUser(t)
+
+	// reversing the payment moves money out of the recipient's account, but
+	// the recipient did not make that transfer
+	recipient := original.ToAccount
+	setRandomUserTransferLimits(t, recipient.Owner, 0, 10, 0)
+	recipient = fundAccount(t, recipient, 10)
+
+	_, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+		Amount:     10,
+		Reason:     "refund",
+		ReversedBy: admin.Username,
+	})
+	assert.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: recipient.ID,
+		ToAccountID:   original.FromAccount.ID,
+		Amount:        10,
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+)
+
+type UpdateAccountStatusTxParams struct {
+	AccountID int64  `json:"account_id"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+	// username of the owner or admin making the change
+	ChangedBy string `json:"changed_by"`
+	// role of ChangedBy, which decides the changes they may make
+	ChangedByRole string `json:"changed_by_role"`
+}
+
+type UpdateAccountStatusTxResult struct {
+	Account      Account             `json:"account"`
+	StatusChange AccountStatusChange `json:"status_change"`
+	// the payment of the accrued interest when the account was frozen
+	InterestTransfer TransferTxResult `json:"interest_transfer"`
+}
+
+// UpdateAccountStatusTx moves the account to a new status and records who did
+// it and why. It fails with ErrInvalidAccountStatusTransition for a change that
+// is not allowed, with ErrAccountStatusChangeNotAllowed for a change the role
+// of ChangedBy may not make, and with ErrAccountBalanceNotZero when closing an
+// account that still holds money. The checks are made against the locked
+// account, so that a concurrent status change cannot get around them.
+//
+// Interest is only posted to active accounts, so freezing an account first
+// pays the whole units of its accrued interest, and closing it forfeits the
+// accrued interest, since a closed account holds no money.
+func (s *SQLStore) UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (UpdateAccountStatusTxResult, error) {
+	var result UpdateAccountStatusTxResult
+
+	err := s.execTxWithEvents(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if !util.IsValidAccountStatusTransition(account.Status, arg.Status) {
+			return fmt.Errorf("cannot change account %d from %s to %s: %w", account.ID, account.Status, arg.Status, ErrInvalidAccountStatusTransition)
+		}
+
+		if !util.CanChangeAccountStatus(arg.ChangedByRole, account.Status, arg.Status) {
+			return fmt.Errorf("only an admin can change account %d from %s to %s: %w", account.ID, account.Status, arg.Status, ErrAccountStatusChangeNotAllowed)
+		}
+
+		if arg.Status == util.AccountStatusClosed && account.Balance != 0 {
+			return fmt.Errorf("cannot close account %d with balance %d: %w", account.ID, account.Balance, ErrAccountBalanceNotZero)
+		}
+
+		switch {
+		case arg.Status == util.AccountStatusFrozen:
+			result.InterestTransfer, account, err = payAccruedInterest(ctx, q, account)
+		case arg.Status == util.AccountStatusClosed && account.AccruedInterest != 0:
+			account, err = q.AddAccountAccruedInterest(ctx, AddAccountAccruedInterestParams{
+				Amount: -account.AccruedInterest,
+				ID:     account.ID,
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			Status: arg.Status,
+			ID:     account.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.StatusChange, err = q.CreateAccountStatusChange(ctx, CreateAccountStatusChangeParams{
+			AccountID:  account.ID,
+			FromStatus: account.Status,
+			ToStatus:   arg.Status,
+			Reason:     arg.Reason,
+			ChangedBy:  arg.ChangedBy,
+		})
+		return err
+	}, func() []events.Event {
+		if result.InterestTransfer.Transfer.ID == 0 {
+			return nil
+		}
+		return []events.Event{transferCompleted(result.InterestTransfer)}
+	})
+
+	return result, err
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+)
+
+type AdjustBalanceTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// positive to credit the account, negative to debit it
+	Amount     int64  `json:"amount"`
+	Reason     string `json:"reason"`
+	AdjustedBy string `json:"adjusted_by"`
+}
+
+type AdjustBalanceTxResult struct {
+	Account    Account           `json:"account"`
+	Entry      Entry             `json:"entry"`
+	Adjustment BalanceAdjustment `json:"adjustment"`
+	// the transfer between the account and the system account of its currency
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// AdjustBalanceTx corrects the balance of an account with a transfer from or
+// to the system account of its currency, so that the ledger still balances,
+// and records who did it and why. A debit must be covered by the available
+// balance, and a closed account cannot be adjusted; a frozen one can.
+func (s *SQLStore) AdjustBalanceTx(ctx context.Context, arg AdjustBalanceTxParams) (AdjustBalanceTxResult, error) {
+	var result AdjustBalanceTxResult
+
+	err := s.execTxWithEvents(ctx, func(q *Queries) error {
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		systemAccount, err := q.GetAccountByOwnerAndCurrency(ctx, GetAccountByOwnerAndCurrencyParams{
+			Owner:    util.SystemUsername,
+			Currency: account.Currency,
+		})
+		if err != nil {
+			return err
+		}
+
+		transferArg := TransferTxParams{
+			FromAccountID: systemAccount.ID,
+			ToAccountID:   account.ID,
+			Amount:        arg.Amount,
+			Description:   arg.Reason,
+		}
+		if arg.Amount < 0 {
+			transferArg.FromAccountID, transferArg.ToAccountID = account.ID, systemAccount.ID
+			transferArg.Amount = -arg.Amount
+		}
+
+		fromAccount, toAccount, err := lockAccounts(ctx, q, transferArg.FromAccountID, transferArg.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		if arg.Amount < 0 {
+			account = fromAccount
+		} else {
+			account = toAccount
+		}
+
+		if account.Status == util.AccountStatusClosed {
+			return fmt.Errorf("account %d is %s: %w", account.ID, account.Status, ErrAccountNotActive)
+		}
+
+		if arg.Amount < 0 {
+			if err := checkAvailableFunds(account, -arg.Amount); err != nil {
+				return err
+			}
+		}
+
+		result.Transfer, err = recordTransfer(ctx, q, transferArg, sql.NullInt64{})
+		if err != nil {
+			return err
+		}
+
+		if arg.Amount < 0 {
+			result.Account, result.Entry = result.Transfer.FromAccount, result.Transfer.FromEntry
+		} else {
+			result.Account, result.Entry = result.Transfer.ToAccount, result.Transfer.ToEntry
+		}
+
+		result.Adjustment, err = q.CreateBalanceAdjustment(ctx, CreateBalanceAdjustmentParams{
+			AccountID:  account.ID,
+			EntryID:    result.Entry.ID,
+			Amount:     arg.Amount,
+			Reason:     arg.Reason,
+			AdjustedBy: arg.AdjustedBy,
+		})
+		return err
+	}, func() []events.Event {
+		return []events.Event{transferCompleted(result.Transfer)}
+	})
+
+	return result, err
+}
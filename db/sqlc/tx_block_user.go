@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+)
+
+type BlockUserTxParams struct {
+	Username string `json:"username"`
+}
+
+type BlockUserTxResult struct {
+	User User `json:"user"`
+	// sessions that could still renew tokens and were blocked
+	RevokedSessions []Session `json:"revoked_sessions"`
+}
+
+// BlockUserTx keeps a user from logging in again and blocks the sessions of
+// the user, so that they cannot renew their access tokens either. Access
+// tokens that were already issued stay valid until they expire.
+func (s *SQLStore) BlockUserTx(ctx context.Context, arg BlockUserTxParams) (BlockUserTxResult, error) {
+	var result BlockUserTxResult
+
+	err := s.execTxWithEvents(ctx, func(q *Queries) error {
+		var err error
+		result.User, err = q.UpdateUserBlocked(ctx, UpdateUserBlockedParams{
+			Username:  arg.Username,
+			IsBlocked: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.RevokedSessions, err = q.BlockUserSessions(ctx, arg.Username)
+		return err
+	}, func() []events.Event {
+		revokedAt := time.Now()
+		revoked := make([]events.Event, 0, len(result.RevokedSessions))
+		for _, session := range result.RevokedSessions {
+			revoked = append(revoked, events.SessionRevoked{
+				SessionID: session.ID,
+				Username:  session.Username,
+				RevokedAt: revokedAt,
+			})
+		}
+		return revoked
+	})
+
+	return result, err
+}
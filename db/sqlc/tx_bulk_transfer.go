@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+)
+
+type BulkTransferItem struct {
+	ToAccountID       int64           `json:"to_account_id"`
+	Amount            int64           `json:"amount"`
+	Description       string          `json:"description"`
+	ExternalReference sql.NullString  `json:"external_reference"`
+	Metadata          json.RawMessage `json:"metadata"`
+}
+
+type BulkTransferTxParams struct {
+	FromAccountID int64              `json:"from_account_id"`
+	Items         []BulkTransferItem `json:"items"`
+	// true to execute all of the items or none of them, false to execute the
+	// items that can be and report the others as failed
+	Atomic bool `json:"atomic"`
+}
+
+type BulkTransferItemResult struct {
+	Transfer TransferTxResult `json:"transfer"`
+	// why the item was not executed, only set in best-effort mode. Database
+	// errors are translated like the ones of the queries.
+	Err error `json:"-"`
+}
+
+type BulkTransferTxResult struct {
+	// in the order of the items
+	Results     []BulkTransferItemResult `json:"results"`
+	FromAccount Account                  `json:"from_account"`
+}
+
+// BulkTransferTx executes transfers from one account to many in a single
+// transaction. Every item is checked like a transfer of TransferTx, in the
+// order of the items, so that earlier items count against the funds and
+// limits of later ones.
+//
+// In atomic mode the first failed item rolls back the whole transaction and a
+// BulkTransferItemError is returned. In best-effort mode each item runs in a
+// savepoint, so that a failed item is rolled back alone and reported in its
+// result, and the items that succeeded are committed.
+//
+// All the accounts are locked upfront in id order, the order every other
+// transaction locks accounts in, so that bulk transfers cannot deadlock with
+// them or with each other. An account that does not exist fails the whole
+// bulk transfer in both modes.
+func (s *SQLStore) BulkTransferTx(ctx context.Context, arg BulkTransferTxParams) (BulkTransferTxResult, error) {
+	var result BulkTransferTxResult
+
+	err := s.execTxWithEvents(ctx, func(q *Queries) error {
+		result.Results = make([]BulkTransferItemResult, len(arg.Items))
+
+		accountIDs := []int64{arg.FromAccountID}
+		for _, item := range arg.Items {
+			accountIDs = append(accountIDs, item.ToAccountID)
+		}
+		if err := lockAccountIDs(ctx, q, accountIDs); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for i, item := range arg.Items {
+			itemArg := TransferTxParams{
+				FromAccountID:     arg.FromAccountID,
+				ToAccountID:       item.ToAccountID,
+				Amount:            item.Amount,
+				Description:       item.Description,
+				ExternalReference: item.ExternalReference,
+				Metadata:          item.Metadata,
+			}
+
+			if arg.Atomic {
+				transfer, err := customerTransfer(ctx, q, itemArg, now)
+				if err != nil {
+					return &BulkTransferItemError{Index: i, Err: translateError(err)}
+				}
+				result.Results[i].Transfer = transfer
+				continue
+			}
+
+			transfer, itemErr, err := savepointTransfer(ctx, q, itemArg, now)
+			if err != nil {
+				return err
+			}
+			result.Results[i] = BulkTransferItemResult{Transfer: transfer, Err: translateError(itemErr)}
+		}
+
+		fromAccount, err := q.GetAccount(ctx, arg.FromAccountID)
+		if err != nil {
+			return err
+		}
+		result.FromAccount = fromAccount
+		return nil
+	}, func() []events.Event {
+		var completed []events.Event
+		for _, itemResult := range result.Results {
+			if itemResult.Err == nil {
+				completed = append(completed, transferCompleted(itemResult.Transfer))
+			}
+		}
+		return completed
+	})
+
+	return result, err
+}
+
+// savepointTransfer is customerTransfer within a savepoint that is rolled
+// back when the transfer fails, leaving the transaction usable. The failure of
+// the transfer is returned as itemErr, err is for the savepoint itself.
+func savepointTransfer(ctx context.Context, q *Queries, arg TransferTxParams, now time.Time) (result TransferTxResult, itemErr error, err error) {
+	if _, err = q.db.Exec(ctx, "SAVEPOINT bulk_transfer_item"); err != nil {
+		return
+	}
+
+	result, itemErr = customerTransfer(ctx, q, arg, now)
+	if itemErr != nil {
+		_, err = q.db.Exec(ctx, "ROLLBACK TO SAVEPOINT bulk_transfer_item")
+		return
+	}
+
+	_, err = q.db.Exec(ctx, "RELEASE SAVEPOINT bulk_transfer_item")
+	return
+}
+
+// lockAccountIDs locks the accounts in id order, skipping duplicates.
+func lockAccountIDs(ctx context.Context, q *Queries, accountIDs []int64) error {
+	ids := append([]int64(nil), accountIDs...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for i, id := range ids {
+		if i > 0 && id == ids[i-1] {
+			continue
+		}
+		if _, err := q.GetAccountForUpdate(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
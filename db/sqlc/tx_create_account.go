@@ -0,0 +1,37 @@
+package db
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+)
+
+type CreateAccountTxParams struct {
+	CreateAccountParams
+}
+
+type CreateAccountTxResult struct {
+	Account Account `json:"account"`
+}
+
+// CreateAccountTx opens an account and publishes events.AccountCreated once it
+// is committed.
+func (s *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (CreateAccountTxResult, error) {
+	var result CreateAccountTxResult
+
+	err := s.execTxWithEvents(ctx, func(q *Queries) error {
+		var err error
+		result.Account, err = q.CreateAccount(ctx, arg.CreateAccountParams)
+		return err
+	}, func() []events.Event {
+		return []events.Event{events.AccountCreated{
+			AccountID:   result.Account.ID,
+			Owner:       result.Account.Owner,
+			Currency:    result.Account.Currency,
+			AccountType: result.Account.AccountType,
+			CreatedAt:   result.Account.CreatedAt,
+		}}
+	})
+
+	return result, err
+}
@@ -0,0 +1,36 @@
+package db
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+)
+
+type CreateUserTxParams struct {
+	CreateUserParams
+}
+
+type CreateUserTxResult struct {
+	User User `json:"user"`
+}
+
+// CreateUserTx creates a user and publishes events.UserCreated once it is
+// committed.
+func (s *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error) {
+	var result CreateUserTxResult
+
+	err := s.execTxWithEvents(ctx, func(q *Queries) error {
+		var err error
+		result.User, err = q.CreateUser(ctx, arg.CreateUserParams)
+		return err
+	}, func() []events.Event {
+		return []events.Event{events.UserCreated{
+			Username:  result.User.Username,
+			FullName:  result.User.FullName,
+			Email:     result.User.Email,
+			CreatedAt: result.User.CreatedAt,
+		}}
+	})
+
+	return result, err
+}
@@ -0,0 +1,235 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+)
+
+type CreateHoldTxParams struct {
+	AccountID   int64     `json:"account_id"`
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type CreateHoldTxResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+}
+
+// CreateHoldTx reserves money of an account for a later transfer to another
+// account. The reserved money is no longer part of the available balance, and
+// stays reserved until the hold is captured, released or expires.
+func (s *SQLStore) CreateHoldTx(ctx context.Context, arg CreateHoldTxParams) (CreateHoldTxResult, error) {
+	var result CreateHoldTxResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		fromAccount, toAccount, err := lockAccounts(ctx, q, arg.AccountID, arg.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkAccountsActive(fromAccount, toAccount); err != nil {
+			return err
+		}
+
+		if err := checkAvailableFunds(fromAccount, arg.Amount); err != nil {
+			return err
+		}
+
+		result.Hold, err = q.CreateHold(ctx, CreateHoldParams{
+			AccountID:   arg.AccountID,
+			ToAccountID: arg.ToAccountID,
+			Amount:      arg.Amount,
+			ExpiresAt:   arg.ExpiresAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     arg.AccountID,
+			Amount: arg.Amount,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type CaptureHoldTxParams struct {
+	HoldID int64     `json:"hold_id"`
+	Now    time.Time `json:"now"`
+}
+
+type CaptureHoldTxResult struct {
+	Hold     Hold             `json:"hold"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// CaptureHoldTx turns an active hold into a transfer of the held amount. The
+// transfer must stay within the limits of the source account, which are only
+// checked now since a hold is not a transfer yet.
+func (s *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var result CaptureHoldTxResult
+
+	err := s.execTxWithEvents(ctx, func(q *Queries) error {
+		hold, err := lockActiveHold(ctx, q, arg.HoldID, arg.Now)
+		if err != nil {
+			return err
+		}
+
+		fromAccount, toAccount, err := lockAccounts(ctx, q, hold.AccountID, hold.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkAccountsActive(fromAccount, toAccount); err != nil {
+			return err
+		}
+
+		if _, err := q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     hold.AccountID,
+			Amount: -hold.Amount,
+		}); err != nil {
+			return err
+		}
+
+		if err := checkTransferLimits(ctx, q, hold.AccountID, hold.Amount, arg.Now); err != nil {
+			return err
+		}
+
+		result.Transfer, err = recordTransfer(ctx, q, TransferTxParams{
+			FromAccountID: hold.AccountID,
+			ToAccountID:   hold.ToAccountID,
+			Amount:        hold.Amount,
+			Description:   fmt.Sprintf("capture of hold %d", hold.ID),
+		}, sql.NullInt64{})
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = q.UpdateHoldStatus(ctx, UpdateHoldStatusParams{
+			ID:     hold.ID,
+			Status: util.HoldStatusCaptured,
+			TransferID: sql.NullInt64{
+				Int64: result.Transfer.Transfer.ID,
+				Valid: true,
+			},
+		})
+		return err
+	}, func() []events.Event {
+		return []events.Event{transferCompleted(result.Transfer)}
+	})
+
+	return result, err
+}
+
+type ReleaseHoldTxParams struct {
+	HoldID int64     `json:"hold_id"`
+	Now    time.Time `json:"now"`
+}
+
+type ReleaseHoldTxResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+}
+
+// ReleaseHoldTx cancels an active hold and makes its money available again.
+func (s *SQLStore) ReleaseHoldTx(ctx context.Context, arg ReleaseHoldTxParams) (ReleaseHoldTxResult, error) {
+	var result ReleaseHoldTxResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		hold, err := lockActiveHold(ctx, q, arg.HoldID, arg.Now)
+		if err != nil {
+			return err
+		}
+
+		result.Hold, result.Account, err = releaseHold(ctx, q, hold, util.HoldStatusReleased)
+		return err
+	})
+
+	return result, err
+}
+
+type ExpireHoldTxParams struct {
+	HoldID int64     `json:"hold_id"`
+	Now    time.Time `json:"now"`
+}
+
+type ExpireHoldTxResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+	// false when the hold was captured or released in the meantime, or has
+	// not expired yet
+	Expired bool `json:"expired"`
+}
+
+// ExpireHoldTx releases a hold that is past its expiry time.
+func (s *SQLStore) ExpireHoldTx(ctx context.Context, arg ExpireHoldTxParams) (ExpireHoldTxResult, error) {
+	var result ExpireHoldTxResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		hold, err := q.GetHoldForUpdate(ctx, arg.HoldID)
+		if err != nil {
+			return err
+		}
+		result.Hold = hold
+
+		if hold.Status != util.HoldStatusHeld || hold.ExpiresAt.After(arg.Now) {
+			return nil
+		}
+
+		result.Hold, result.Account, err = releaseHold(ctx, q, hold, util.HoldStatusExpired)
+		if err != nil {
+			return err
+		}
+
+		result.Expired = true
+		return nil
+	})
+
+	return result, err
+}
+
+// lockActiveHold locks a hold that can still be captured or released. An
+// expired hold is left for ExpireHoldTx.
+func lockActiveHold(ctx context.Context, q *Queries, holdID int64, now time.Time) (Hold, error) {
+	hold, err := q.GetHoldForUpdate(ctx, holdID)
+	if err != nil {
+		return hold, err
+	}
+
+	if hold.Status != util.HoldStatusHeld {
+		return hold, fmt.Errorf("hold %d is %s: %w", hold.ID, hold.Status, ErrHoldNotActive)
+	}
+
+	if !hold.ExpiresAt.After(now) {
+		return hold, fmt.Errorf("hold %d expired at %s: %w", hold.ID, hold.ExpiresAt.UTC().Format(time.RFC3339), ErrHoldExpired)
+	}
+
+	return hold, nil
+}
+
+// releaseHold gives the money of a locked active hold back to the available
+// balance and moves the hold to its final status.
+func releaseHold(ctx context.Context, q *Queries, hold Hold, status string) (Hold, Account, error) {
+	account, err := q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+		ID:     hold.AccountID,
+		Amount: -hold.Amount,
+	})
+	if err != nil {
+		return hold, account, err
+	}
+
+	hold, err = q.UpdateHoldStatus(ctx, UpdateHoldStatusParams{
+		ID:     hold.ID,
+		Status: status,
+	})
+	return hold, account, err
+}
@@ -0,0 +1,214 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/jackc/pgx/v5"
+)
+
+type AccrueInterestTxParams struct {
+	AccountID   int64     `json:"account_id"`
+	AccrualDate time.Time `json:"accrual_date"`
+}
+
+type AccrueInterestTxResult struct {
+	Account Account         `json:"account"`
+	Accrual InterestAccrual `json:"accrual"`
+	// false when the account was opened after the accrual date or interest
+	// for that date was already accrued
+	Accrued bool `json:"accrued"`
+}
+
+// AccrueInterestTx adds one day of interest on the end-of-day balance to the
+// accrued interest of the account. It is idempotent per account and day.
+func (s *SQLStore) AccrueInterestTx(ctx context.Context, arg AccrueInterestTxParams) (AccrueInterestTxResult, error) {
+	var result AccrueInterestTxResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		result.Account = account
+
+		day := startOfDay(arg.AccrualDate)
+		nextDay := day.AddDate(0, 0, 1)
+		if !account.CreatedAt.Before(nextDay) {
+			return nil
+		}
+
+		accountType, err := q.GetAccountType(ctx, account.AccountType)
+		if err != nil {
+			return err
+		}
+
+		// entries created after the accrual day must not earn interest for it
+		laterMovements, err := q.GetEntriesSumSince(ctx, GetEntriesSumSinceParams{
+			AccountID: account.ID,
+			CreatedAt: nextDay,
+		})
+		if err != nil {
+			return err
+		}
+		balance := account.Balance - laterMovements
+
+		result.Accrual, err = q.CreateInterestAccrual(ctx, CreateInterestAccrualParams{
+			AccountID:             account.ID,
+			AccrualDate:           day,
+			Balance:               balance,
+			AnnualInterestRateBps: accountType.AnnualInterestRateBps,
+			Amount:                util.DailyInterest(balance, accountType.AnnualInterestRateBps, day),
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			// already accrued for this day
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.AddAccountAccruedInterest(ctx, AddAccountAccruedInterestParams{
+			Amount: result.Accrual.Amount,
+			ID:     account.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Accrued = true
+		return nil
+	})
+
+	return result, err
+}
+
+type PostInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// any time within the month the interest is posted for
+	Period time.Time `json:"period"`
+}
+
+type PostInterestTxResult struct {
+	Account  Account          `json:"account"`
+	Posting  InterestPosting  `json:"posting"`
+	Transfer TransferTxResult `json:"transfer"`
+	// false when interest was already posted for the period
+	Posted bool `json:"posted"`
+}
+
+// PostInterestTx pays the whole units of accrued interest from the system
+// interest account of the same currency. The fraction of a unit that is left
+// is carried over to the next period. Nothing is posted for an account that
+// is not active.
+func (s *SQLStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+
+	period := startOfMonth(arg.Period)
+
+	err := s.execTxWithEvents(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		result.Account = account
+
+		result.Posting, err = q.GetInterestPosting(ctx, GetInterestPostingParams{
+			AccountID: account.ID,
+			Period:    period,
+		})
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		// a frozen account is paid once it is active again, and a closed one
+		// forfeited its interest
+		if account.Status != util.AccountStatusActive {
+			return nil
+		}
+
+		result.Transfer, result.Account, err = payAccruedInterest(ctx, q, account)
+		if err != nil {
+			return err
+		}
+
+		postingArg := CreateInterestPostingParams{
+			AccountID: account.ID,
+			Period:    period,
+			Amount:    result.Transfer.Transfer.Amount,
+		}
+		if result.Transfer.Transfer.ID != 0 {
+			postingArg.TransferID = sql.NullInt64{
+				Int64: result.Transfer.Transfer.ID,
+				Valid: true,
+			}
+		}
+
+		result.Posting, err = q.CreateInterestPosting(ctx, postingArg)
+		if err != nil {
+			return err
+		}
+
+		result.Posted = true
+		return nil
+	}, func() []events.Event {
+		if !result.Posting.TransferID.Valid {
+			return nil
+		}
+		return []events.Event{transferCompleted(result.Transfer)}
+	})
+
+	return result, err
+}
+
+// payAccruedInterest pays the whole units of the accrued interest of a locked
+// account from the system interest account of its currency, and returns the
+// account with the fraction of a unit that is carried over. Nothing is paid
+// when less than a unit was accrued.
+func payAccruedInterest(ctx context.Context, q *Queries, account Account) (TransferTxResult, Account, error) {
+	amount, _ := util.SplitAccruedInterest(account.AccruedInterest)
+	if amount == 0 {
+		return TransferTxResult{}, account, nil
+	}
+
+	interestAccount, err := q.GetAccountByOwnerAndCurrency(ctx, GetAccountByOwnerAndCurrencyParams{
+		Owner:    util.SystemUsername,
+		Currency: account.Currency,
+	})
+	if err != nil {
+		return TransferTxResult{}, account, err
+	}
+
+	result, err := transfer(ctx, q, TransferTxParams{
+		FromAccountID: interestAccount.ID,
+		ToAccountID:   account.ID,
+		Amount:        amount,
+		Description:   "interest",
+	})
+	if err != nil {
+		return result, account, err
+	}
+
+	account, err = q.AddAccountAccruedInterest(ctx, AddAccountAccruedInterestParams{
+		Amount: -amount * util.InterestScale,
+		ID:     account.ID,
+	})
+	return result, account, err
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func startOfMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
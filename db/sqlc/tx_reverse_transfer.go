@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+)
+
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// amount to give back, at most what is left to reverse of the transfer
+	Amount int64  `json:"amount"`
+	Reason string `json:"reason"`
+	// username of the admin reversing the transfer
+	ReversedBy string `json:"reversed_by"`
+}
+
+type ReverseTransferTxResult struct {
+	// the compensating transfer, from the recipient back to the sender
+	Transfer TransferTxResult `json:"transfer"`
+	Reversal TransferReversal `json:"reversal"`
+	// total amount of the original transfer reversed so far
+	ReversedAmount int64 `json:"reversed_amount"`
+}
+
+// ReverseTransferTx gives back all or part of a transfer with a compensating
+// transfer linked to it. The reversals of a transfer can never add up to more
+// than its amount, and a reversal cannot be reversed itself. The recipient must
+// still have the money available.
+func (s *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+
+	err := s.execTxWithEvents(ctx, func(q *Queries) error {
+		// locking the original transfer serializes its reversals
+		original, err := q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		if original.ReversalOfID.Valid {
+			return fmt.Errorf("transfer %d reverses transfer %d: %w", original.ID, original.ReversalOfID.Int64, ErrTransferIsReversal)
+		}
+
+		reversedAmount, err := q.GetReversedAmount(ctx, sql.NullInt64{Int64: original.ID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		if left := original.Amount - reversedAmount; arg.Amount > left {
+			return fmt.Errorf("transfer %d has %d left to reverse: %w", original.ID, left, ErrReversalExceedsTransfer)
+		}
+
+		recipient, sender, err := lockAccounts(ctx, q, original.ToAccountID, original.FromAccountID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkAccountsActive(recipient, sender); err != nil {
+			return err
+		}
+
+		if err := checkAvailableFunds(recipient, arg.Amount); err != nil {
+			return err
+		}
+
+		result.Transfer, err = recordTransfer(ctx, q, TransferTxParams{
+			FromAccountID: original.ToAccountID,
+			ToAccountID:   original.FromAccountID,
+			Amount:        arg.Amount,
+			Description:   fmt.Sprintf("reversal of transfer %d", original.ID),
+		}, sql.NullInt64{Int64: original.ID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		result.Reversal, err = q.CreateTransferReversal(ctx, CreateTransferReversalParams{
+			TransferID: result.Transfer.Transfer.ID,
+			Reason:     arg.Reason,
+			ReversedBy: arg.ReversedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ReversedAmount = reversedAmount + arg.Amount
+		return nil
+	}, func() []events.Event {
+		return []events.Event{transferCompleted(result.Transfer)}
+	})
+
+	return result, err
+}
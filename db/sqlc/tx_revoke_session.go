@@ -0,0 +1,40 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+	"github.com/google/uuid"
+)
+
+type RevokeSessionTxParams struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type RevokeSessionTxResult struct {
+	Session Session `json:"session"`
+}
+
+// RevokeSessionTx blocks a session so that it cannot renew access tokens any
+// more, and publishes events.SessionRevoked once it is committed.
+func (s *SQLStore) RevokeSessionTx(ctx context.Context, arg RevokeSessionTxParams) (RevokeSessionTxResult, error) {
+	var result RevokeSessionTxResult
+
+	err := s.execTxWithEvents(ctx, func(q *Queries) error {
+		var err error
+		result.Session, err = q.UpdateSessionBlocked(ctx, UpdateSessionBlockedParams{
+			ID:        arg.ID,
+			IsBlocked: true,
+		})
+		return err
+	}, func() []events.Event {
+		return []events.Event{events.SessionRevoked{
+			SessionID: result.Session.ID,
+			Username:  result.Session.Username,
+			RevokedAt: time.Now(),
+		}}
+	})
+
+	return result, err
+}
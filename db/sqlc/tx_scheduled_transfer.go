@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+)
+
+// FailureInsufficientFunds is recorded on an execution that was skipped
+// because the source account could not cover the amount.
+const FailureInsufficientFunds = "insufficient funds"
+
+type ExecuteScheduledTransferTxParams struct {
+	ScheduledTransferID int64     `json:"scheduled_transfer_id"`
+	Now                 time.Time `json:"now"`
+	// delay before an execution that failed for insufficient funds is retried
+	RetryDelay time.Duration `json:"retry_delay"`
+	// consecutive failed executions after which the transfer is suspended
+	MaxAttempts int32 `json:"max_attempts"`
+}
+
+type ExecuteScheduledTransferTxResult struct {
+	ScheduledTransfer ScheduledTransfer          `json:"scheduled_transfer"`
+	Execution         ScheduledTransferExecution `json:"execution"`
+	Transfer          TransferTxResult           `json:"transfer"`
+	// false when the scheduled transfer is no longer active or due, e.g. it
+	// was already executed by another worker
+	Executed bool `json:"executed"`
+	// true when this execution failed and the transfer got suspended
+	Suspended bool `json:"suspended"`
+}
+
+// ExecuteScheduledTransferTx runs one due occurrence of a scheduled transfer
+// and records the result. An occurrence that cannot be covered by the source
+// account is recorded as failed and retried after RetryDelay, until MaxAttempts
+// consecutive failures suspend the scheduled transfer. The same goes for an
+// occurrence over the daily or monthly transfer limit. It is suspended right
+// away when one of the accounts is not active or the amount is over the
+// per-transfer limit.
+func (s *SQLStore) ExecuteScheduledTransferTx(ctx context.Context, arg ExecuteScheduledTransferTxParams) (ExecuteScheduledTransferTxResult, error) {
+	var result ExecuteScheduledTransferTxResult
+
+	err := s.execTxWithEvents(ctx, func(q *Queries) error {
+		scheduledTransfer, err := q.GetScheduledTransferForUpdate(ctx, arg.ScheduledTransferID)
+		if err != nil {
+			return err
+		}
+		result.ScheduledTransfer = scheduledTransfer
+
+		if scheduledTransfer.Status != util.ScheduledTransferActive || scheduledTransfer.NextRunAt.After(arg.Now) {
+			return nil
+		}
+
+		fromAccount, toAccount, err := lockAccounts(ctx, q, scheduledTransfer.FromAccountID, scheduledTransfer.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		executionArg := CreateScheduledTransferExecutionParams{
+			ScheduledTransferID: scheduledTransfer.ID,
+			ScheduledAt:         scheduledTransfer.NextRunAt,
+		}
+		updateArg := UpdateScheduledTransferParams{
+			ID: scheduledTransfer.ID,
+		}
+
+		// failures that are not going to go away by themselves, like a frozen
+		// or closed account, suspend the transfer without retrying
+		var failureReason string
+		permanent := false
+		if err := checkAccountsActive(fromAccount, toAccount); err != nil {
+			failureReason = err.Error()
+			permanent = true
+		} else if fromAccount.AvailableBalance < scheduledTransfer.Amount {
+			failureReason = FailureInsufficientFunds
+		} else if err := checkTransferLimits(ctx, q, fromAccount.ID, scheduledTransfer.Amount, arg.Now); err != nil {
+			var limitErr *TransferLimitError
+			if !errors.As(err, &limitErr) {
+				return err
+			}
+			failureReason = limitErr.Error()
+			permanent = limitErr.Limit == TransferLimitPerTransfer
+		}
+
+		if failureReason != "" {
+			executionArg.Status = util.ExecutionFailed
+			executionArg.FailureReason = failureReason
+
+			failedAttempts := scheduledTransfer.FailedAttempts + 1
+			updateArg.FailedAttempts = sql.NullInt32{Int32: failedAttempts, Valid: true}
+
+			if permanent || failedAttempts >= arg.MaxAttempts {
+				updateArg.Status = sql.NullString{String: util.ScheduledTransferSuspended, Valid: true}
+				result.Suspended = true
+			} else {
+				updateArg.NextRunAt = sql.NullTime{Time: arg.Now.Add(arg.RetryDelay), Valid: true}
+			}
+		} else {
+			result.Transfer, err = recordTransfer(ctx, q, TransferTxParams{
+				FromAccountID: scheduledTransfer.FromAccountID,
+				ToAccountID:   scheduledTransfer.ToAccountID,
+				Amount:        scheduledTransfer.Amount,
+				Description:   fmt.Sprintf("scheduled transfer %d", scheduledTransfer.ID),
+			}, sql.NullInt64{})
+			if err != nil {
+				return err
+			}
+
+			executionArg.Status = util.ExecutionSucceeded
+			executionArg.TransferID = sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}
+
+			updateArg.FailedAttempts = sql.NullInt32{Int32: 0, Valid: true}
+			nextRunAt, done, err := nextRun(scheduledTransfer, arg.Now)
+			if err != nil {
+				return err
+			}
+			if done {
+				updateArg.Status = sql.NullString{String: util.ScheduledTransferCompleted, Valid: true}
+			} else {
+				updateArg.NextRunAt = sql.NullTime{Time: nextRunAt, Valid: true}
+			}
+		}
+
+		result.Execution, err = q.CreateScheduledTransferExecution(ctx, executionArg)
+		if err != nil {
+			return err
+		}
+
+		result.ScheduledTransfer, err = q.UpdateScheduledTransfer(ctx, updateArg)
+		if err != nil {
+			return err
+		}
+
+		result.Executed = true
+		return nil
+	}, func() []events.Event {
+		if !result.Executed || result.Execution.Status != util.ExecutionSucceeded {
+			return nil
+		}
+		return []events.Event{transferCompleted(result.Transfer)}
+	})
+
+	return result, err
+}
+
+// nextRun returns the next occurrence of a scheduled transfer after now, or
+// done when it is a one-off transfer or the recurrence has passed its end.
+// Occurrences missed while the transfer was retried are skipped, not caught up.
+func nextRun(scheduledTransfer ScheduledTransfer, now time.Time) (nextRunAt time.Time, done bool, err error) {
+	if !scheduledTransfer.Recurrence.Valid {
+		return time.Time{}, true, nil
+	}
+
+	nextRunAt, err = util.NextRecurrence(scheduledTransfer.Recurrence.String, now)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	if scheduledTransfer.EndAt.Valid && nextRunAt.After(scheduledTransfer.EndAt.Time) {
+		return time.Time{}, true, nil
+	}
+	return nextRunAt, false, nil
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func createRandomWebhookSubscription(t *testing.T, owner string, eventTypes ...string) WebhookSubscription {
+	arg := CreateWebhookSubscriptionParams{
+		Owner:      owner,
+		Url:        "https://example.com/" + util.RandomString(8),
+		Secret:     util.RandomString(32),
+		EventTypes: eventTypes,
+	}
+
+	subscription, err := testQueries.CreateWebhookSubscription(context.Background(), arg)
+	assert.NoError(t, err)
+	assert.Equal(t, arg.Owner, subscription.Owner)
+	assert.Equal(t, arg.Url, subscription.Url)
+	assert.Equal(t, arg.Secret, subscription.Secret)
+	assert.Equal(t, arg.EventTypes, subscription.EventTypes)
+
+	return subscription
+}
+
+func TestListWebhookSubscriptionsForEvent(t *testing.T) {
+	user := createRandomUser(t)
+	transfers := createRandomWebhookSubscription(t, user.Username, util.WebhookEventTransferCompleted)
+	both := createRandomWebhookSubscription(t, user.Username, util.WebhookEventAccountCreated, util.WebhookEventTransferCompleted)
+
+	subscriptions, err := testQueries.ListWebhookSubscriptionsForEvent(context.Background(), ListWebhookSubscriptionsForEventParams{
+		Owner:     user.Username,
+		EventType: util.WebhookEventTransferCompleted,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []WebhookSubscription{transfers, both}, subscriptions)
+
+	subscriptions, err = testQueries.ListWebhookSubscriptionsForEvent(context.Background(), ListWebhookSubscriptionsForEventParams{
+		Owner:     user.Username,
+		EventType: util.WebhookEventAccountCreated,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []WebhookSubscription{both}, subscriptions)
+}
+
+func TestWebhookDeliveryAttempts(t *testing.T) {
+	user := createRandomUser(t)
+	subscription := createRandomWebhookSubscription(t, user.Username, util.WebhookEventTransferCompleted)
+
+	delivery, err := testQueries.CreateWebhookDelivery(context.Background(), CreateWebhookDeliveryParams{
+		SubscriptionID: subscription.ID,
+		EventType:      util.WebhookEventTransferCompleted,
+		Payload:        json.RawMessage(`{"type": "transfer.completed"}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, util.WebhookDeliveryPending, delivery.Status)
+	assert.Zero(t, delivery.Attempts)
+
+	delivery, err = testQueries.UpdateWebhookDeliveryAttempt(context.Background(), UpdateWebhookDeliveryAttemptParams{
+		ID:               delivery.ID,
+		Status:           util.WebhookDeliveryPending,
+		LastResponseCode: sql.NullInt32{Int32: 503, Valid: true},
+		LastError:        sql.NullString{String: "unavailable", Valid: true},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), delivery.Attempts)
+	assert.Equal(t, int32(503), delivery.LastResponseCode.Int32)
+
+	deliveredAt := time.Now()
+	delivery, err = testQueries.UpdateWebhookDeliveryAttempt(context.Background(), UpdateWebhookDeliveryAttemptParams{
+		ID:               delivery.ID,
+		Status:           util.WebhookDeliverySucceeded,
+		LastResponseCode: sql.NullInt32{Int32: 200, Valid: true},
+		DeliveredAt:      sql.NullTime{Time: deliveredAt, Valid: true},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, util.WebhookDeliverySucceeded, delivery.Status)
+	assert.Equal(t, int32(2), delivery.Attempts)
+	assert.False(t, delivery.LastError.Valid)
+	assert.WithinDuration(t, deliveredAt, delivery.DeliveredAt.Time, time.Second)
+
+	deliveries, err := testQueries.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		SubscriptionID: subscription.ID,
+		Limit:          5,
+		Offset:         0,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []WebhookDelivery{delivery}, deliveries)
+
+	// the delivery log goes with the subscription
+	err = testQueries.DeleteWebhookSubscription(context.Background(), subscription.ID)
+	assert.NoError(t, err)
+
+	_, err = testQueries.GetWebhookDelivery(context.Background(), delivery.ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
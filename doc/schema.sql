@@ -8,6 +8,17 @@ CREATE TABLE "users" (
   "full_name" varchar NOT NULL,
   "email" varchar UNIQUE NOT NULL,
   "password_changed_at" timestamptz NOT NULL DEFAULT '0001-01-01',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "role" varchar NOT NULL DEFAULT 'depositor',
+  "is_blocked" boolean NOT NULL DEFAULT false
+);
+
+CREATE TABLE "account_types" (
+  "name" varchar PRIMARY KEY,
+  "annual_interest_rate_bps" integer NOT NULL DEFAULT 0,
+  "max_transfer_amount" bigint,
+  "daily_transfer_limit" bigint,
+  "monthly_transfer_limit" bigint,
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
@@ -16,14 +27,20 @@ CREATE TABLE "accounts" (
   "owner" varchar NOT NULL,
   "balance" bigint NOT NULL,
   "currency" varchar NOT NULL,
-  "created_at" timestamptz NOT NULL DEFAULT (now())
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "account_type" varchar NOT NULL DEFAULT 'checking',
+  "accrued_interest" bigint NOT NULL DEFAULT 0,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "held_amount" bigint NOT NULL DEFAULT 0,
+  "available_balance" bigint NOT NULL GENERATED ALWAYS AS ("balance" - "held_amount") STORED
 );
 
 CREATE TABLE "entries" (
   "id" bigserial PRIMARY KEY,
   "account_id" bigint NOT NULL,
   "amount" bigint NOT NULL,
-  "created_at" timestamptz NOT NULL DEFAULT (now())
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "transfer_id" bigint
 );
 
 CREATE TABLE "transfers" (
@@ -31,7 +48,11 @@ CREATE TABLE "transfers" (
   "from_account_id" bigint NOT NULL,
   "to_account_id" bigint NOT NULL,
   "amount" bigint NOT NULL,
-  "created_at" timestamptz NOT NULL DEFAULT (now())
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "reversal_of_id" bigint,
+  "description" varchar NOT NULL DEFAULT '',
+  "external_reference" varchar,
+  "metadata" jsonb NOT NULL DEFAULT '{}'
 );
 
 CREATE TABLE "sessions" (
@@ -45,22 +66,169 @@ CREATE TABLE "sessions" (
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_interest_rate_bps" integer NOT NULL,
+  "amount" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "interest_postings" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "period" date NOT NULL,
+  "amount" bigint NOT NULL,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "scheduled_transfers" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "recurrence" varchar,
+  "next_run_at" timestamptz NOT NULL,
+  "end_at" timestamptz,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "failed_attempts" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "scheduled_transfer_executions" (
+  "id" bigserial PRIMARY KEY,
+  "scheduled_transfer_id" bigint NOT NULL,
+  "scheduled_at" timestamptz NOT NULL,
+  "status" varchar NOT NULL,
+  "transfer_id" bigint,
+  "failure_reason" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "account_status_changes" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "from_status" varchar NOT NULL,
+  "to_status" varchar NOT NULL,
+  "reason" varchar NOT NULL,
+  "changed_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "payees" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "nickname" varchar NOT NULL,
+  "account_id" bigint,
+  "username" varchar,
+  "currency" varchar NOT NULL,
+  "available_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "user_transfer_limits" (
+  "username" varchar PRIMARY KEY,
+  "max_transfer_amount" bigint,
+  "daily_transfer_limit" bigint,
+  "monthly_transfer_limit" bigint,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "holds" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'held',
+  "expires_at" timestamptz NOT NULL,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "transfer_reversals" (
+  "transfer_id" bigint PRIMARY KEY,
+  "reason" varchar NOT NULL,
+  "reversed_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
 CREATE INDEX ON "accounts" ("owner");
 
 CREATE UNIQUE INDEX ON "accounts" ("owner", "currency");
 
 CREATE INDEX ON "entries" ("account_id");
 
+CREATE INDEX ON "entries" ("account_id", "created_at");
+
 CREATE INDEX ON "transfers" ("from_account_id");
 
 CREATE INDEX ON "transfers" ("to_account_id");
 
 CREATE INDEX ON "transfers" ("from_account_id", "to_account_id");
 
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+CREATE INDEX ON "transfers" ("reversal_of_id");
+
+CREATE UNIQUE INDEX ON "transfers" ("from_account_id", "external_reference");
+
+CREATE UNIQUE INDEX ON "interest_accruals" ("account_id", "accrual_date");
+
+CREATE UNIQUE INDEX ON "interest_postings" ("account_id", "period");
+
+CREATE TABLE "statement_exports" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "format" varchar NOT NULL,
+  "period_start" timestamptz NOT NULL,
+  "period_end" timestamptz NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "error" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "completed_at" timestamptz
+);
+
+CREATE INDEX ON "statement_exports" ("owner");
+
+CREATE INDEX ON "account_status_changes" ("account_id");
+
+CREATE UNIQUE INDEX ON "payees" ("owner", "nickname");
+
+CREATE INDEX ON "holds" ("account_id");
+
+CREATE INDEX ON "holds" ("status", "expires_at");
+
+CREATE INDEX ON "scheduled_transfers" ("owner");
+
+CREATE INDEX ON "scheduled_transfers" ("status", "next_run_at");
+
+CREATE INDEX ON "scheduled_transfer_executions" ("scheduled_transfer_id");
+
+COMMENT ON COLUMN "users"."role" IS 'depositor or admin';
+
+COMMENT ON COLUMN "accounts"."status" IS 'active, frozen or closed';
+
+COMMENT ON COLUMN "account_types"."annual_interest_rate_bps" IS 'annual interest rate in basis points';
+
+COMMENT ON COLUMN "accounts"."accrued_interest" IS 'unposted interest in millionths of the minor currency unit';
+
 COMMENT ON COLUMN "entries"."amount" IS 'can be negative or positive';
 
 COMMENT ON COLUMN "transfers"."amount" IS 'must be positive';
 
+COMMENT ON COLUMN "interest_accruals"."amount" IS 'in millionths of the minor currency unit';
+
+COMMENT ON COLUMN "interest_postings"."period" IS 'first day of the month the interest was posted for';
+
 ALTER TABLE "accounts" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
 
 ALTER TABLE "entries" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
@@ -70,3 +238,179 @@ ALTER TABLE "transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts
 ALTER TABLE "transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
 
 ALTER TABLE "sessions" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("account_type") REFERENCES "account_types" ("name");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+COMMENT ON COLUMN "scheduled_transfers"."amount" IS 'must be positive';
+
+COMMENT ON COLUMN "scheduled_transfers"."recurrence" IS 'cron expression, NULL for a one-off transfer';
+
+COMMENT ON COLUMN "scheduled_transfers"."failed_attempts" IS 'consecutive failed executions, reset on success';
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfer_executions" ADD FOREIGN KEY ("scheduled_transfer_id") REFERENCES "scheduled_transfers" ("id");
+
+ALTER TABLE "scheduled_transfer_executions" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+COMMENT ON COLUMN "statement_exports"."period_end" IS 'exclusive';
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "statement_exports" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "statement_exports" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_status_changes" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_status_changes" ADD FOREIGN KEY ("changed_by") REFERENCES "users" ("username");
+
+COMMENT ON COLUMN "payees"."account_id" IS 'target account, NULL when the target is a username';
+
+COMMENT ON COLUMN "payees"."username" IS 'target user, whose account in the currency receives the transfer';
+
+COMMENT ON COLUMN "payees"."available_at" IS 'end of the cooling-off period, no transfer to the payee before it';
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+COMMENT ON COLUMN "account_types"."max_transfer_amount" IS 'largest single outgoing transfer, NULL for no limit';
+
+COMMENT ON COLUMN "account_types"."daily_transfer_limit" IS 'outgoing transfers per UTC day, NULL for no limit';
+
+COMMENT ON COLUMN "account_types"."monthly_transfer_limit" IS 'outgoing transfers per UTC month, NULL for no limit';
+
+COMMENT ON TABLE "user_transfer_limits" IS 'overrides the limits of the account types for all accounts of the user, a NULL column keeps the limit of the account type';
+
+ALTER TABLE "user_transfer_limits" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "user_transfer_limits" ADD FOREIGN KEY ("updated_by") REFERENCES "users" ("username");
+
+COMMENT ON COLUMN "accounts"."held_amount" IS 'sum of the amounts of the active holds on the account';
+
+COMMENT ON COLUMN "accounts"."available_balance" IS 'balance that is not reserved by holds';
+
+COMMENT ON COLUMN "holds"."to_account_id" IS 'account that receives the money when the hold is captured';
+
+COMMENT ON COLUMN "holds"."status" IS 'held, captured, released or expired';
+
+COMMENT ON COLUMN "holds"."transfer_id" IS 'transfer the hold was captured into';
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+COMMENT ON COLUMN "transfers"."reversal_of_id" IS 'transfer this one reverses, fully or partially';
+
+COMMENT ON COLUMN "transfers"."external_reference" IS 'supplied by the client, unique per source account';
+
+COMMENT ON COLUMN "transfers"."metadata" IS 'JSON object supplied by the client';
+
+COMMENT ON TABLE "transfer_reversals" IS 'audit record of the compensating transfer of a reversal';
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("reversal_of_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("reversed_by") REFERENCES "users" ("username");
+
+CREATE TABLE "webhook_subscriptions" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "url" varchar NOT NULL,
+  "secret" varchar NOT NULL,
+  "event_types" varchar[] NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_deliveries" (
+  "id" bigserial PRIMARY KEY,
+  "subscription_id" bigint NOT NULL,
+  "event_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "last_response_code" int,
+  "last_error" varchar,
+  "delivered_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "webhook_subscriptions" ("owner");
+
+CREATE INDEX ON "webhook_deliveries" ("subscription_id", "id");
+
+COMMENT ON COLUMN "webhook_subscriptions"."secret" IS 'key of the HMAC-SHA256 signature of the deliveries';
+
+COMMENT ON COLUMN "webhook_deliveries"."status" IS 'pending, succeeded or dead';
+
+ALTER TABLE "webhook_subscriptions" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("subscription_id") REFERENCES "webhook_subscriptions" ("id") ON DELETE CASCADE;
+
+CREATE TABLE "balance_adjustments" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "entry_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "reason" varchar NOT NULL,
+  "adjusted_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "balance_adjustments" ("account_id");
+
+CREATE UNIQUE INDEX ON "balance_adjustments" ("entry_id");
+
+COMMENT ON COLUMN "users"."is_blocked" IS 'blocked users cannot log in';
+
+COMMENT ON TABLE "balance_adjustments" IS 'audit record of a manual correction of a balance';
+
+ALTER TABLE "balance_adjustments" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "balance_adjustments" ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "balance_adjustments" ADD FOREIGN KEY ("adjusted_by") REFERENCES "users" ("username");
+
+CREATE TABLE "seed_runs" (
+  "id" bigserial PRIMARY KEY,
+  "started_at" timestamptz NOT NULL DEFAULT (now()),
+  "completed_at" timestamptz
+);
+
+COMMENT ON TABLE "seed_runs" IS 'runs of the seed command, a run that did not complete left a partly seeded database';
+
+CREATE TABLE "statement_files" (
+  "export_id" bigint PRIMARY KEY,
+  "content" bytea NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON TABLE "statement_files" IS 'files of the completed statement exports, kept in the database so that the gateway can serve what the worker generated';
+
+ALTER TABLE "statement_files" ADD FOREIGN KEY ("export_id") REFERENCES "statement_exports" ("id") ON DELETE CASCADE;
+
+CREATE TABLE "event_outbox" (
+  "id" bigserial PRIMARY KEY,
+  "event_name" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON TABLE "event_outbox" IS 'domain events written by the transactions that made them happen, deleted once published by the worker';
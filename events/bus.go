@@ -0,0 +1,87 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Publisher hands events to whoever is interested in them. An error means
+// that some of them failed to handle the events, which are then published
+// again later: handlers see an event at least once.
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event) error
+}
+
+// NopPublisher drops every event.
+type NopPublisher struct{}
+
+func (NopPublisher) Publish(ctx context.Context, events ...Event) error { return nil }
+
+// Handler reacts to an event. An error is logged and does not stop the other
+// handlers, but fails the Publish.
+type Handler func(ctx context.Context, event Event) error
+
+type subscriber struct {
+	name    string
+	handler Handler
+}
+
+// Bus is a Publisher that calls the handlers subscribed to an event, in the
+// order they subscribed, before Publish returns. Handlers that do slow work
+// should hand it off, e.g. to a task queue.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string][]subscriber
+	all         []subscriber
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[string][]subscriber),
+	}
+}
+
+// Subscribe registers the handler for the events with the given names, or for
+// every event when no name is given. The name of the subscriber only shows up
+// in logs.
+func (b *Bus) Subscribe(name string, handler Handler, eventNames ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := subscriber{name: name, handler: handler}
+	if len(eventNames) == 0 {
+		b.all = append(b.all, sub)
+		return
+	}
+
+	for _, eventName := range eventNames {
+		b.subscribers[eventName] = append(b.subscribers[eventName], sub)
+	}
+}
+
+// Publish returns the first error of the handlers, once every handler got
+// every event.
+func (b *Bus) Publish(ctx context.Context, events ...Event) error {
+	var firstErr error
+	for _, event := range events {
+		b.mu.RLock()
+		subscribers := append(append([]subscriber{}, b.subscribers[event.EventName()]...), b.all...)
+		b.mu.RUnlock()
+
+		for _, sub := range subscribers {
+			if err := sub.handler(ctx, event); err != nil {
+				logrus.WithError(err).WithFields(logrus.Fields{
+					"event":      event.EventName(),
+					"subscriber": sub.name,
+				}).Error("failed to handle event")
+				if firstErr == nil {
+					firstErr = fmt.Errorf("subscriber %s failed to handle %s: %w", sub.name, event.EventName(), err)
+				}
+			}
+		}
+	}
+	return firstErr
+}
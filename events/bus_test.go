@@ -0,0 +1,66 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusPublish(t *testing.T) {
+	bus := NewBus()
+
+	var got []string
+	record := func(prefix string) Handler {
+		return func(ctx context.Context, event Event) error {
+			got = append(got, prefix+":"+event.EventName())
+			return nil
+		}
+	}
+
+	bus.Subscribe("transfers", record("transfers"), TransferCompletedEvent)
+	bus.Subscribe("failing", func(ctx context.Context, event Event) error {
+		got = append(got, "failing:"+event.EventName())
+		return errors.New("failed")
+	}, UserCreatedEvent, TransferCompletedEvent)
+	bus.Subscribe("all", record("all"))
+
+	err := bus.Publish(context.Background(), UserCreated{Username: "alice"}, TransferCompleted{TransferID: 1})
+	require.ErrorContains(t, err, "subscriber failing failed to handle user.created")
+
+	require.Equal(t, []string{
+		"failing:user.created",
+		"all:user.created",
+		"transfers:transfer.completed",
+		"failing:transfer.completed",
+		"all:transfer.completed",
+	}, got)
+}
+
+func TestBusPublishWithoutSubscribers(t *testing.T) {
+	bus := NewBus()
+	require.NoError(t, bus.Publish(context.Background(), SessionRevoked{Username: "alice"}))
+}
+
+func TestDecode(t *testing.T) {
+	event := TransferCompleted{
+		TransferID: 1,
+		FromOwner:  "alice",
+		ToOwner:    "bob",
+		Amount:     10,
+		Metadata:   json.RawMessage(`{"order":"42"}`),
+		CreatedAt:  time.Now().UTC().Truncate(time.Microsecond),
+	}
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	decoded, err := Decode(event.EventName(), payload)
+	require.NoError(t, err)
+	require.Equal(t, event, decoded)
+
+	_, err = Decode("user.deleted", payload)
+	require.ErrorContains(t, err, "unknown event")
+}
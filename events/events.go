@@ -0,0 +1,99 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	UserCreatedEvent       = "user.created"
+	AccountCreatedEvent    = "account.created"
+	TransferCompletedEvent = "transfer.completed"
+	SessionRevokedEvent    = "session.revoked"
+)
+
+// Event is something that happened in the domain. The transaction that made
+// it happen writes it to an outbox, from where it is published once committed.
+type Event interface {
+	EventName() string
+}
+
+// Decode reads back an event from its name and its JSON encoding.
+func Decode(name string, payload []byte) (Event, error) {
+	var event Event
+	var err error
+	switch name {
+	case UserCreatedEvent:
+		var e UserCreated
+		err = json.Unmarshal(payload, &e)
+		event = e
+	case AccountCreatedEvent:
+		var e AccountCreated
+		err = json.Unmarshal(payload, &e)
+		event = e
+	case TransferCompletedEvent:
+		var e TransferCompleted
+		err = json.Unmarshal(payload, &e)
+		event = e
+	case SessionRevokedEvent:
+		var e SessionRevoked
+		err = json.Unmarshal(payload, &e)
+		event = e
+	default:
+		return nil, fmt.Errorf("unknown event %s", name)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event %s: %w", name, err)
+	}
+	return event, nil
+}
+
+type UserCreated struct {
+	Username  string    `json:"username"`
+	FullName  string    `json:"full_name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (UserCreated) EventName() string { return UserCreatedEvent }
+
+type AccountCreated struct {
+	AccountID   int64     `json:"account_id"`
+	Owner       string    `json:"owner"`
+	Currency    string    `json:"currency"`
+	AccountType string    `json:"account_type"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (AccountCreated) EventName() string { return AccountCreatedEvent }
+
+// TransferCompleted is published for every transfer that moved money, be it
+// made by a customer or by the bank, e.g. interest or a reversal.
+type TransferCompleted struct {
+	TransferID        int64           `json:"transfer_id"`
+	FromAccountID     int64           `json:"from_account_id"`
+	FromOwner         string          `json:"from_owner"`
+	ToAccountID       int64           `json:"to_account_id"`
+	ToOwner           string          `json:"to_owner"`
+	Amount            int64           `json:"amount"`
+	Currency          string          `json:"currency"`
+	Description       string          `json:"description"`
+	ExternalReference string          `json:"external_reference,omitempty"`
+	Metadata          json.RawMessage `json:"metadata"`
+	ReversalOfID      int64           `json:"reversal_of_id,omitempty"`
+	CreatedAt         time.Time       `json:"created_at"`
+}
+
+func (TransferCompleted) EventName() string { return TransferCompletedEvent }
+
+type SessionRevoked struct {
+	SessionID uuid.UUID `json:"session_id"`
+	Username  string    `json:"username"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+func (SessionRevoked) EventName() string { return SessionRevokedEvent }
@@ -0,0 +1,15 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+)
+
+func (s *Server) getOwnedAccount(ctx context.Context, accountID int64, username string) (db.Account, error) {
+	account, err := s.service.OwnedAccount(ctx, username, accountID)
+	if err != nil {
+		return account, serviceError(err)
+	}
+	return account, nil
+}
@@ -0,0 +1,54 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/NguyenMinhKhanhBK/simple_bank/worker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getAdmin fetches the authenticated user and checks that it is an admin. The
+// returned error is a gRPC status error.
+func (s *Server) getAdmin(ctx context.Context, username string) (db.User, error) {
+	user, err := s.store.GetUser(ctx, username)
+	if err != nil {
+		return user, serviceError(fmt.Errorf("failed to get user: %w", err))
+	}
+
+	if user.Role != util.AdminRole {
+		return user, status.Errorf(codes.PermissionDenied, "only an admin can do this")
+	}
+
+	return user, nil
+}
+
+// authorizeAdmin authenticates the request and checks that it comes from an
+// admin. The returned error is a gRPC status error.
+func (s *Server) authorizeAdmin(ctx context.Context) (db.User, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return db.User{}, unauthenticatedError(err)
+	}
+
+	return s.getAdmin(ctx, authPayload.Username)
+}
+
+// AdminServer serves the back-office API. It shares the store and helpers of
+// Server, and every call requires an admin.
+type AdminServer struct {
+	pb.UnimplementedAdminServiceServer
+	*Server
+	taskInspector worker.TaskInspector
+}
+
+func NewAdminServer(server *Server, taskInspector worker.TaskInspector) *AdminServer {
+	return &AdminServer{
+		Server:        server,
+		taskInspector: taskInspector,
+	}
+}
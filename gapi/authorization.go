@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
 	"github.com/NguyenMinhKhanhBK/simple_bank/token"
 	"google.golang.org/grpc/metadata"
 )
@@ -14,33 +15,39 @@ const (
 	authorizationBearer = "bearer"
 )
 
+// authorizeUser authenticates the user of a call. Its errors are meant for
+// unauthenticatedError.
 func (s *Server) authorizeUser(ctx context.Context) (*token.Payload, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("missing metadata")
+		return nil, unauthenticated("missing metadata")
 	}
 
 	values := md.Get(authorizationHeader)
 	if len(values) == 0 {
-		return nil, fmt.Errorf("missing authorization header")
+		return nil, unauthenticated("missing authorization header")
 	}
 
-	authHeader := values[0]
+	return s.verifyAuthorizationHeader(ctx, values[0])
+}
+
+// verifyAuthorizationHeader checks a "Bearer <token>" header value and that
+// the user of the token is not blocked. It is shared with the plain HTTP
+// handlers of the gateway.
+func (s *Server) verifyAuthorizationHeader(ctx context.Context, authHeader string) (*token.Payload, error) {
 	fields := strings.Fields(authHeader)
 	if len(fields) < 2 {
-		return nil, fmt.Errorf("invalid authorization header format")
+		return nil, unauthenticated("invalid authorization header format")
 	}
 
 	authType := strings.ToLower(fields[0])
 	if authType != authorizationBearer {
-		return nil, fmt.Errorf("unsupported authorization type: %s", authType)
+		return nil, unauthenticated("unsupported authorization type: %s", authType)
 	}
 
-	accessToken := fields[1]
-	payload, err := s.tokenMaker.VerifyToken(accessToken)
-	if err != nil {
-		return nil, fmt.Errorf("invalid access token: %s", err)
-	}
+	return s.service.AuthenticateUser(ctx, fields[1])
+}
 
-	return payload, nil
+func unauthenticated(format string, args ...interface{}) error {
+	return &service.Error{Kind: service.ErrUnauthenticated, Err: fmt.Errorf(format, args...)}
 }
@@ -0,0 +1,140 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	mock_sqlc "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc/mock"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/token"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorizeUser(t *testing.T) {
+	user := db.User{Username: util.RandomOwner(), Role: util.DepositorRole}
+	blockedUser := db.User{Username: util.RandomOwner(), Role: util.DepositorRole, IsBlocked: true}
+
+	testCases := []struct {
+		name         string
+		buildStubs   func(store *mock_sqlc.MockStore)
+		buildContext func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkError   func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, time.Minute)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "BlockedUser",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(blockedUser.Username)).Times(1).Return(blockedUser, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, blockedUser.Username, time.Minute)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name: "UserNotFound",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.User{}, db.ErrNotFound)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, time.Minute)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.User{}, errors.New("connection refused"))
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, time.Minute)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.Equal(t, codes.Internal, status.Code(err))
+			},
+		},
+		{
+			name: "ExpiredToken",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, -time.Minute)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkError: func(t *testing.T, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mock_sqlc.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			_, err := server.authorizeUser(ctx)
+			tc.checkError(t, unauthenticatedError(err))
+		})
+	}
+}
+
+// TestCreateTransferBlockedUser checks that a blocked user cannot move money
+// with an access token issued before they were blocked.
+func TestCreateTransferBlockedUser(t *testing.T) {
+	user := db.User{Username: util.RandomOwner(), Role: util.DepositorRole, IsBlocked: true}
+
+	ctrl := gomock.NewController(t)
+	store := mock_sqlc.NewMockStore(ctrl)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, time.Minute)
+
+	_, err := server.CreateTransfer(ctx, &pb.CreateTransferRequest{
+		FromAccountId: 1,
+		Recipient:     &pb.CreateTransferRequest_ToAccountId{ToAccountId: 2},
+		Amount:        10,
+		Currency:      util.USD,
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
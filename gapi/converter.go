@@ -1,8 +1,14 @@
 package gapi
 
 import (
+	"encoding/json"
+	"time"
+
 	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
 	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/statement"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -13,6 +19,202 @@ func convertUser(user db.User) *pb.User {
 		Email:             user.Email,
 		PasswordChangedAt: timestamppb.New(user.PasswordChangedAt),
 		CreatedAt:         timestamppb.New(user.CreatedAt),
+		Role:              user.Role,
+		IsBlocked:         user.IsBlocked,
+	}
+
+}
+
+func convertSession(session db.Session) *pb.Session {
+	return &pb.Session{
+		Id:        session.ID.String(),
+		Username:  session.Username,
+		UserAgent: session.UserAgent,
+		ClientIp:  session.ClientIp,
+		IsBlocked: session.IsBlocked,
+		ExpiresAt: timestamppb.New(session.ExpiresAt),
+		CreatedAt: timestamppb.New(session.CreatedAt),
+	}
+}
+
+func convertBalanceAdjustment(adjustment db.BalanceAdjustment) *pb.BalanceAdjustment {
+	return &pb.BalanceAdjustment{
+		Id:         adjustment.ID,
+		AccountId:  adjustment.AccountID,
+		EntryId:    adjustment.EntryID,
+		Amount:     adjustment.Amount,
+		Reason:     adjustment.Reason,
+		AdjustedBy: adjustment.AdjustedBy,
+		CreatedAt:  timestamppb.New(adjustment.CreatedAt),
+	}
+}
+
+func convertScheduledTransfer(scheduledTransfer db.ScheduledTransfer) *pb.ScheduledTransfer {
+	rsp := &pb.ScheduledTransfer{
+		Id:             scheduledTransfer.ID,
+		Owner:          scheduledTransfer.Owner,
+		FromAccountId:  scheduledTransfer.FromAccountID,
+		ToAccountId:    scheduledTransfer.ToAccountID,
+		Amount:         scheduledTransfer.Amount,
+		Recurrence:     scheduledTransfer.Recurrence.String,
+		NextRunAt:      timestamppb.New(scheduledTransfer.NextRunAt),
+		Status:         scheduledTransfer.Status,
+		FailedAttempts: scheduledTransfer.FailedAttempts,
+		CreatedAt:      timestamppb.New(scheduledTransfer.CreatedAt),
+		UpdatedAt:      timestamppb.New(scheduledTransfer.UpdatedAt),
+	}
+	if scheduledTransfer.EndAt.Valid {
+		rsp.EndAt = timestamppb.New(scheduledTransfer.EndAt.Time)
+	}
+	return rsp
+}
+
+func convertScheduledTransferExecution(execution db.ScheduledTransferExecution) *pb.ScheduledTransferExecution {
+	return &pb.ScheduledTransferExecution{
+		Id:                  execution.ID,
+		ScheduledTransferId: execution.ScheduledTransferID,
+		ScheduledAt:         timestamppb.New(execution.ScheduledAt),
+		Status:              execution.Status,
+		TransferId:          execution.TransferID.Int64,
+		FailureReason:       execution.FailureReason,
+		CreatedAt:           timestamppb.New(execution.CreatedAt),
+	}
+}
+
+func convertStatementExport(export db.StatementExport) *pb.StatementExport {
+	rsp := &pb.StatementExport{
+		Id:          export.ID,
+		AccountId:   export.AccountID,
+		Format:      export.Format,
+		PeriodStart: timestamppb.New(export.PeriodStart),
+		PeriodEnd:   timestamppb.New(export.PeriodEnd),
+		Status:      export.Status,
+		Error:       export.Error,
+		CreatedAt:   timestamppb.New(export.CreatedAt),
+	}
+	if export.Status == statement.ExportCompleted {
+		rsp.DownloadUrl = statementExportDownloadURL(export.ID)
+	}
+	if export.CompletedAt.Valid {
+		rsp.CompletedAt = timestamppb.New(export.CompletedAt.Time)
+	}
+	return rsp
+}
+
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:               account.ID,
+		Owner:            account.Owner,
+		Balance:          account.Balance,
+		Currency:         account.Currency,
+		AccountType:      account.AccountType,
+		Status:           account.Status,
+		CreatedAt:        timestamppb.New(account.CreatedAt),
+		AvailableBalance: account.AvailableBalance,
+	}
+}
+
+func convertTransfer(transfer db.Transfer) *pb.Transfer {
+	return &pb.Transfer{
+		Id:                transfer.ID,
+		FromAccountId:     transfer.FromAccountID,
+		ToAccountId:       transfer.ToAccountID,
+		Amount:            transfer.Amount,
+		CreatedAt:         timestamppb.New(transfer.CreatedAt),
+		ReversalOfId:      transfer.ReversalOfID.Int64,
+		Description:       transfer.Description,
+		ExternalReference: transfer.ExternalReference.String,
+		Metadata:          convertJSONObject(transfer.Metadata),
 	}
+}
 
+func convertEntry(entry db.ListAccountEntriesRow) *pb.Entry {
+	return &pb.Entry{
+		Id:                entry.ID,
+		AccountId:         entry.AccountID,
+		Amount:            entry.Amount,
+		CreatedAt:         timestamppb.New(entry.CreatedAt),
+		TransferId:        entry.TransferID.Int64,
+		Description:       entry.Description,
+		ExternalReference: entry.ExternalReference.String,
+		Metadata:          convertJSONObject(entry.Metadata),
+	}
+}
+
+// convertJSONObject returns nil for data that is not a JSON object, which is
+// never stored as transfer metadata is validated on the way in.
+func convertJSONObject(data json.RawMessage) *structpb.Struct {
+	result := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, result); err != nil {
+		return nil
+	}
+	return result
+}
+
+func convertPayee(payee db.Payee) *pb.Payee {
+	return &pb.Payee{
+		Id:          payee.ID,
+		Nickname:    payee.Nickname,
+		AccountId:   payee.AccountID.Int64,
+		Username:    payee.Username.String,
+		Currency:    payee.Currency,
+		AvailableAt: timestamppb.New(payee.AvailableAt),
+		CreatedAt:   timestamppb.New(payee.CreatedAt),
+		UpdatedAt:   timestamppb.New(payee.UpdatedAt),
+	}
+}
+
+func convertUserTransferLimits(limits db.UserTransferLimit) *pb.UserTransferLimits {
+	rsp := &pb.UserTransferLimits{
+		Username:  limits.Username,
+		UpdatedBy: limits.UpdatedBy,
+		UpdatedAt: timestamppb.New(limits.UpdatedAt),
+	}
+	if limits.MaxTransferAmount.Valid {
+		rsp.MaxTransferAmount = &limits.MaxTransferAmount.Int64
+	}
+	if limits.DailyTransferLimit.Valid {
+		rsp.DailyTransferLimit = &limits.DailyTransferLimit.Int64
+	}
+	if limits.MonthlyTransferLimit.Valid {
+		rsp.MonthlyTransferLimit = &limits.MonthlyTransferLimit.Int64
+	}
+	return rsp
+}
+
+func convertWebhookSubscription(subscription db.WebhookSubscription) *pb.WebhookSubscription {
+	return &pb.WebhookSubscription{
+		Id:         subscription.ID,
+		Url:        subscription.Url,
+		EventTypes: subscription.EventTypes,
+		CreatedAt:  timestamppb.New(subscription.CreatedAt),
+	}
+}
+
+func convertWebhookDelivery(delivery db.WebhookDelivery) *pb.WebhookDelivery {
+	rsp := &pb.WebhookDelivery{
+		Id:               delivery.ID,
+		SubscriptionId:   delivery.SubscriptionID,
+		EventType:        delivery.EventType,
+		Payload:          convertJSONObject(delivery.Payload),
+		Status:           delivery.Status,
+		Attempts:         delivery.Attempts,
+		LastResponseCode: delivery.LastResponseCode.Int32,
+		LastError:        delivery.LastError.String,
+		CreatedAt:        timestamppb.New(delivery.CreatedAt),
+		UpdatedAt:        timestamppb.New(delivery.UpdatedAt),
+	}
+	if delivery.DeliveredAt.Valid {
+		rsp.DeliveredAt = timestamppb.New(delivery.DeliveredAt.Time)
+	}
+	return rsp
+}
+
+// optionalTime returns nil when the timestamp is not set.
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
 }
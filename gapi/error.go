@@ -1,6 +1,10 @@
 package gapi
 
 import (
+	"errors"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/apierror"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -14,15 +18,20 @@ func fieldViolation(field string, err error) *errdetails.BadRequest_FieldViolati
 }
 
 func invalidArgumentError(violations []*errdetails.BadRequest_FieldViolation) error {
-	badRequest := &errdetails.BadRequest{FieldViolations: violations}
-	statusInvalid := status.New(codes.InvalidArgument, "invalid argument")
-	statusDetails, err := statusInvalid.WithDetails(badRequest)
-	if err != nil {
-		return statusInvalid.Err()
-	}
-	return statusDetails.Err()
+	return apierror.InvalidArgument(violations).Err()
 }
 
+// unauthenticatedError maps an error of authorizeUser to a status error. A
+// blocked user is denied, and a failure to look up the user is internal.
 func unauthenticatedError(err error) error {
-	return status.Errorf(codes.Unauthenticated, "unauthorized: %s", err)
+	if errors.Is(err, service.ErrUnauthenticated) {
+		return status.Errorf(codes.Unauthenticated, "unauthorized: %s", err)
+	}
+	return serviceError(err)
+}
+
+// serviceError maps an error of the service, or of the store, to a status
+// error.
+func serviceError(err error) error {
+	return apierror.Error(err)
 }
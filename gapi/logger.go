@@ -58,6 +58,14 @@ func (rr *ResponseRecorder) Write(body []byte) (int, error) {
 	return rr.ResponseWriter.Write(body)
 }
 
+// Flush lets streaming handlers, like server-sent events, flush through the
+// recorder.
+func (rr *ResponseRecorder) Flush() {
+	if flusher, ok := rr.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 func HTTPLogger(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		startTime := time.Now()
@@ -0,0 +1,50 @@
+package gapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// readYourWritesHeader lets a client make every read of a request go to the
+// primary, e.g. right after a write it made in an earlier request.
+const readYourWritesHeader = "x-read-your-writes"
+
+// ReadYourWrites is a unary interceptor that makes the reads of a request see
+// the writes made earlier in the request, and every committed write when the
+// client sets the x-read-your-writes metadata.
+func ReadYourWrites(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp interface{}, err error) {
+	var values []string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		values = md.Get(readYourWritesHeader)
+	}
+	return handler(readYourWritesContext(ctx, values), req)
+}
+
+// HTTPReadYourWrites is ReadYourWrites for the gateway, with the
+// X-Read-Your-Writes header.
+func HTTPReadYourWrites(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := readYourWritesContext(req.Context(), req.Header.Values(readYourWritesHeader))
+		handler.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+func readYourWritesContext(ctx context.Context, values []string) context.Context {
+	ctx = db.WithReadYourWrites(ctx)
+	if len(values) > 0 {
+		if primary, _ := strconv.ParseBool(values[0]); primary {
+			ctx = db.WithPrimary(ctx)
+		}
+	}
+	return ctx
+}
@@ -0,0 +1,56 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// AdjustBalance credits or debits an account against the system account of
+// its currency, e.g. to correct a mistake. Every adjustment is recorded with
+// its reason.
+func (s *AdminServer) AdjustBalance(ctx context.Context, req *pb.AdjustBalanceRequest) (*pb.AdjustBalanceResponse, error) {
+	admin, err := s.authorizeAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if violations := validateAdjustBalanceRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := s.store.AdjustBalanceTx(ctx, db.AdjustBalanceTxParams{
+		AccountID:  req.GetAccountId(),
+		Amount:     req.GetAmount(),
+		Reason:     req.GetReason(),
+		AdjustedBy: admin.Username,
+	})
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to adjust balance: %w", err))
+	}
+
+	return &pb.AdjustBalanceResponse{
+		Account:    convertAccount(result.Account),
+		Adjustment: convertBalanceAdjustment(result.Adjustment),
+	}, nil
+}
+
+func validateAdjustBalanceRequest(req *pb.AdjustBalanceRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if req.GetAmount() == 0 {
+		violations = append(violations, fieldViolation("amount", fmt.Errorf("must not be zero")))
+	}
+
+	if err := val.ValidateReason(req.GetReason()); err != nil {
+		violations = append(violations, fieldViolation("reason", err))
+	}
+
+	return violations
+}
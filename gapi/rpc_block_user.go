@@ -0,0 +1,50 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BlockUser keeps a user from logging in and blocks their sessions. Access
+// tokens already issued stay valid until they expire.
+func (s *AdminServer) BlockUser(ctx context.Context, req *pb.BlockUserRequest) (*pb.BlockUserResponse, error) {
+	admin, err := s.authorizeAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if violations := validateBlockUserRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if req.GetUsername() == admin.Username {
+		return nil, status.Errorf(codes.FailedPrecondition, "an admin cannot block themselves")
+	}
+
+	result, err := s.store.BlockUserTx(ctx, db.BlockUserTxParams{
+		Username: req.GetUsername(),
+	})
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to block user: %w", err))
+	}
+
+	return &pb.BlockUserResponse{
+		User:            convertUser(result.User),
+		RevokedSessions: int32(len(result.RevokedSessions)),
+	}, nil
+}
+
+func validateBlockUserRequest(req *pb.BlockUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	return violations
+}
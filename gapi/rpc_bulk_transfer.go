@@ -0,0 +1,70 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
+	"google.golang.org/grpc/status"
+)
+
+// BulkTransfer executes transfers from one account of the user to many, all
+// of them or none of them in atomic mode.
+func (s *Server) BulkTransfer(ctx context.Context, req *pb.BulkTransferRequest) (*pb.BulkTransferResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	arg := service.BulkTransferParams{
+		FromAccountID: req.GetFromAccountId(),
+		Currency:      req.GetCurrency(),
+		Atomic:        req.GetAtomic(),
+	}
+	for _, item := range req.GetItems() {
+		metadata, err := marshalMetadata(item.GetMetadata())
+		if err != nil {
+			return nil, serviceError(fmt.Errorf("failed to marshal metadata: %w", err))
+		}
+
+		arg.Items = append(arg.Items, service.BulkTransferItem{
+			ToAccountID:       item.GetToAccountId(),
+			Amount:            item.GetAmount(),
+			Description:       item.GetDescription(),
+			ExternalReference: item.ExternalReference,
+			Metadata:          metadata,
+		})
+	}
+
+	result, err := s.service.BulkTransfer(ctx, authPayload.Username, arg)
+	if err != nil {
+		var itemErr *db.BulkTransferItemError
+		if errors.As(err, &itemErr) {
+			st := status.Convert(serviceError(itemErr.Err))
+			return nil, status.Errorf(st.Code(), "item %d: %s", itemErr.Index, st.Message())
+		}
+		return nil, serviceError(err)
+	}
+
+	rsp := &pb.BulkTransferResponse{
+		FromAccount: convertAccount(result.FromAccount),
+	}
+	for _, itemResult := range result.Results {
+		if itemResult.Err != nil {
+			st := status.Convert(serviceError(itemResult.Err))
+			rsp.Results = append(rsp.Results, &pb.BulkTransferItemResult{
+				ErrorCode:    st.Code().String(),
+				ErrorMessage: st.Message(),
+			})
+			continue
+		}
+		rsp.Results = append(rsp.Results, &pb.BulkTransferItemResult{
+			Transfer: convertTransfer(itemResult.Transfer.Transfer),
+		})
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,27 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
+)
+
+func (s *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	account, err := s.service.CreateAccount(ctx, authPayload.Username, service.CreateAccountParams{
+		Currency:    req.GetCurrency(),
+		AccountType: req.GetAccountType(),
+	})
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	return &pb.CreateAccountResponse{
+		Account: convertAccount(account),
+	}, nil
+}
@@ -0,0 +1,29 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
+)
+
+func (s *Server) CreatePayee(ctx context.Context, req *pb.CreatePayeeRequest) (*pb.CreatePayeeResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	payee, err := s.service.CreatePayee(ctx, authPayload.Username, service.CreatePayeeParams{
+		Nickname:  req.GetNickname(),
+		Currency:  req.GetCurrency(),
+		AccountID: req.GetAccountId(),
+		Username:  req.GetUsername(),
+	})
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	return &pb.CreatePayeeResponse{
+		Payee: convertPayee(payee),
+	}, nil
+}
@@ -0,0 +1,36 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
+)
+
+func (s *Server) CreateScheduledTransfer(ctx context.Context, req *pb.CreateScheduledTransferRequest) (*pb.CreateScheduledTransferResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	arg := service.CreateScheduledTransferParams{
+		FromAccountID: req.GetFromAccountId(),
+		ToAccountID:   req.GetToAccountId(),
+		Amount:        req.GetAmount(),
+		Currency:      req.GetCurrency(),
+		Recurrence:    req.Recurrence,
+		EndAt:         optionalTime(req.EndAt),
+	}
+	if req.StartAt != nil {
+		arg.StartAt = req.GetStartAt().AsTime()
+	}
+
+	scheduledTransfer, err := s.service.CreateScheduledTransfer(ctx, authPayload.Username, arg)
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	return &pb.CreateScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}, nil
+}
@@ -0,0 +1,84 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/statement"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+	"github.com/NguyenMinhKhanhBK/simple_bank/worker"
+	"github.com/hibiken/asynq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) CreateStatementExport(ctx context.Context, req *pb.CreateStatementExportRequest) (*pb.CreateStatementExportResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateCreateStatementExportRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := s.store.GetAccount(ctx, req.GetAccountId())
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to get account: %w", err))
+	}
+
+	if account.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "account does not belong to the authenticated user")
+	}
+
+	export, err := s.store.CreateStatementExport(ctx, db.CreateStatementExportParams{
+		Owner:       authPayload.Username,
+		AccountID:   account.ID,
+		Format:      req.GetFormat(),
+		PeriodStart: req.GetPeriodStart().AsTime(),
+		PeriodEnd:   req.GetPeriodEnd().AsTime(),
+	})
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to create statement export: %w", err))
+	}
+
+	taskPayload := &worker.PayloadGenerateStatement{
+		StatementExportID: export.ID,
+	}
+	err = s.taskDistributor.DistributeTaskGenerateStatement(ctx, taskPayload,
+		asynq.MaxRetry(3),
+		asynq.Queue(worker.QueueDefault),
+	)
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to distribute task to generate statement: %w", err))
+	}
+
+	return &pb.CreateStatementExportResponse{
+		StatementExport: convertStatementExport(export),
+	}, nil
+}
+
+func validateCreateStatementExportRequest(req *pb.CreateStatementExportRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if !statement.IsSupportedFormat(req.GetFormat()) {
+		violations = append(violations, fieldViolation("format", fmt.Errorf("must be either %s or %s", statement.FormatCSV, statement.FormatPDF)))
+	}
+
+	if req.PeriodStart == nil {
+		violations = append(violations, fieldViolation("period_start", fmt.Errorf("is required")))
+	}
+
+	if req.PeriodEnd == nil {
+		violations = append(violations, fieldViolation("period_end", fmt.Errorf("is required")))
+	} else if !req.GetPeriodEnd().AsTime().After(req.GetPeriodStart().AsTime()) {
+		violations = append(violations, fieldViolation("period_end", fmt.Errorf("must be after period_start")))
+	}
+
+	return violations
+}
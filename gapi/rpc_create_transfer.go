@@ -0,0 +1,58 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func (s *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	arg := service.CreateTransferParams{
+		FromAccountID:     req.GetFromAccountId(),
+		ToAccountID:       req.GetToAccountId(),
+		ToUsername:        req.GetToUsername(),
+		ToEmail:           req.GetToEmail(),
+		PayeeID:           req.GetPayeeId(),
+		Amount:            req.GetAmount(),
+		Currency:          req.GetCurrency(),
+		Description:       req.GetDescription(),
+		ExternalReference: req.ExternalReference,
+	}
+	arg.Metadata, err = marshalMetadata(req.GetMetadata())
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to marshal metadata: %w", err))
+	}
+
+	result, err := s.service.CreateTransfer(ctx, authPayload.Username, arg)
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	rsp := &pb.CreateTransferResponse{
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+	}
+	if _, ok := req.GetRecipient().(*pb.CreateTransferRequest_ToAccountId); ok {
+		rsp.ToAccount = convertAccount(result.ToAccount)
+	}
+	return rsp, nil
+}
+
+// marshalMetadata returns the metadata of a transfer as JSON, nil when it is
+// not set.
+func marshalMetadata(metadata *structpb.Struct) (json.RawMessage, error) {
+	if metadata == nil {
+		return nil, nil
+	}
+	return protojson.Marshal(metadata)
+}
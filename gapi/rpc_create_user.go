@@ -2,85 +2,23 @@ package gapi
 
 import (
 	"context"
-	"time"
 
-	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
 	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
-	"github.com/NguyenMinhKhanhBK/simple_bank/util"
-	"github.com/NguyenMinhKhanhBK/simple_bank/val"
-	"github.com/NguyenMinhKhanhBK/simple_bank/worker"
-	"github.com/hibiken/asynq"
-	"github.com/lib/pq"
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
 )
 
 func (s *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
-	if violations := validateCreateUserRequest(req); violations != nil {
-		return nil, invalidArgumentError(violations)
-	}
-
-	hashedPassword, err := util.HashPassword(req.GetPassword())
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
-	}
-
-	arg := db.CreateUserParams{
-		Username:       req.GetUsername(),
-		HashedPassword: hashedPassword,
-		FullName:       req.GetFullName(),
-		Email:          req.GetEmail(),
-	}
-
-	user, err := s.store.CreateUser(ctx, arg)
-	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "unique_violation":
-				return nil, status.Errorf(codes.AlreadyExists, "username already exists: %s", err)
-			}
-		}
-		return nil, status.Errorf(codes.Internal, "username already exists: %s", err)
-	}
-
-	// TODO: should call in db transaction
-	taskPayload := &worker.PayloadSendVerifyEmail{
-		Username: user.Username,
-	}
-
-	opts := []asynq.Option{
-		asynq.MaxRetry(10),
-		asynq.ProcessIn(10 * time.Second),
-		asynq.Queue("critical"),
-	}
-	err = s.taskDistributor.DistributeTaskSendVerifyEmail(ctx, taskPayload, opts...)
+	user, err := s.service.CreateUser(ctx, service.CreateUserParams{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+		FullName: req.GetFullName(),
+		Email:    req.GetEmail(),
+	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to distribute task to send verify email: %v", err)
+		return nil, serviceError(err)
 	}
 
 	return &pb.CreateUserResponse{
 		User: convertUser(user),
 	}, nil
-
-}
-
-func validateCreateUserRequest(req *pb.CreateUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
-	if err := val.ValidateUsername(req.GetUsername()); err != nil {
-		violations = append(violations, fieldViolation("username", err))
-	}
-
-	if err := val.ValidatePassword(req.GetPassword()); err != nil {
-		violations = append(violations, fieldViolation("password", err))
-	}
-
-	if err := val.ValidateEmail(req.GetEmail()); err != nil {
-		violations = append(violations, fieldViolation("email", err))
-	}
-
-	if err := val.ValidateFullName(req.GetFullName()); err != nil {
-		violations = append(violations, fieldViolation("full_name", err))
-	}
-
-	return violations
 }
@@ -0,0 +1,60 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+	"github.com/NguyenMinhKhanhBK/simple_bank/webhook"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func (s *Server) CreateWebhookSubscription(ctx context.Context, req *pb.CreateWebhookSubscriptionRequest) (*pb.CreateWebhookSubscriptionResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateCreateWebhookSubscriptionRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to generate secret: %w", err))
+	}
+
+	subscription, err := s.store.CreateWebhookSubscription(ctx, db.CreateWebhookSubscriptionParams{
+		Owner:      authPayload.Username,
+		Url:        req.GetUrl(),
+		Secret:     secret,
+		EventTypes: req.GetEventTypes(),
+	})
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to create webhook subscription: %w", err))
+	}
+
+	return &pb.CreateWebhookSubscriptionResponse{
+		Subscription: convertWebhookSubscription(subscription),
+		Secret:       subscription.Secret,
+	}, nil
+}
+
+func validateCreateWebhookSubscriptionRequest(req *pb.CreateWebhookSubscriptionRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateWebhookURL(req.GetUrl()); err != nil {
+		violations = append(violations, fieldViolation("url", err))
+	}
+
+	if len(req.GetEventTypes()) == 0 {
+		violations = append(violations, fieldViolation("event_types", fmt.Errorf("must contain at least one event type")))
+	}
+	for i, eventType := range req.GetEventTypes() {
+		if err := val.ValidateWebhookEventType(eventType); err != nil {
+			violations = append(violations, fieldViolation(fmt.Sprintf("event_types[%d]", i), err))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,20 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+)
+
+func (s *Server) DeletePayee(ctx context.Context, req *pb.DeletePayeeRequest) (*pb.DeletePayeeResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err := s.service.DeletePayee(ctx, authPayload.Username, req.GetId()); err != nil {
+		return nil, serviceError(err)
+	}
+
+	return &pb.DeletePayeeResponse{}, nil
+}
@@ -0,0 +1,25 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+)
+
+// DeleteScheduledTransfer cancels the scheduled transfer. The record is kept
+// so that its past executions can still be looked up.
+func (s *Server) DeleteScheduledTransfer(ctx context.Context, req *pb.DeleteScheduledTransferRequest) (*pb.DeleteScheduledTransferResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	scheduledTransfer, err := s.service.CancelScheduledTransfer(ctx, authPayload.Username, req.GetId())
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	return &pb.DeleteScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}, nil
+}
@@ -0,0 +1,58 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeleteWebhookSubscription deletes the subscription together with its
+// delivery log. Pending deliveries are dropped.
+func (s *Server) DeleteWebhookSubscription(ctx context.Context, req *pb.DeleteWebhookSubscriptionRequest) (*pb.DeleteWebhookSubscriptionResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateDeleteWebhookSubscriptionRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	subscription, err := s.getOwnedWebhookSubscription(ctx, req.GetId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.DeleteWebhookSubscription(ctx, subscription.ID); err != nil {
+		return nil, serviceError(fmt.Errorf("failed to delete webhook subscription: %w", err))
+	}
+
+	return &pb.DeleteWebhookSubscriptionResponse{}, nil
+}
+
+func (s *Server) getOwnedWebhookSubscription(ctx context.Context, id int64, username string) (db.WebhookSubscription, error) {
+	subscription, err := s.store.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		return subscription, serviceError(fmt.Errorf("failed to get webhook subscription: %w", err))
+	}
+
+	if subscription.Owner != username {
+		return subscription, status.Errorf(codes.PermissionDenied, "webhook subscription does not belong to the authenticated user")
+	}
+
+	return subscription, nil
+}
+
+func validateDeleteWebhookSubscriptionRequest(req *pb.DeleteWebhookSubscriptionRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,23 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+)
+
+func (s *Server) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.GetAccountResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	account, err := s.service.GetAccount(ctx, authPayload.Username, req.GetId())
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	return &pb.GetAccountResponse{
+		Account: convertAccount(account),
+	}, nil
+}
@@ -0,0 +1,23 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+)
+
+func (s *Server) GetPayee(ctx context.Context, req *pb.GetPayeeRequest) (*pb.GetPayeeResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	payee, err := s.service.GetPayee(ctx, authPayload.Username, req.GetId())
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	return &pb.GetPayeeResponse{
+		Payee: convertPayee(payee),
+	}, nil
+}
@@ -0,0 +1,28 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+)
+
+func (s *Server) GetScheduledTransfer(ctx context.Context, req *pb.GetScheduledTransferRequest) (*pb.GetScheduledTransferResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	scheduledTransfer, executions, err := s.service.GetScheduledTransfer(ctx, authPayload.Username, req.GetId())
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	rsp := &pb.GetScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}
+	for _, execution := range executions {
+		rsp.Executions = append(rsp.Executions, convertScheduledTransferExecution(execution))
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,44 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) GetStatementExport(ctx context.Context, req *pb.GetStatementExportRequest) (*pb.GetStatementExportResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateGetStatementExportRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	export, err := s.store.GetStatementExport(ctx, req.GetId())
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to get statement export: %w", err))
+	}
+
+	if export.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "statement export does not belong to the authenticated user")
+	}
+
+	return &pb.GetStatementExportResponse{
+		StatementExport: convertStatementExport(export),
+	}, nil
+}
+
+func validateGetStatementExportRequest(req *pb.GetStatementExportRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
+)
+
+func (s *Server) ListPayees(ctx context.Context, req *pb.ListPayeesRequest) (*pb.ListPayeesResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	payees, err := s.service.ListPayees(ctx, authPayload.Username, service.Page{
+		ID:   req.GetPageId(),
+		Size: req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	rsp := &pb.ListPayeesResponse{}
+	for _, payee := range payees {
+		rsp.Payees = append(rsp.Payees, convertPayee(payee))
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
+)
+
+func (s *Server) ListScheduledTransfers(ctx context.Context, req *pb.ListScheduledTransfersRequest) (*pb.ListScheduledTransfersResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	scheduledTransfers, err := s.service.ListScheduledTransfers(ctx, authPayload.Username, service.Page{
+		ID:   req.GetPageId(),
+		Size: req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	rsp := &pb.ListScheduledTransfersResponse{}
+	for _, scheduledTransfer := range scheduledTransfers {
+		rsp.ScheduledTransfers = append(rsp.ScheduledTransfers, convertScheduledTransfer(scheduledTransfer))
+	}
+
+	return rsp, nil
+}
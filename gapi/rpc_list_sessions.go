@@ -0,0 +1,31 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
+)
+
+// ListSessions lists the login sessions of the user, most recent first.
+func (s *Server) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	sessions, err := s.service.ListSessions(ctx, authPayload.Username, service.Page{
+		ID:   req.GetPageId(),
+		Size: req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	rsp := &pb.ListSessionsResponse{}
+	for _, session := range sessions {
+		rsp.Sessions = append(rsp.Sessions, convertSession(session))
+	}
+
+	return rsp, nil
+}
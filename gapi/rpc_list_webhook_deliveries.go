@@ -0,0 +1,60 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// ListWebhookDeliveries is the delivery log of a subscription of the user.
+func (s *Server) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateListWebhookDeliveriesRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	subscription, err := s.getOwnedWebhookSubscription(ctx, req.GetSubscriptionId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.store.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+		SubscriptionID: subscription.ID,
+		Limit:          req.GetPageSize(),
+		Offset:         (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to list webhook deliveries: %w", err))
+	}
+
+	rsp := &pb.ListWebhookDeliveriesResponse{}
+	for _, delivery := range deliveries {
+		rsp.Deliveries = append(rsp.Deliveries, convertWebhookDelivery(delivery))
+	}
+
+	return rsp, nil
+}
+
+func validateListWebhookDeliveriesRequest(req *pb.ListWebhookDeliveriesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetSubscriptionId()); err != nil {
+		violations = append(violations, fieldViolation("subscription_id", err))
+	}
+
+	if req.GetPageId() < 1 {
+		violations = append(violations, fieldViolation("page_id", fmt.Errorf("must be at least 1")))
+	}
+
+	if req.GetPageSize() < 5 || req.GetPageSize() > 10 {
+		violations = append(violations, fieldViolation("page_size", fmt.Errorf("must be between 5 and 10")))
+	}
+
+	return violations
+}
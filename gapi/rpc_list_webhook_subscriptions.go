@@ -0,0 +1,27 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+)
+
+func (s *Server) ListWebhookSubscriptions(ctx context.Context, req *pb.ListWebhookSubscriptionsRequest) (*pb.ListWebhookSubscriptionsResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	subscriptions, err := s.store.ListWebhookSubscriptions(ctx, authPayload.Username)
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to list webhook subscriptions: %w", err))
+	}
+
+	rsp := &pb.ListWebhookSubscriptionsResponse{}
+	for _, subscription := range subscriptions {
+		rsp.Subscriptions = append(rsp.Subscriptions, convertWebhookSubscription(subscription))
+	}
+
+	return rsp, nil
+}
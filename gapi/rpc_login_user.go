@@ -2,78 +2,30 @@ package gapi
 
 import (
 	"context"
-	"database/sql"
 
-	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
 	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
-	"github.com/NguyenMinhKhanhBK/simple_bank/util"
-	"github.com/NguyenMinhKhanhBK/simple_bank/val"
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
-	if violations := validateLoginUserRequest(req); violations != nil {
-		return nil, invalidArgumentError(violations)
-	}
-
-	user, err := s.store.GetUser(ctx, req.GetUsername())
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, status.Errorf(codes.NotFound, "user not found: %s", err)
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get user: %s", err)
-	}
-
-	err = util.CheckPassword(req.GetPassword(), user.HashedPassword)
-	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "password not match: %s", err)
-	}
-
-	accessToken, accessPayload, err := s.tokenMaker.CreateToken(user.Username, s.config.AccessTokenDuration)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create token: %s", err)
-	}
-
-	refreshToken, refreshPayload, err := s.tokenMaker.CreateToken(user.Username, s.config.RefreshTokenDuration)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create token: %s", err)
-	}
-
 	mtdt := s.extractMetadata(ctx)
-	session, err := s.store.CreateSession(ctx, db.CreateSessionParams{
-		ID:           refreshPayload.ID,
-		Username:     user.Username,
-		RefreshToken: refreshToken,
-		UserAgent:    mtdt.GetUserAgent(),
-		ClientIp:     mtdt.GetClientIP(),
-		IsBlocked:    false,
-		ExpiresAt:    refreshPayload.ExpiredAt,
+	result, err := s.service.LoginUser(ctx, service.LoginUserParams{
+		Username:  req.GetUsername(),
+		Password:  req.GetPassword(),
+		UserAgent: mtdt.GetUserAgent(),
+		ClientIP:  mtdt.GetClientIP(),
 	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create session: %s", err)
+		return nil, serviceError(err)
 	}
 
 	return &pb.LoginUserResponse{
-		User:                  convertUser(user),
-		SessionId:             session.ID.String(),
-		AccessToken:           accessToken,
-		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiredAt),
-		RefreshToken:          refreshToken,
-		RefreshTokenExpiresAt: timestamppb.New(refreshPayload.ExpiredAt),
+		User:                  convertUser(result.User),
+		SessionId:             result.Session.ID.String(),
+		AccessToken:           result.AccessToken,
+		AccessTokenExpiresAt:  timestamppb.New(result.AccessTokenExpiresAt),
+		RefreshToken:          result.RefreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(result.RefreshTokenExpiresAt),
 	}, nil
 }
-
-func validateLoginUserRequest(req *pb.LoginUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
-	if err := val.ValidateUsername(req.GetUsername()); err != nil {
-		violations = append(violations, fieldViolation("username", err))
-	}
-
-	if err := val.ValidatePassword(req.GetPassword()); err != nil {
-		violations = append(violations, fieldViolation("password", err))
-	}
-
-	return violations
-}
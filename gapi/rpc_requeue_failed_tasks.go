@@ -0,0 +1,66 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/worker"
+	"github.com/hibiken/asynq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RequeueFailedTasks processes again the background tasks that ran out of
+// retries, either the given ones or all of them.
+func (s *AdminServer) RequeueFailedTasks(ctx context.Context, req *pb.RequeueFailedTasksRequest) (*pb.RequeueFailedTasksResponse, error) {
+	if _, err := s.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if violations := validateRequeueFailedTasksRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	queues := worker.Queues
+	if req.Queue != nil {
+		queues = []string{req.GetQueue()}
+	}
+
+	rsp := &pb.RequeueFailedTasksResponse{}
+	for _, queue := range queues {
+		requeued, err := s.taskInspector.RequeueArchivedTasks(queue, req.GetTaskIds()...)
+		rsp.Requeued += int32(requeued)
+		if err != nil {
+			if errors.Is(err, asynq.ErrTaskNotFound) || errors.Is(err, asynq.ErrQueueNotFound) {
+				return nil, status.Errorf(codes.NotFound, "%s", err)
+			}
+			return nil, status.Errorf(codes.Internal, "failed to requeue tasks after %d: %s", rsp.Requeued, err)
+		}
+	}
+
+	return rsp, nil
+}
+
+func validateRequeueFailedTasksRequest(req *pb.RequeueFailedTasksRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.Queue != nil && !isWorkerQueue(req.GetQueue()) {
+		violations = append(violations, fieldViolation("queue", fmt.Errorf("unsupported queue")))
+	}
+
+	if len(req.GetTaskIds()) > 0 && req.Queue == nil {
+		violations = append(violations, fieldViolation("task_ids", fmt.Errorf("requires a queue")))
+	}
+
+	return violations
+}
+
+func isWorkerQueue(queue string) bool {
+	for _, q := range worker.Queues {
+		if q == queue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReverseTransfer refunds all or part of a transfer to its sender. Only admins
+// can reverse transfers, and every reversal is recorded with its reason.
+func (s *Server) ReverseTransfer(ctx context.Context, req *pb.ReverseTransferRequest) (*pb.ReverseTransferResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateReverseTransferRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	admin, err := s.getAdmin(ctx, authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := s.store.GetTransfer(ctx, req.GetTransferId())
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to get transfer: %w", err))
+	}
+
+	amount := req.GetAmount()
+	if req.Amount == nil {
+		reversedAmount, err := s.store.GetReversedAmount(ctx, sql.NullInt64{Int64: transfer.ID, Valid: true})
+		if err != nil {
+			return nil, serviceError(fmt.Errorf("failed to get reversed amount: %w", err))
+		}
+
+		amount = transfer.Amount - reversedAmount
+		if amount == 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "transfer is already fully reversed")
+		}
+	}
+
+	// the amount left to reverse is checked again within the transaction, in
+	// case another reversal happened in between
+	result, err := s.store.ReverseTransferTx(ctx, db.ReverseTransferTxParams{
+		TransferID: transfer.ID,
+		Amount:     amount,
+		Reason:     req.GetReason(),
+		ReversedBy: admin.Username,
+	})
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to reverse transfer: %w", err))
+	}
+
+	return &pb.ReverseTransferResponse{
+		Transfer:       convertTransfer(result.Transfer.Transfer),
+		ReversedAmount: result.ReversedAmount,
+	}, nil
+}
+
+func validateReverseTransferRequest(req *pb.ReverseTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetTransferId()); err != nil {
+		violations = append(violations, fieldViolation("transfer_id", err))
+	}
+
+	if req.Amount != nil {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+
+	if err := val.ValidateReason(req.GetReason()); err != nil {
+		violations = append(violations, fieldViolation("reason", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,25 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+)
+
+// RevokeSession blocks a session of the user, e.g. of a lost device, so that
+// it cannot renew access tokens any more.
+func (s *Server) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	session, err := s.service.RevokeSession(ctx, authPayload.Username, req.GetSessionId())
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	return &pb.RevokeSessionResponse{
+		Session: convertSession(session),
+	}, nil
+}
@@ -0,0 +1,59 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// likeEscaper makes the wildcards of LIKE match themselves.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchUsers finds the users whose username, full name or email contains the
+// query, ordered by username.
+func (s *AdminServer) SearchUsers(ctx context.Context, req *pb.SearchUsersRequest) (*pb.SearchUsersResponse, error) {
+	if _, err := s.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if violations := validateSearchUsersRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	users, err := s.store.SearchUsers(ctx, db.SearchUsersParams{
+		Pattern:    "%" + likeEscaper.Replace(req.GetQuery()) + "%",
+		PageSize:   req.GetPageSize(),
+		PageOffset: (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to search users: %w", err))
+	}
+
+	rsp := &pb.SearchUsersResponse{}
+	for _, user := range users {
+		rsp.Users = append(rsp.Users, convertUser(user))
+	}
+
+	return rsp, nil
+}
+
+func validateSearchUsersRequest(req *pb.SearchUsersRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateString(req.GetQuery(), 1, 100); err != nil {
+		violations = append(violations, fieldViolation("query", err))
+	}
+
+	if req.GetPageId() < 1 {
+		violations = append(violations, fieldViolation("page_id", fmt.Errorf("must be at least 1")))
+	}
+
+	if req.GetPageSize() < 5 || req.GetPageSize() > 10 {
+		violations = append(violations, fieldViolation("page_size", fmt.Errorf("must be between 5 and 10")))
+	}
+
+	return violations
+}
@@ -0,0 +1,61 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnblockSession lets a blocked session renew access tokens again. The
+// sessions of a blocked user stay blocked.
+func (s *AdminServer) UnblockSession(ctx context.Context, req *pb.UnblockSessionRequest) (*pb.UnblockSessionResponse, error) {
+	if _, err := s.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	sessionID, violations := validateUnblockSessionRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	session, err := s.store.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to get session: %w", err))
+	}
+
+	user, err := s.store.GetUser(ctx, session.Username)
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to get user: %w", err))
+	}
+
+	if user.IsBlocked {
+		return nil, status.Errorf(codes.FailedPrecondition, "user of the session is blocked")
+	}
+
+	session, err = s.store.UpdateSessionBlocked(ctx, db.UpdateSessionBlockedParams{
+		ID:        session.ID,
+		IsBlocked: false,
+	})
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to unblock session: %w", err))
+	}
+
+	return &pb.UnblockSessionResponse{
+		Session: convertSession(session),
+	}, nil
+}
+
+func validateUnblockSessionRequest(req *pb.UnblockSessionRequest) (sessionID uuid.UUID, violations []*errdetails.BadRequest_FieldViolation) {
+	sessionID, err := uuid.Parse(req.GetSessionId())
+	if err != nil {
+		violations = append(violations, fieldViolation("session_id", err))
+	}
+
+	return sessionID, violations
+}
@@ -0,0 +1,28 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
+)
+
+func (s *Server) UpdateAccountStatus(ctx context.Context, req *pb.UpdateAccountStatusRequest) (*pb.UpdateAccountStatusResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	account, err := s.service.UpdateAccountStatus(ctx, authPayload.Username, service.UpdateAccountStatusParams{
+		AccountID: req.GetAccountId(),
+		Status:    req.GetStatus(),
+		Reason:    req.GetReason(),
+	})
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	return &pb.UpdateAccountStatusResponse{
+		Account: convertAccount(account),
+	}, nil
+}
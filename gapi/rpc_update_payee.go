@@ -0,0 +1,27 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
+)
+
+func (s *Server) UpdatePayee(ctx context.Context, req *pb.UpdatePayeeRequest) (*pb.UpdatePayeeResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	payee, err := s.service.UpdatePayee(ctx, authPayload.Username, service.UpdatePayeeParams{
+		ID:       req.GetId(),
+		Nickname: req.GetNickname(),
+	})
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	return &pb.UpdatePayeeResponse{
+		Payee: convertPayee(payee),
+	}, nil
+}
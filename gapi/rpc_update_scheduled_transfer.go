@@ -0,0 +1,31 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
+)
+
+func (s *Server) UpdateScheduledTransfer(ctx context.Context, req *pb.UpdateScheduledTransferRequest) (*pb.UpdateScheduledTransferResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	scheduledTransfer, err := s.service.UpdateScheduledTransfer(ctx, authPayload.Username, service.UpdateScheduledTransferParams{
+		ID:         req.GetId(),
+		Amount:     req.Amount,
+		Recurrence: req.Recurrence,
+		NextRunAt:  optionalTime(req.NextRunAt),
+		EndAt:      optionalTime(req.EndAt),
+		Status:     req.Status,
+	})
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	return &pb.UpdateScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}, nil
+}
@@ -2,16 +2,9 @@ package gapi
 
 import (
 	"context"
-	"database/sql"
-	"time"
 
-	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
 	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
-	"github.com/NguyenMinhKhanhBK/simple_bank/util"
-	"github.com/NguyenMinhKhanhBK/simple_bank/val"
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
 )
 
 func (s *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
@@ -20,79 +13,17 @@ func (s *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb
 		return nil, unauthenticatedError(err)
 	}
 
-	if violations := validateUpdateUserRequest(req); violations != nil {
-		return nil, invalidArgumentError(violations)
-	}
-
-	if authPayload.Username != req.GetUsername() {
-		return nil, status.Errorf(codes.PermissionDenied, "cannot update other user's info")
-	}
-
-	arg := db.UpdateUserParams{
+	user, err := s.service.UpdateUser(ctx, authPayload.Username, service.UpdateUserParams{
 		Username: req.GetUsername(),
-		FullName: sql.NullString{
-			String: req.GetFullName(),
-			Valid:  req.FullName != nil,
-		},
-		Email: sql.NullString{
-			String: req.GetEmail(),
-			Valid:  req.Email != nil,
-		},
-	}
-
-	if req.Password != nil {
-		hashedPassword, err := util.HashPassword(req.GetPassword())
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
-		}
-
-		arg.HashedPassword = sql.NullString{
-			String: hashedPassword,
-			Valid:  true,
-		}
-
-		arg.PasswordChangedAt = sql.NullTime{
-			Time:  time.Now(),
-			Valid: true,
-		}
-	}
-
-	user, err := s.store.UpdateUser(ctx, arg)
+		Password: req.Password,
+		FullName: req.FullName,
+		Email:    req.Email,
+	})
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, status.Errorf(codes.NotFound, "user not found")
-		}
-		return nil, status.Errorf(codes.Internal, "username already exists: %s", err)
+		return nil, serviceError(err)
 	}
 
 	return &pb.UpdateUserResponse{
 		User: convertUser(user),
 	}, nil
-
-}
-
-func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
-	if err := val.ValidateUsername(req.GetUsername()); err != nil {
-		violations = append(violations, fieldViolation("username", err))
-	}
-
-	if req.Password != nil {
-		if err := val.ValidatePassword(req.GetPassword()); err != nil {
-			violations = append(violations, fieldViolation("password", err))
-		}
-	}
-
-	if req.Email != nil {
-		if err := val.ValidateEmail(req.GetEmail()); err != nil {
-			violations = append(violations, fieldViolation("email", err))
-		}
-	}
-
-	if req.FullName != nil {
-		if err := val.ValidateFullName(req.GetFullName()); err != nil {
-			violations = append(violations, fieldViolation("full_name", err))
-		}
-	}
-
-	return violations
 }
@@ -0,0 +1,84 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// UpdateUserTransferLimits overrides the transfer limits of the account types
+// for all accounts of a user. Only admins can change limits.
+func (s *Server) UpdateUserTransferLimits(ctx context.Context, req *pb.UpdateUserTransferLimitsRequest) (*pb.UpdateUserTransferLimitsResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateUpdateUserTransferLimitsRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	admin, err := s.getAdmin(ctx, authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.GetUser(ctx, req.GetUsername()); err != nil {
+		return nil, serviceError(fmt.Errorf("failed to get user: %w", err))
+	}
+
+	limits, err := s.store.UpsertUserTransferLimits(ctx, db.UpsertUserTransferLimitsParams{
+		Username: req.GetUsername(),
+		MaxTransferAmount: sql.NullInt64{
+			Int64: req.GetMaxTransferAmount(),
+			Valid: req.MaxTransferAmount != nil,
+		},
+		DailyTransferLimit: sql.NullInt64{
+			Int64: req.GetDailyTransferLimit(),
+			Valid: req.DailyTransferLimit != nil,
+		},
+		MonthlyTransferLimit: sql.NullInt64{
+			Int64: req.GetMonthlyTransferLimit(),
+			Valid: req.MonthlyTransferLimit != nil,
+		},
+		UpdatedBy: admin.Username,
+	})
+	if err != nil {
+		return nil, serviceError(fmt.Errorf("failed to update transfer limits: %w", err))
+	}
+
+	return &pb.UpdateUserTransferLimitsResponse{
+		Limits: convertUserTransferLimits(limits),
+	}, nil
+}
+
+func validateUpdateUserTransferLimitsRequest(req *pb.UpdateUserTransferLimitsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if req.MaxTransferAmount != nil {
+		if err := val.ValidateAmount(req.GetMaxTransferAmount()); err != nil {
+			violations = append(violations, fieldViolation("max_transfer_amount", err))
+		}
+	}
+
+	if req.DailyTransferLimit != nil {
+		if err := val.ValidateAmount(req.GetDailyTransferLimit()); err != nil {
+			violations = append(violations, fieldViolation("daily_transfer_limit", err))
+		}
+	}
+
+	if req.MonthlyTransferLimit != nil {
+		if err := val.ValidateAmount(req.GetMonthlyTransferLimit()); err != nil {
+			violations = append(violations, fieldViolation("monthly_transfer_limit", err))
+		}
+	}
+
+	return violations
+}
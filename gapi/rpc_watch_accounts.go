@@ -0,0 +1,239 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	watchPageSize = 100
+	// the entries are read again this often even without notifications, in
+	// case one was missed
+	watchPollInterval = 30 * time.Second
+	// watchLookback is how long the entries behind the last sent one are read
+	// again, for the ones whose transaction commits after an entry with a
+	// greater id was sent. Transactions that create entries are expected to
+	// take less than half of it.
+	watchLookback = 2 * time.Minute
+)
+
+// WatchAccounts streams the new entries of accounts of the user, with the
+// account they changed, until the client goes away.
+func (s *Server) WatchAccounts(req *pb.WatchAccountsRequest, stream pb.SimpleBank_WatchAccountsServer) error {
+	ctx := stream.Context()
+
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return unauthenticatedError(err)
+	}
+
+	if violations := validateWatchAccountsRequest(req); violations != nil {
+		return invalidArgumentError(violations)
+	}
+
+	accountIDs, err := s.watchedAccountIDs(ctx, authPayload.Username, req.GetAccountIds())
+	if err != nil {
+		return err
+	}
+
+	return s.watchAccounts(ctx, accountIDs, req.GetAfterEntryId(), stream.Send, nil)
+}
+
+// watchedAccountIDs returns the accounts to watch, all the accounts of the
+// user when none are given.
+func (s *Server) watchedAccountIDs(ctx context.Context, username string, accountIDs []int64) ([]int64, error) {
+	if len(accountIDs) == 0 {
+		ids, err := s.store.ListAccountIDs(ctx, username)
+		if err != nil {
+			return nil, serviceError(fmt.Errorf("failed to list accounts: %w", err))
+		}
+		if len(ids) == 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "the user has no accounts")
+		}
+		return ids, nil
+	}
+
+	for _, accountID := range accountIDs {
+		if _, err := s.getOwnedAccount(ctx, accountID, username); err != nil {
+			return nil, err
+		}
+	}
+	return accountIDs, nil
+}
+
+// watchAccounts sends the entries of the accounts after afterEntryID, or the
+// entries created from now on when it is zero, then waits for notifications of
+// new ones. heartbeat, when set, is called whenever there was nothing to send
+// for watchPollInterval.
+//
+// Entries are sent about in id order, but an entry whose transaction commits
+// after one with a greater id was sent is still sent, once, when it commits.
+// When resuming after afterEntryID, the entries of the last watchLookback are
+// sent again, since they may have committed after the client got that entry;
+// clients tell them apart by their id.
+func (s *Server) watchAccounts(
+	ctx context.Context,
+	accountIDs []int64,
+	afterEntryID int64,
+	send func(*pb.AccountEvent) error,
+	heartbeat func() error,
+) error {
+	// the entries are read when the primary notifies that they were created,
+	// which a lagging replica may not know of yet
+	ctx = db.WithPrimary(ctx)
+
+	// subscribe before reading, so that entries created meanwhile are not missed
+	sub := s.hub.Subscribe(accountIDs)
+	defer sub.Close()
+
+	maxID := afterEntryID
+	if maxID == 0 {
+		maxID = math.MaxInt64
+	}
+	floor, err := s.store.GetLastEntryIDBefore(ctx, db.GetLastEntryIDBeforeParams{
+		AccountIds:    accountIDs,
+		MaxID:         maxID,
+		CreatedBefore: time.Now().Add(-watchLookback),
+	})
+	if err != nil {
+		return serviceError(fmt.Errorf("failed to get last entry: %w", err))
+	}
+
+	window := newEntryWindow(floor)
+	if afterEntryID == 0 {
+		// the entries that already exist are not sent
+		if err := s.readEntries(ctx, accountIDs, window, nil); err != nil {
+			return err
+		}
+	}
+
+	for {
+		if err := s.readEntries(ctx, accountIDs, window, send); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.C:
+		case <-time.After(watchPollInterval):
+			if heartbeat != nil {
+				if err := heartbeat(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// readEntries sends the entries of the window that were not sent yet, or only
+// marks them as sent when send is nil.
+func (s *Server) readEntries(ctx context.Context, accountIDs []int64, window *entryWindow, send func(*pb.AccountEvent) error) error {
+	readAt := time.Now()
+	lastEntryID := window.floor
+
+	for {
+		entries, err := s.store.ListEntriesAfter(ctx, db.ListEntriesAfterParams{
+			AccountIds: accountIDs,
+			AfterID:    lastEntryID,
+			PageSize:   watchPageSize,
+		})
+		if err != nil {
+			return serviceError(fmt.Errorf("failed to list entries: %w", err))
+		}
+
+		for _, entry := range entries {
+			lastEntryID = entry.ID
+			if window.sent[entry.ID] {
+				continue
+			}
+
+			if send != nil {
+				account, err := s.store.GetAccount(ctx, entry.AccountID)
+				if err != nil {
+					return serviceError(fmt.Errorf("failed to get account: %w", err))
+				}
+
+				err = send(&pb.AccountEvent{
+					Entry:   convertEntry(db.ListAccountEntriesRow(entry)),
+					Account: convertAccount(account),
+				})
+				if err != nil {
+					return err
+				}
+			}
+			window.sent[entry.ID] = true
+		}
+
+		if len(entries) < watchPageSize {
+			break
+		}
+	}
+
+	window.advance(readAt, lastEntryID)
+	return nil
+}
+
+// entryWindow keeps the ids of the entries sent since its floor, every entry
+// up to which was handled.
+type entryWindow struct {
+	floor int64
+	sent  map[int64]bool
+	// the last entry id that was seen by the reads of the lookback, oldest
+	// first
+	reads []entryRead
+}
+
+type entryRead struct {
+	at          time.Time
+	lastEntryID int64
+}
+
+func newEntryWindow(floor int64) *entryWindow {
+	return &entryWindow{floor: floor, sent: make(map[int64]bool)}
+}
+
+// advance records a read that started at the given time and saw the entries
+// up to lastEntryID. An entry that was not visible to a read gets a greater id
+// than the entries that were, unless its transaction was already running, so
+// the floor moves up to what the reads saw watchLookback ago.
+func (w *entryWindow) advance(at time.Time, lastEntryID int64) {
+	w.reads = append(w.reads, entryRead{at: at, lastEntryID: lastEntryID})
+
+	cutoff := at.Add(-watchLookback)
+	for len(w.reads) > 0 && w.reads[0].at.Before(cutoff) {
+		if w.reads[0].lastEntryID > w.floor {
+			w.floor = w.reads[0].lastEntryID
+		}
+		w.reads = w.reads[1:]
+	}
+
+	for id := range w.sent {
+		if id <= w.floor {
+			delete(w.sent, id)
+		}
+	}
+}
+
+func validateWatchAccountsRequest(req *pb.WatchAccountsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	for i, accountID := range req.GetAccountIds() {
+		if err := val.ValidateID(accountID); err != nil {
+			violations = append(violations, fieldViolation(fmt.Sprintf("account_ids[%d]", i), err))
+		}
+	}
+
+	if req.GetAfterEntryId() < 0 {
+		violations = append(violations, fieldViolation("after_entry_id", fmt.Errorf("must not be negative")))
+	}
+
+	return violations
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/notify"
 	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/service"
 	"github.com/NguyenMinhKhanhBK/simple_bank/token"
 	"github.com/NguyenMinhKhanhBK/simple_bank/util"
 	"github.com/NguyenMinhKhanhBK/simple_bank/worker"
@@ -12,13 +14,15 @@ import (
 
 type Server struct {
 	store      db.Store
+	service    *service.Service
 	tokenMaker token.Maker
 	config     util.Config
 	pb.UnimplementedSimpleBankServer
 	taskDistributor worker.TaskDistributor
+	hub             *notify.Hub
 }
 
-func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) (*Server, error) {
+func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, hub *notify.Hub) (*Server, error) {
 	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
@@ -26,9 +30,11 @@ func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDi
 
 	server := &Server{
 		store:           store,
+		service:         service.New(config, store, tokenMaker),
 		tokenMaker:      tokenMaker,
 		config:          config,
 		taskDistributor: taskDistributor,
+		hub:             hub,
 	}
 
 	return server, nil
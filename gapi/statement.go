@@ -0,0 +1,164 @@
+package gapi
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/apierror"
+	"github.com/NguyenMinhKhanhBK/simple_bank/statement"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DownloadStatementPath       = "/v1/download_statement"
+	DownloadStatementExportPath = "/v1/download_statement_export"
+
+	statementDateLayout = "2006-01-02"
+
+	// longer periods have to go through CreateStatementExport
+	maxStatementDownloadPeriod = 366 * 24 * time.Hour
+)
+
+// DownloadStatementHandler streams the statement of an account as it is
+// generated. Query parameters: account_id, from and to (inclusive dates as
+// YYYY-MM-DD) and format (csv or pdf, csv by default).
+func (s *Server) DownloadStatementHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			writeHTTPError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", req.Method))
+			return
+		}
+
+		authPayload, err := s.verifyAuthorizationHeader(req.Context(), req.Header.Get(authorizationHeader))
+		if err != nil {
+			apierror.WriteProblem(w, unauthenticatedError(err))
+			return
+		}
+
+		query := req.URL.Query()
+		accountID, err := strconv.ParseInt(query.Get("account_id"), 10, 64)
+		if err != nil || accountID < 1 {
+			writeHTTPError(w, http.StatusBadRequest, fmt.Errorf("account_id must be a positive integer"))
+			return
+		}
+
+		periodStart, err := time.Parse(statementDateLayout, query.Get("from"))
+		if err != nil {
+			writeHTTPError(w, http.StatusBadRequest, fmt.Errorf("from must be a date formatted as YYYY-MM-DD"))
+			return
+		}
+
+		lastDay, err := time.Parse(statementDateLayout, query.Get("to"))
+		if err != nil {
+			writeHTTPError(w, http.StatusBadRequest, fmt.Errorf("to must be a date formatted as YYYY-MM-DD"))
+			return
+		}
+		periodEnd := lastDay.AddDate(0, 0, 1)
+
+		if !periodEnd.After(periodStart) {
+			writeHTTPError(w, http.StatusBadRequest, fmt.Errorf("to must not be before from"))
+			return
+		}
+		if periodEnd.Sub(periodStart) > maxStatementDownloadPeriod {
+			writeHTTPError(w, http.StatusBadRequest, fmt.Errorf("period is longer than %d days, create a statement export instead", int(maxStatementDownloadPeriod.Hours()/24)))
+			return
+		}
+
+		format := query.Get("format")
+		if format == "" {
+			format = statement.FormatCSV
+		}
+		if !statement.IsSupportedFormat(format) {
+			writeHTTPError(w, http.StatusBadRequest, fmt.Errorf("unsupported format: %s", format))
+			return
+		}
+
+		account, err := s.store.GetAccount(req.Context(), accountID)
+		if err != nil {
+			apierror.WriteProblem(w, fmt.Errorf("failed to get account: %w", err))
+			return
+		}
+
+		if account.Owner != authPayload.Username {
+			writeHTTPError(w, http.StatusForbidden, fmt.Errorf("account does not belong to the authenticated user"))
+			return
+		}
+
+		renderer, err := statement.NewRenderer(format, w)
+		if err != nil {
+			writeHTTPError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", statement.ContentType(format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, statement.FileName(account.ID, periodStart, periodEnd, format)))
+
+		// once the first bytes are sent the status can no longer change, so
+		// a failure past this point can only cut the download short
+		err = statement.NewGenerator(s.store).Generate(req.Context(), account, periodStart, periodEnd, renderer)
+		if err != nil {
+			logrus.WithError(err).WithField("account_id", account.ID).Error("failed to generate statement")
+		}
+	})
+}
+
+// DownloadStatementExportHandler serves the file of a completed statement
+// export. Query parameter: id.
+func (s *Server) DownloadStatementExportHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			writeHTTPError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", req.Method))
+			return
+		}
+
+		authPayload, err := s.verifyAuthorizationHeader(req.Context(), req.Header.Get(authorizationHeader))
+		if err != nil {
+			apierror.WriteProblem(w, unauthenticatedError(err))
+			return
+		}
+
+		id, err := strconv.ParseInt(req.URL.Query().Get("id"), 10, 64)
+		if err != nil || id < 1 {
+			writeHTTPError(w, http.StatusBadRequest, fmt.Errorf("id must be a positive integer"))
+			return
+		}
+
+		export, err := s.store.GetStatementExport(req.Context(), id)
+		if err != nil {
+			apierror.WriteProblem(w, fmt.Errorf("failed to get statement export: %w", err))
+			return
+		}
+
+		if export.Owner != authPayload.Username {
+			writeHTTPError(w, http.StatusForbidden, fmt.Errorf("statement export does not belong to the authenticated user"))
+			return
+		}
+
+		if export.Status != statement.ExportCompleted {
+			writeHTTPError(w, http.StatusConflict, fmt.Errorf("statement export is %s", export.Status))
+			return
+		}
+
+		file, err := s.store.GetStatementFile(req.Context(), export.ID)
+		if err != nil {
+			apierror.WriteProblem(w, fmt.Errorf("failed to get statement file: %w", err))
+			return
+		}
+
+		fileName := statement.FileName(export.AccountID, export.PeriodStart, export.PeriodEnd, export.Format)
+		w.Header().Set("Content-Type", statement.ContentType(export.Format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+		http.ServeContent(w, req, fileName, file.CreatedAt, bytes.NewReader(file.Content))
+	})
+}
+
+func writeHTTPError(w http.ResponseWriter, statusCode int, err error) {
+	apierror.NewHTTPProblem(statusCode, err).Write(w)
+}
+
+func statementExportDownloadURL(id int64) string {
+	return fmt.Sprintf("%s?id=%d", DownloadStatementExportPath, id)
+}
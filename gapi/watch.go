@@ -0,0 +1,107 @@
+package gapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/apierror"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const WatchAccountsPath = "/v1/watch_accounts"
+
+// WatchAccountsHandler serves the events of WatchAccounts as server-sent
+// events, with the entry id as event id. Query parameters: account_ids
+// (comma-separated, all accounts of the user by default) and after_entry_id.
+// The Last-Event-ID header sent by reconnecting clients takes precedence over
+// after_entry_id; the entries of the last few minutes are then sent again, see
+// watchAccounts.
+func (s *Server) WatchAccountsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			writeHTTPError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", req.Method))
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			writeHTTPError(w, http.StatusInternalServerError, fmt.Errorf("streaming is not supported"))
+			return
+		}
+
+		authPayload, err := s.verifyAuthorizationHeader(req.Context(), req.Header.Get(authorizationHeader))
+		if err != nil {
+			apierror.WriteProblem(w, unauthenticatedError(err))
+			return
+		}
+
+		query := req.URL.Query()
+		var accountIDs []int64
+		if value := query.Get("account_ids"); value != "" {
+			for _, field := range strings.Split(value, ",") {
+				accountID, err := strconv.ParseInt(field, 10, 64)
+				if err != nil || accountID < 1 {
+					writeHTTPError(w, http.StatusBadRequest, fmt.Errorf("account_ids must be positive integers"))
+					return
+				}
+				accountIDs = append(accountIDs, accountID)
+			}
+		}
+
+		afterEntryID := query.Get("after_entry_id")
+		if lastEventID := req.Header.Get("Last-Event-ID"); lastEventID != "" {
+			afterEntryID = lastEventID
+		}
+		var lastEntryID int64
+		if afterEntryID != "" {
+			lastEntryID, err = strconv.ParseInt(afterEntryID, 10, 64)
+			if err != nil || lastEntryID < 0 {
+				writeHTTPError(w, http.StatusBadRequest, fmt.Errorf("after_entry_id must be a non-negative integer"))
+				return
+			}
+		}
+
+		accountIDs, err = s.watchedAccountIDs(req.Context(), authPayload.Username, accountIDs)
+		if err != nil {
+			apierror.WriteProblem(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		marshaler := protojson.MarshalOptions{UseProtoNames: true}
+		send := func(event *pb.AccountEvent) error {
+			data, err := marshaler.Marshal(event)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: entry\ndata: %s\n\n", event.GetEntry().GetId(), data); err != nil {
+				return err
+			}
+			flusher.Flush()
+			return nil
+		}
+		// a comment keeps idle connections from being closed by proxies
+		heartbeat := func() error {
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return err
+			}
+			flusher.Flush()
+			return nil
+		}
+
+		// once the stream started the status can no longer change, so a
+		// failure can only end it
+		err = s.watchAccounts(req.Context(), accountIDs, lastEntryID, send, heartbeat)
+		if err != nil {
+			logrus.WithError(err).WithField("username", authPayload.Username).Error("failed to watch accounts")
+		}
+	})
+}
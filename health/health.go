@@ -0,0 +1,248 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+
+	checkTimeout = 2 * time.Second
+)
+
+// ProcessorStatus is implemented by components that can report whether the
+// background task processor is running.
+type ProcessorStatus interface {
+	Status() error
+}
+
+type CheckResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Checker probes the dependencies of the service. It backs both the gateway
+// /healthz and /readyz endpoints and the standard gRPC health service.
+type Checker struct {
+	db               *pgxpool.Pool
+	redis            redis.UniversalClient
+	processor        ProcessorStatus
+	migrationVersion uint
+
+	mu           sync.RWMutex
+	shuttingDown bool
+}
+
+// NewChecker creates a checker. The processor may be nil when the task
+// processor does not run in this process.
+func NewChecker(
+	db *pgxpool.Pool,
+	migrationURL string,
+	redisOpt asynq.RedisConnOpt,
+	processor ProcessorStatus,
+) (*Checker, error) {
+	version, err := LatestMigrationVersion(migrationURL)
+	if err != nil {
+		return nil, err
+	}
+
+	redisClient, ok := redisOpt.MakeRedisClient().(redis.UniversalClient)
+	if !ok {
+		return nil, fmt.Errorf("unsupported redis connection option: %T", redisOpt)
+	}
+
+	return &Checker{
+		db:               db,
+		redis:            redisClient,
+		processor:        processor,
+		migrationVersion: version,
+	}, nil
+}
+
+// LatestMigrationVersion returns the highest migration version available in
+// the migration source.
+func LatestMigrationVersion(migrationURL string) (uint, error) {
+	src, err := source.Open(migrationURL)
+	if err != nil {
+		return 0, fmt.Errorf("cannot open migration source: %w", err)
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("cannot read first migration: %w", err)
+	}
+
+	for {
+		next, err := src.Next(version)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return version, nil
+			}
+			return 0, fmt.Errorf("cannot read next migration: %w", err)
+		}
+		version = next
+	}
+}
+
+// SetShuttingDown makes the readiness checks report a degraded state so that
+// load balancers stop routing new requests while the servers drain.
+func (c *Checker) SetShuttingDown() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shuttingDown = true
+}
+
+func (c *Checker) isShuttingDown() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.shuttingDown
+}
+
+// Check runs all dependency checks and aggregates them into a report.
+func (c *Checker) Check(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	report := Report{
+		Status: StatusOK,
+		Checks: map[string]CheckResult{
+			"database":  newCheckResult(c.checkDatabase(ctx)),
+			"migration": newCheckResult(c.checkMigration(ctx)),
+			"redis":     newCheckResult(c.redis.Ping(ctx).Err()),
+		},
+	}
+
+	if c.processor != nil {
+		report.Checks["task_processor"] = newCheckResult(c.processor.Status())
+	}
+
+	for _, result := range report.Checks {
+		if result.Status != StatusOK {
+			report.Status = StatusUnavailable
+		}
+	}
+
+	if report.Status == StatusOK && c.isShuttingDown() {
+		report.Status = StatusDegraded
+	}
+
+	return report
+}
+
+func (c *Checker) checkDatabase(ctx context.Context) error {
+	return c.db.Ping(ctx)
+}
+
+func (c *Checker) checkMigration(ctx context.Context) error {
+	var version uint
+	var dirty bool
+
+	err := c.db.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil {
+		return fmt.Errorf("cannot read migration version: %w", err)
+	}
+
+	if dirty {
+		return fmt.Errorf("migration version %d is dirty", version)
+	}
+
+	if version != c.migrationVersion {
+		return fmt.Errorf("migration version mismatch: %d vs %d", version, c.migrationVersion)
+	}
+
+	return nil
+}
+
+func newCheckResult(err error) CheckResult {
+	if err != nil {
+		return CheckResult{Status: StatusUnavailable, Error: err.Error()}
+	}
+	return CheckResult{Status: StatusOK}
+}
+
+// LivenessHandler reports whether the process is up. It does not check any
+// dependency so that a broken database does not restart every pod.
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		writeJSON(w, http.StatusOK, Report{Status: StatusOK})
+	})
+}
+
+// ReadinessHandler reports whether the service can accept traffic.
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		report := c.Check(req.Context())
+
+		statusCode := http.StatusOK
+		if report.Status != StatusOK {
+			statusCode = http.StatusServiceUnavailable
+		}
+		writeJSON(w, statusCode, report)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logrus.WithError(err).Error("failed to write health response")
+	}
+}
+
+// Watch periodically runs the checks and publishes the result to the gRPC
+// health server for the given services until the context is done.
+func (c *Checker) Watch(ctx context.Context, server *health.Server, interval time.Duration, services ...string) {
+	update := func() {
+		status := healthpb.HealthCheckResponse_SERVING
+		if report := c.Check(ctx); report.Status != StatusOK {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		server.SetServingStatus("", status)
+		for _, service := range services {
+			server.SetServingStatus(service, status)
+		}
+	}
+
+	update()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			update()
+		}
+	}
+}
+
+// Close releases the redis connection used for the checks.
+func (c *Checker) Close() error {
+	return c.redis.Close()
+}
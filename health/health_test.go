@@ -0,0 +1,33 @@
+package health
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatestMigrationVersion(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"000001_init.up.sql",
+		"000001_init.down.sql",
+		"000002_add_users.up.sql",
+		"000002_add_users.down.sql",
+		"000010_add_sessions.up.sql",
+	} {
+		err := os.WriteFile(filepath.Join(dir, name), []byte("SELECT 1;"), 0o600)
+		assert.NoError(t, err)
+	}
+
+	version, err := LatestMigrationVersion("file://" + dir)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(10), version)
+}
+
+func TestLatestMigrationVersionInvalidSource(t *testing.T) {
+	_, err := LatestMigrationVersion("file://" + filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
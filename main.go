@@ -1,175 +1,122 @@
+// Command simple_bank runs the bank. Without a command it runs every
+// component in one process, after migrating the database; the other commands
+// run a single component so that each of them can scale on its own.
 package main
 
 import (
 	"context"
-	"database/sql"
 	"embed"
+	"errors"
+	"flag"
+	"fmt"
 	"io/fs"
-	"net"
-	"net/http"
-	"sync"
+	"os"
+	"os/signal"
+	"syscall"
 
-	_ "embed"
-
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/hibiken/asynq"
-
-	"github.com/NguyenMinhKhanhBK/simple_bank/api"
-	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
-	"github.com/NguyenMinhKhanhBK/simple_bank/gapi"
-	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
 	"github.com/NguyenMinhKhanhBK/simple_bank/util"
-	"github.com/NguyenMinhKhanhBK/simple_bank/worker"
-	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
-	_ "github.com/lib/pq"
 	"github.com/sirupsen/logrus"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/reflection"
-	"google.golang.org/protobuf/encoding/protojson"
 )
 
+const usage = `Usage: simple_bank [<command>] [<subcommand>] [args]
+
+Commands:
+  serve                run the gRPC server, the HTTP gateway and the worker
+                       after migrating the database (default)
+  serve-grpc           run the gRPC server
+  serve-gateway        run the HTTP gateway
+  worker               run the task processor and the task scheduler
+  migrate up [N]       apply the next N migrations, or all of them
+  migrate down [N]     revert the last N migrations (default 1)
+  migrate version      print the current migration version
+  migrate force <V>    set the migration version without migrating
+  seed                 create users, accounts and transfers for demos
+`
+
+var interruptSignals = []os.Signal{
+	os.Interrupt,
+	syscall.SIGTERM,
+	syscall.SIGINT,
+}
+
 //go:embed doc/swagger
 var docFS embed.FS
 var swaggerFS, _ = fs.Sub(docFS, "doc/swagger")
 
-func main() {
-	config, err := util.LoadConfig(".")
-	if err != nil {
-		logrus.Fatal("cannot load config:", err)
-	}
-
-	conn, err := sql.Open(config.DBDriver, config.DBSource)
-	if err != nil {
-		logrus.Fatal("cannot connect to db:", err)
-	}
-
-	if err := conn.Ping(); err != nil {
-		logrus.Fatal("cannot ping db:", err)
-	}
-
-	runDBMigration(config.MigrationURL, config.DBSource)
-
-	store := db.NewStore(conn)
-
-	redisOpt := asynq.RedisClientOpt{
-		Addr: config.RedisAddress,
-	}
-
-	taskDistributor := worker.NewRedisTaskDistributor(redisOpt)
-
-	wg := &sync.WaitGroup{}
-	wg.Add(3)
-	go runGRPCServer(wg, config, store, taskDistributor)
-	go runGatewayServer(wg, config, store, taskDistributor)
-	go runTaskProcessor(wg, redisOpt, store)
-	wg.Wait()
-
-	logrus.Info("Exiting ...")
-}
-
-func runDBMigration(migrationURL string, dbSource string) {
-	migration, err := migrate.New(migrationURL, dbSource)
-	if err != nil {
-		logrus.Fatal("cannot create new migrate instance:", err)
-	}
+// errUsage is returned for a malformed command line, after its usage was
+// printed.
+var errUsage = errors.New("invalid usage")
 
-	if err := migration.Up(); err != nil && err != migrate.ErrNoChange {
-		logrus.Fatal("failed to run migrate up:", err)
-	}
+type command func(ctx context.Context, config util.Config, args []string) error
 
-	logrus.Info("DB migrate succesfully")
+var commands = map[string]map[string]command{
+	"serve":         {"": runServe},
+	"serve-grpc":    {"": runServeGRPC},
+	"serve-gateway": {"": runServeGateway},
+	"worker":        {"": runWorker},
+	"migrate":       {"up": runMigrateUp, "down": runMigrateDown, "version": runMigrateVersion, "force": runMigrateForce},
+	"seed":          {"": runSeed},
 }
 
-func runGinServer(config util.Config, store db.Store) {
-	server, err := api.NewServer(config, store)
-	if err != nil {
-		logrus.Fatal("cannot create server:", err)
-	}
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), interruptSignals...)
+	defer stop()
 
-	err = server.Start(config.HTTPServerAddress)
-	if err != nil {
-		logrus.Fatal("cannot start server:", err)
+	if err := run(ctx, os.Args[1:]); err != nil {
+		if !errors.Is(err, errUsage) {
+			logrus.Fatal(err)
+		}
+		os.Exit(2)
 	}
 }
 
-func runGRPCServer(wg *sync.WaitGroup, config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
-	defer wg.Done()
-
-	server, err := gapi.NewServer(config, store, taskDistributor)
-	if err != nil {
-		logrus.Fatal("cannot create server:", err)
+func run(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("simple_bank", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
 	}
-
-	gRPCLogger := grpc.UnaryInterceptor(gapi.GRPCLogger)
-	grpcServer := grpc.NewServer(gRPCLogger)
-	pb.RegisterSimpleBankServer(grpcServer, server)
-	reflection.Register(grpcServer)
-
-	listener, err := net.Listen("tcp", config.GRPCServerAddress)
-	if err != nil {
-		logrus.Fatal("cannot create listener:", err)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
 	}
 
-	logrus.Infof("start gRPC server at %v", config.GRPCServerAddress)
-
-	err = grpcServer.Serve(listener)
-	if err != nil {
-		logrus.Fatal("cannot start gRPC server:", err)
+	args = flags.Args()
+	if len(args) == 0 {
+		args = []string{"serve"}
 	}
-}
-
-func runGatewayServer(wg *sync.WaitGroup, config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
-	defer wg.Done()
 
-	server, err := gapi.NewServer(config, store, taskDistributor)
-	if err != nil {
-		logrus.Fatal("cannot create server:", err)
+	subcommands, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q, see simple_bank -h", args[0])
 	}
 
-	jsonOption := runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
-		MarshalOptions: protojson.MarshalOptions{
-			UseProtoNames: true,
-		},
-		UnmarshalOptions: protojson.UnmarshalOptions{
-			DiscardUnknown: true,
-		},
-	})
-	grpcMux := runtime.NewServeMux(jsonOption)
-
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	err = pb.RegisterSimpleBankHandlerServer(ctx, grpcMux, server)
-	if err != nil {
-		logrus.Fatal("cannot register handler server:", err)
+	name, args := args[0], args[1:]
+	cmd, ok := subcommands[""]
+	if !ok {
+		if len(args) == 0 {
+			return fmt.Errorf("%s needs a subcommand, see simple_bank -h", name)
+		}
+		cmd, ok = subcommands[args[0]]
+		if !ok {
+			return fmt.Errorf("unknown command %q, see simple_bank -h", name+" "+args[0])
+		}
+		args = args[1:]
 	}
-	mux := http.NewServeMux()
-	mux.Handle("/", grpcMux)
-
-	fsHandler := http.StripPrefix("/swagger/", http.FileServer(http.FS(swaggerFS)))
-	mux.Handle("/swagger/", fsHandler)
 
-	listener, err := net.Listen("tcp", config.HTTPServerAddress)
+	config, err := util.LoadConfig(".")
 	if err != nil {
-		logrus.Fatal("cannot create listener:", err)
+		return fmt.Errorf("cannot load config: %w", err)
 	}
 
-	logrus.Infof("start HTTP gateway server at %v", config.HTTPServerAddress)
-
-	handler := gapi.HTTPLogger(mux)
-	err = http.Serve(listener, handler)
-	if err != nil {
-		logrus.Fatal("cannot start gRPC server:", err)
-	}
+	return cmd(ctx, config, args)
 }
 
-func runTaskProcessor(wg *sync.WaitGroup, redisOpt asynq.RedisClientOpt, store db.Store) {
-	defer wg.Done()
-
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store)
-	logrus.Info("start task processor")
-	if err := taskProcessor.Start(); err != nil {
-		logrus.WithError(err).Fatal("failed to start task processor")
+// noArgs rejects the arguments of a command that takes none.
+func noArgs(name string, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%s takes no arguments, see simple_bank -h", name)
 	}
+	return nil
 }
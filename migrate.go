@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/sirupsen/logrus"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+)
+
+func runDBMigration(migrationURL string, dbSource string) error {
+	migration, err := migrate.New(migrationURL, dbSource)
+	if err != nil {
+		return fmt.Errorf("cannot create new migrate instance: %w", err)
+	}
+	defer migration.Close()
+
+	if err := migration.Up(); err != nil && err != migrate.ErrNoChange {
+		return fmt.Errorf("failed to run migrate up: %w", err)
+	}
+
+	logrus.Info("DB migrate succesfully")
+	return nil
+}
+
+func runMigrateUp(ctx context.Context, config util.Config, args []string) error {
+	steps, err := parseSteps("migrate up", args, 0)
+	if err != nil {
+		return err
+	}
+
+	return withMigration(config, func(migration *migrate.Migrate) error {
+		if steps == 0 {
+			err = migration.Up()
+		} else {
+			err = migration.Steps(steps)
+		}
+		if err := ignoreNoChange(err); err != nil {
+			return err
+		}
+		return logMigrationVersion(migration)
+	})
+}
+
+func runMigrateDown(ctx context.Context, config util.Config, args []string) error {
+	steps, err := parseSteps("migrate down", args, 1)
+	if err != nil {
+		return err
+	}
+
+	return withMigration(config, func(migration *migrate.Migrate) error {
+		if err := ignoreNoChange(migration.Steps(-steps)); err != nil {
+			return err
+		}
+		return logMigrationVersion(migration)
+	})
+}
+
+func runMigrateVersion(ctx context.Context, config util.Config, args []string) error {
+	if err := noArgs("migrate version", args); err != nil {
+		return err
+	}
+
+	return withMigration(config, func(migration *migrate.Migrate) error {
+		version, dirty, err := migration.Version()
+		if errors.Is(err, migrate.ErrNilVersion) {
+			fmt.Println("no migration applied")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if dirty {
+			fmt.Printf("%d (dirty)\n", version)
+		} else {
+			fmt.Println(version)
+		}
+		return nil
+	})
+}
+
+// runMigrateForce records a version without running any migration, to recover
+// from a migration that failed halfway and left the database dirty.
+func runMigrateForce(ctx context.Context, config util.Config, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("migrate force needs a version, see simple_bank -h")
+	}
+
+	version, err := strconv.Atoi(args[0])
+	if err != nil || version < -1 {
+		return fmt.Errorf("invalid migration version %q", args[0])
+	}
+
+	return withMigration(config, func(migration *migrate.Migrate) error {
+		if err := migration.Force(version); err != nil {
+			return err
+		}
+		return logMigrationVersion(migration)
+	})
+}
+
+func withMigration(config util.Config, fn func(migration *migrate.Migrate) error) error {
+	migration, err := migrate.New(config.MigrationURL, config.DBSource)
+	if err != nil {
+		return fmt.Errorf("cannot create new migrate instance: %w", err)
+	}
+	defer migration.Close()
+
+	if err := fn(migration); err != nil {
+		return fmt.Errorf("failed to migrate: %w", err)
+	}
+	return nil
+}
+
+func logMigrationVersion(migration *migrate.Migrate) error {
+	version, dirty, err := migration.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("cannot read migration version: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"version": version,
+		"dirty":   dirty,
+	}).Info("DB migrate succesfully")
+	return nil
+}
+
+// parseSteps reads the optional number of migrations of migrate up and down.
+func parseSteps(name string, args []string, defaultSteps int) (int, error) {
+	switch len(args) {
+	case 0:
+		return defaultSteps, nil
+	case 1:
+		steps, err := strconv.Atoi(args[0])
+		if err != nil || steps < 1 {
+			return 0, fmt.Errorf("invalid number of migrations %q", args[0])
+		}
+		return steps, nil
+	default:
+		return 0, fmt.Errorf("%s takes at most one argument, see simple_bank -h", name)
+	}
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
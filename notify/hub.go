@@ -0,0 +1,93 @@
+package notify
+
+import "sync"
+
+// Hub fans out the accounts that got new entries to the subscriptions watching
+// them. It only carries wake-ups: subscribers read what changed from the
+// database, so a slow subscriber never holds up the others and nothing is lost
+// when signals are coalesced.
+type Hub struct {
+	mu            sync.Mutex
+	subscriptions map[int64]map[*Subscription]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscriptions: make(map[int64]map[*Subscription]struct{}),
+	}
+}
+
+// Subscription is signalled on C whenever one of its accounts gets new
+// entries. Signals that arrive before the previous one was received are
+// merged into it.
+type Subscription struct {
+	C <-chan struct{}
+
+	c          chan struct{}
+	hub        *Hub
+	accountIDs []int64
+}
+
+// Subscribe watches the accounts until the subscription is closed.
+func (h *Hub) Subscribe(accountIDs []int64) *Subscription {
+	c := make(chan struct{}, 1)
+	sub := &Subscription{
+		C:          c,
+		c:          c,
+		hub:        h,
+		accountIDs: accountIDs,
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, accountID := range accountIDs {
+		if h.subscriptions[accountID] == nil {
+			h.subscriptions[accountID] = make(map[*Subscription]struct{})
+		}
+		h.subscriptions[accountID][sub] = struct{}{}
+	}
+	return sub
+}
+
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	for _, accountID := range s.accountIDs {
+		delete(s.hub.subscriptions[accountID], s)
+		if len(s.hub.subscriptions[accountID]) == 0 {
+			delete(s.hub.subscriptions, accountID)
+		}
+	}
+}
+
+// Publish signals the subscriptions watching the account.
+func (h *Hub) Publish(accountID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscriptions[accountID] {
+		sub.signal()
+	}
+}
+
+// PublishAll signals every subscription, for when notifications may have been
+// missed.
+func (h *Hub) PublishAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, subs := range h.subscriptions {
+		for sub := range subs {
+			sub.signal()
+		}
+	}
+}
+
+func (s *Subscription) signal() {
+	select {
+	case s.c <- struct{}{}:
+	default:
+	}
+}
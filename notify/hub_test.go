@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func signalled(sub *Subscription) bool {
+	select {
+	case <-sub.C:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestHubPublish(t *testing.T) {
+	hub := NewHub()
+	sub1 := hub.Subscribe([]int64{1, 2})
+	sub2 := hub.Subscribe([]int64{2})
+	defer sub2.Close()
+
+	hub.Publish(1)
+	assert.True(t, signalled(sub1))
+	assert.False(t, signalled(sub2))
+
+	// signals are merged until received
+	hub.Publish(2)
+	hub.Publish(2)
+	assert.True(t, signalled(sub1))
+	assert.False(t, signalled(sub1))
+	assert.True(t, signalled(sub2))
+
+	sub1.Close()
+	hub.Publish(1)
+	assert.False(t, signalled(sub1))
+	assert.NotContains(t, hub.subscriptions, int64(1))
+}
+
+func TestHubPublishAll(t *testing.T) {
+	hub := NewHub()
+	sub1 := hub.Subscribe([]int64{1})
+	defer sub1.Close()
+	sub2 := hub.Subscribe([]int64{2})
+	defer sub2.Close()
+
+	hub.PublishAll()
+	assert.True(t, signalled(sub1))
+	assert.True(t, signalled(sub2))
+}
@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// channel the entries trigger notifies with the id of the account
+	EntryCreatedChannel = "entry_created"
+
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+	// the connection is checked this often when there are no notifications
+	pingInterval = 90 * time.Second
+)
+
+// Listen receives the notifications of new entries from Postgres and publishes
+// them to the hub until ctx is done. As notifications sent while the
+// connection was down are lost, every subscription is signalled when it is
+// reestablished.
+func Listen(ctx context.Context, dbSource string, hub *Hub) error {
+	conn, err := listen(ctx, dbSource)
+	if err != nil {
+		return err
+	}
+
+	reconnectInterval := minReconnectInterval
+	for {
+		err = receive(ctx, conn, hub)
+		conn.Close(context.Background())
+		if ctx.Err() != nil {
+			return nil
+		}
+		logrus.WithError(err).Warn("notification listener connection lost")
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(reconnectInterval):
+			}
+
+			conn, err = listen(ctx, dbSource)
+			if err == nil {
+				break
+			}
+			logrus.WithError(err).Warn("notification listener cannot reconnect")
+			reconnectInterval *= 2
+			if reconnectInterval > maxReconnectInterval {
+				reconnectInterval = maxReconnectInterval
+			}
+		}
+
+		reconnectInterval = minReconnectInterval
+		hub.PublishAll()
+	}
+}
+
+// listen opens a connection listening to EntryCreatedChannel.
+func listen(ctx context.Context, dbSource string) (*pgx.Conn, error) {
+	conn, err := pgx.Connect(ctx, dbSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to db: %w", err)
+	}
+
+	if _, err := conn.Exec(ctx, "LISTEN "+EntryCreatedChannel); err != nil {
+		conn.Close(context.Background())
+		return nil, fmt.Errorf("failed to listen to %s: %w", EntryCreatedChannel, err)
+	}
+
+	return conn, nil
+}
+
+// receive publishes the notifications until ctx is done or the connection
+// fails.
+func receive(ctx context.Context, conn *pgx.Conn, hub *Hub) error {
+	for {
+		waitCtx, cancel := context.WithTimeout(ctx, pingInterval)
+		notification, err := conn.WaitForNotification(waitCtx)
+		cancel()
+
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case errors.Is(err, context.DeadlineExceeded):
+			if err := conn.Ping(ctx); err != nil {
+				return fmt.Errorf("ping failed: %w", err)
+			}
+			continue
+		case err != nil:
+			return err
+		}
+
+		accountID, err := strconv.ParseInt(notification.Payload, 10, 64)
+		if err != nil {
+			logrus.WithError(err).Warnf("invalid %s notification payload: %q", EntryCreatedChannel, notification.Payload)
+			continue
+		}
+		hub.Publish(accountID)
+	}
+}
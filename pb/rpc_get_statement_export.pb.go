@@ -0,0 +1,215 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.7
+// source: rpc_get_statement_export.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetStatementExportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetStatementExportRequest) Reset() {
+	*x = GetStatementExportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_get_statement_export_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatementExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatementExportRequest) ProtoMessage() {}
+
+func (x *GetStatementExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_statement_export_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatementExportRequest.ProtoReflect.Descriptor instead.
+func (*GetStatementExportRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_get_statement_export_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetStatementExportRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetStatementExportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatementExport *StatementExport `protobuf:"bytes,1,opt,name=statement_export,json=statementExport,proto3" json:"statement_export,omitempty"`
+}
+
+func (x *GetStatementExportResponse) Reset() {
+	*x = GetStatementExportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_get_statement_export_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatementExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatementExportResponse) ProtoMessage() {}
+
+func (x *GetStatementExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_statement_export_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatementExportResponse.ProtoReflect.Descriptor instead.
+func (*GetStatementExportResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_get_statement_export_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetStatementExportResponse) GetStatementExport() *StatementExport {
+	if x != nil {
+		return x.StatementExport
+	}
+	return nil
+}
+
+var File_rpc_get_statement_export_proto protoreflect.FileDescriptor
+
+var file_rpc_get_statement_export_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x72, 0x70, 0x63, 0x5f, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x02, 0x70, 0x62, 0x1a, 0x16, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2b, 0x0a, 0x19,
+	0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x5c, 0x0a, 0x1a, 0x47, 0x65, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x10, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4e, 0x67, 0x75, 0x79, 0x65, 0x6e, 0x4d, 0x69, 0x6e, 0x68,
+	0x4b, 0x68, 0x61, 0x6e, 0x68, 0x42, 0x4b, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x62,
+	0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_get_statement_export_proto_rawDescOnce sync.Once
+	file_rpc_get_statement_export_proto_rawDescData = file_rpc_get_statement_export_proto_rawDesc
+)
+
+func file_rpc_get_statement_export_proto_rawDescGZIP() []byte {
+	file_rpc_get_statement_export_proto_rawDescOnce.Do(func() {
+		file_rpc_get_statement_export_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_get_statement_export_proto_rawDescData)
+	})
+	return file_rpc_get_statement_export_proto_rawDescData
+}
+
+var file_rpc_get_statement_export_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_get_statement_export_proto_goTypes = []interface{}{
+	(*GetStatementExportRequest)(nil),  // 0: pb.GetStatementExportRequest
+	(*GetStatementExportResponse)(nil), // 1: pb.GetStatementExportResponse
+	(*StatementExport)(nil),            // 2: pb.StatementExport
+}
+var file_rpc_get_statement_export_proto_depIdxs = []int32{
+	2, // 0: pb.GetStatementExportResponse.statement_export:type_name -> pb.StatementExport
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_get_statement_export_proto_init() }
+func file_rpc_get_statement_export_proto_init() {
+	if File_rpc_get_statement_export_proto != nil {
+		return
+	}
+	file_statement_export_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_get_statement_export_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatementExportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_get_statement_export_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatementExportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_get_statement_export_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_get_statement_export_proto_goTypes,
+		DependencyIndexes: file_rpc_get_statement_export_proto_depIdxs,
+		MessageInfos:      file_rpc_get_statement_export_proto_msgTypes,
+	}.Build()
+	File_rpc_get_statement_export_proto = out.File
+	file_rpc_get_statement_export_proto_rawDesc = nil
+	file_rpc_get_statement_export_proto_goTypes = nil
+	file_rpc_get_statement_export_proto_depIdxs = nil
+}
@@ -0,0 +1,223 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.7
+// source: rpc_requeue_failed_tasks.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RequeueFailedTasksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// omit to requeue from every queue
+	Queue *string `protobuf:"bytes,1,opt,name=queue,proto3,oneof" json:"queue,omitempty"`
+	// omit to requeue every task that ran out of retries; requires a queue
+	TaskIds []string `protobuf:"bytes,2,rep,name=task_ids,json=taskIds,proto3" json:"task_ids,omitempty"`
+}
+
+func (x *RequeueFailedTasksRequest) Reset() {
+	*x = RequeueFailedTasksRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_requeue_failed_tasks_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequeueFailedTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueFailedTasksRequest) ProtoMessage() {}
+
+func (x *RequeueFailedTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_requeue_failed_tasks_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueFailedTasksRequest.ProtoReflect.Descriptor instead.
+func (*RequeueFailedTasksRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_requeue_failed_tasks_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RequeueFailedTasksRequest) GetQueue() string {
+	if x != nil && x.Queue != nil {
+		return *x.Queue
+	}
+	return ""
+}
+
+func (x *RequeueFailedTasksRequest) GetTaskIds() []string {
+	if x != nil {
+		return x.TaskIds
+	}
+	return nil
+}
+
+type RequeueFailedTasksResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Requeued int32 `protobuf:"varint,1,opt,name=requeued,proto3" json:"requeued,omitempty"`
+}
+
+func (x *RequeueFailedTasksResponse) Reset() {
+	*x = RequeueFailedTasksResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_requeue_failed_tasks_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequeueFailedTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueFailedTasksResponse) ProtoMessage() {}
+
+func (x *RequeueFailedTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_requeue_failed_tasks_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueFailedTasksResponse.ProtoReflect.Descriptor instead.
+func (*RequeueFailedTasksResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_requeue_failed_tasks_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RequeueFailedTasksResponse) GetRequeued() int32 {
+	if x != nil {
+		return x.Requeued
+	}
+	return 0
+}
+
+var File_rpc_requeue_failed_tasks_proto protoreflect.FileDescriptor
+
+var file_rpc_requeue_failed_tasks_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x72, 0x70, 0x63, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x66, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x02, 0x70, 0x62, 0x22, 0x5b, 0x0a, 0x19, 0x52, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x46,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x05, 0x71, 0x75, 0x65, 0x75, 0x65, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x08,
+	0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x74, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x73, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x22, 0x38, 0x0a, 0x1a, 0x52, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x46, 0x61, 0x69, 0x6c,
+	0x65, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x42, 0x2d, 0x5a, 0x2b, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4e, 0x67, 0x75, 0x79, 0x65, 0x6e,
+	0x4d, 0x69, 0x6e, 0x68, 0x4b, 0x68, 0x61, 0x6e, 0x68, 0x42, 0x4b, 0x2f, 0x73, 0x69, 0x6d, 0x70,
+	0x6c, 0x65, 0x5f, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_rpc_requeue_failed_tasks_proto_rawDescOnce sync.Once
+	file_rpc_requeue_failed_tasks_proto_rawDescData = file_rpc_requeue_failed_tasks_proto_rawDesc
+)
+
+func file_rpc_requeue_failed_tasks_proto_rawDescGZIP() []byte {
+	file_rpc_requeue_failed_tasks_proto_rawDescOnce.Do(func() {
+		file_rpc_requeue_failed_tasks_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_requeue_failed_tasks_proto_rawDescData)
+	})
+	return file_rpc_requeue_failed_tasks_proto_rawDescData
+}
+
+var file_rpc_requeue_failed_tasks_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_requeue_failed_tasks_proto_goTypes = []interface{}{
+	(*RequeueFailedTasksRequest)(nil),  // 0: pb.RequeueFailedTasksRequest
+	(*RequeueFailedTasksResponse)(nil), // 1: pb.RequeueFailedTasksResponse
+}
+var file_rpc_requeue_failed_tasks_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_requeue_failed_tasks_proto_init() }
+func file_rpc_requeue_failed_tasks_proto_init() {
+	if File_rpc_requeue_failed_tasks_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_requeue_failed_tasks_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequeueFailedTasksRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_requeue_failed_tasks_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequeueFailedTasksResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_rpc_requeue_failed_tasks_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_requeue_failed_tasks_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_requeue_failed_tasks_proto_goTypes,
+		DependencyIndexes: file_rpc_requeue_failed_tasks_proto_depIdxs,
+		MessageInfos:      file_rpc_requeue_failed_tasks_proto_msgTypes,
+	}.Build()
+	File_rpc_requeue_failed_tasks_proto = out.File
+	file_rpc_requeue_failed_tasks_proto_rawDesc = nil
+	file_rpc_requeue_failed_tasks_proto_goTypes = nil
+	file_rpc_requeue_failed_tasks_proto_depIdxs = nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/seed"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+)
+
+// runSeed fills the database with the plan of its flags. No event is
+// published, so the seeded users get no verification email.
+func runSeed(ctx context.Context, config util.Config, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage: simple_bank seed [flags]\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	seedConfig := seed.Config{Seed: 1, Users: 10, Transfers: 100}
+	flags.Int64Var(&seedConfig.Seed, "seed", seedConfig.Seed, "seed of the generated data, the same seed gives the same data")
+	flags.IntVar(&seedConfig.Users, "users", seedConfig.Users, "number of users, each with an account in every currency")
+	flags.IntVar(&seedConfig.Transfers, "transfers", seedConfig.Transfers, "number of transfers between the users")
+	password := flags.String("password", "secret", "password of every seeded user")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+	if err := noArgs("seed", flags.Args()); err != nil {
+		return err
+	}
+
+	plan, err := seed.NewPlan(seedConfig)
+	if err != nil {
+		return fmt.Errorf("invalid seed: %w", err)
+	}
+
+	pool, err := db.NewPool(ctx, config.DBSource, config)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	result, err := seed.NewSeeder(db.NewStore(pool, db.WithoutEvents())).Run(ctx, plan, *password)
+	if errors.Is(err, seed.ErrAlreadySeeded) {
+		logrus.Info("database is already seeded, nothing to do")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"users":     result.Users,
+		"accounts":  result.Accounts,
+		"transfers": result.Transfers,
+	}).Info("seeded database")
+	return nil
+}
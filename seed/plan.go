@@ -0,0 +1,203 @@
+// Package seed fills a database with a reproducible set of users, accounts and
+// transfers, for local demos and load tests.
+package seed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+)
+
+// OperatorUsername is the user the opening deposits are recorded for.
+const OperatorUsername = "seed_operator"
+
+const (
+	minOpeningBalance = 50_000
+	maxOpeningBalance = 500_000
+	minTransferAmount = 100
+	maxTransferAmount = 50_000
+	// maxOutgoing keeps the transfers out of an account below the lowest daily
+	// limit of the account types, so that a plan can be seeded in one day.
+	maxOutgoing = 1_000_000
+)
+
+var currencies = []string{util.USD, util.EUR, util.CAD}
+
+var firstNames = []string{
+	"Alice", "Bob", "Carol", "David", "Emma", "Frank", "Grace", "Henry",
+	"Irene", "Jack", "Karen", "Liam", "Mia", "Noah", "Olivia", "Peter",
+	"Quinn", "Rose", "Sam", "Tina",
+}
+
+var lastNames = []string{
+	"Nguyen", "Smith", "Tran", "Johnson", "Le", "Brown", "Pham", "Garcia",
+	"Hoang", "Miller", "Vu", "Davis", "Dang", "Wilson", "Bui", "Moore",
+}
+
+var categories = []struct {
+	name         string
+	descriptions []string
+}{
+	{"rent", []string{"Rent", "Share of the rent", "Utilities"}},
+	{"food", []string{"Dinner", "Lunch", "Groceries", "Coffee"}},
+	{"travel", []string{"Train tickets", "Hotel", "Taxi"}},
+	{"gift", []string{"Birthday present", "Wedding gift"}},
+	{"refund", []string{"Paying you back", "Concert tickets", "Refund"}},
+}
+
+type Config struct {
+	// Seed makes the plan reproducible: the same config gives the same plan.
+	Seed int64
+	// Users is the number of customers, each with a checking account in every
+	// supported currency.
+	Users int
+	// Transfers is the number of transfers between customers. Fewer are
+	// planned when the accounts run out of money or of limits.
+	Transfers int
+}
+
+type User struct {
+	Username string
+	FullName string
+	Email    string
+}
+
+type Account struct {
+	Owner          string
+	Currency       string
+	AccountType    string
+	OpeningBalance int64
+}
+
+// Transfer moves money between two accounts of the plan, given by their index
+// in Plan.Accounts.
+type Transfer struct {
+	From        int
+	To          int
+	Amount      int64
+	Description string
+	Metadata    json.RawMessage
+}
+
+// Plan is what a seed creates, in order.
+type Plan struct {
+	Users     []User
+	Accounts  []Account
+	Transfers []Transfer
+}
+
+// NewPlan generates the plan of config. Every transfer is covered by the
+// balance its source account has at that point of the plan.
+func NewPlan(config Config) (Plan, error) {
+	if config.Users < 1 {
+		return Plan{}, errors.New("at least one user is needed")
+	}
+	if config.Transfers < 0 {
+		return Plan{}, errors.New("the number of transfers cannot be negative")
+	}
+	if config.Transfers > 0 && config.Users < 2 {
+		return Plan{}, errors.New("at least two users are needed for transfers")
+	}
+
+	rng := rand.New(rand.NewSource(config.Seed))
+	var plan Plan
+
+	for i := 0; i < config.Users; i++ {
+		first := firstNames[rng.Intn(len(firstNames))]
+		last := lastNames[rng.Intn(len(lastNames))]
+		username := fmt.Sprintf("%s_%s%d", strings.ToLower(first), strings.ToLower(last), i+1)
+
+		plan.Users = append(plan.Users, User{
+			Username: username,
+			FullName: first + " " + last,
+			Email:    username + "@example.com",
+		})
+
+		for _, currency := range currencies {
+			plan.Accounts = append(plan.Accounts, Account{
+				Owner:          username,
+				Currency:       currency,
+				AccountType:    util.AccountTypeChecking,
+				OpeningBalance: randomAmount(rng, minOpeningBalance, maxOpeningBalance),
+			})
+		}
+	}
+
+	balances := make([]int64, len(plan.Accounts))
+	outgoing := make([]int64, len(plan.Accounts))
+	for i, account := range plan.Accounts {
+		balances[i] = account.OpeningBalance
+	}
+
+	canSend := func(i int) bool {
+		return balances[i] >= minTransferAmount && maxOutgoing-outgoing[i] >= minTransferAmount
+	}
+
+	for len(plan.Transfers) < config.Transfers {
+		// Scan from a random account for one that can still send money, so
+		// that the plan stays reproducible when some cannot.
+		from := -1
+		start := rng.Intn(len(plan.Accounts))
+		for i := 0; i < len(plan.Accounts); i++ {
+			candidate := (start + i) % len(plan.Accounts)
+			if canSend(candidate) {
+				from = candidate
+				break
+			}
+		}
+		if from < 0 {
+			break
+		}
+
+		// Accounts are laid out per user in the order of currencies, so the
+		// account with the same currency of another user is a whole number of
+		// users away.
+		otherUser := 1 + rng.Intn(config.Users-1)
+		to := (from + otherUser*len(currencies)) % len(plan.Accounts)
+
+		upper := balances[from] / 2
+		if upper > maxTransferAmount {
+			upper = maxTransferAmount
+		}
+		if left := maxOutgoing - outgoing[from]; upper > left {
+			upper = left
+		}
+		if upper < minTransferAmount {
+			upper = minTransferAmount
+		}
+		amount := randomAmount(rng, minTransferAmount, upper)
+
+		category := categories[rng.Intn(len(categories))]
+		metadata, err := json.Marshal(map[string]string{"category": category.name})
+		if err != nil {
+			return Plan{}, err
+		}
+
+		plan.Transfers = append(plan.Transfers, Transfer{
+			From:        from,
+			To:          to,
+			Amount:      amount,
+			Description: category.descriptions[rng.Intn(len(category.descriptions))],
+			Metadata:    metadata,
+		})
+
+		balances[from] -= amount
+		balances[to] += amount
+		outgoing[from] += amount
+	}
+
+	return plan, nil
+}
+
+// randomAmount returns an amount between min and max, in whole units when the
+// range allows it.
+func randomAmount(rng *rand.Rand, min, max int64) int64 {
+	if max-min >= 100 {
+		return min + rng.Int63n((max-min)/100+1)*100
+	}
+	return min + rng.Int63n(max-min+1)
+}
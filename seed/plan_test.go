@@ -0,0 +1,98 @@
+package seed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPlanIsReproducible(t *testing.T) {
+	config := Config{Seed: 42, Users: 5, Transfers: 50}
+
+	plan1, err := NewPlan(config)
+	require.NoError(t, err)
+	plan2, err := NewPlan(config)
+	require.NoError(t, err)
+	require.Equal(t, plan1, plan2)
+
+	config.Seed = 43
+	plan3, err := NewPlan(config)
+	require.NoError(t, err)
+	require.NotEqual(t, plan1, plan3)
+}
+
+func TestNewPlan(t *testing.T) {
+	plan, err := NewPlan(Config{Seed: 7, Users: 4, Transfers: 200})
+	require.NoError(t, err)
+
+	require.Len(t, plan.Users, 4)
+	require.Len(t, plan.Accounts, 4*len(currencies))
+	require.Len(t, plan.Transfers, 200)
+
+	usernames := make(map[string]bool)
+	for _, user := range plan.Users {
+		require.False(t, usernames[user.Username])
+		usernames[user.Username] = true
+	}
+
+	balances := make([]int64, len(plan.Accounts))
+	outgoing := make([]int64, len(plan.Accounts))
+	for i, account := range plan.Accounts {
+		require.True(t, usernames[account.Owner])
+		require.Positive(t, account.OpeningBalance)
+		balances[i] = account.OpeningBalance
+	}
+
+	for _, transfer := range plan.Transfers {
+		from, to := plan.Accounts[transfer.From], plan.Accounts[transfer.To]
+		require.Equal(t, from.Currency, to.Currency)
+		require.NotEqual(t, from.Owner, to.Owner)
+		require.NotEmpty(t, transfer.Description)
+		require.JSONEq(t, `{"category":"`+categoryOf(t, transfer.Description)+`"}`, string(transfer.Metadata))
+
+		require.GreaterOrEqual(t, transfer.Amount, int64(minTransferAmount))
+		require.LessOrEqual(t, transfer.Amount, balances[transfer.From])
+		balances[transfer.From] -= transfer.Amount
+		balances[transfer.To] += transfer.Amount
+
+		outgoing[transfer.From] += transfer.Amount
+		require.LessOrEqual(t, outgoing[transfer.From], int64(maxOutgoing))
+	}
+}
+
+func TestNewPlanRunsOutOfMoney(t *testing.T) {
+	plan, err := NewPlan(Config{Seed: 1, Users: 2, Transfers: 100_000})
+	require.NoError(t, err)
+	require.NotEmpty(t, plan.Transfers)
+	require.Less(t, len(plan.Transfers), 100_000)
+}
+
+func TestNewPlanInvalidConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config Config
+	}{
+		{"NoUsers", Config{Users: 0}},
+		{"NegativeTransfers", Config{Users: 2, Transfers: -1}},
+		{"TransfersWithOneUser", Config{Users: 1, Transfers: 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewPlan(tc.config)
+			require.Error(t, err)
+		})
+	}
+}
+
+func categoryOf(t *testing.T, description string) string {
+	for _, category := range categories {
+		for _, d := range category.descriptions {
+			if d == description {
+				return category.name
+			}
+		}
+	}
+	t.Fatalf("unknown description %q", description)
+	return ""
+}
@@ -0,0 +1,152 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+)
+
+var (
+	// ErrAlreadySeeded is returned when the database was seeded before.
+	ErrAlreadySeeded = errors.New("database is already seeded")
+	// ErrIncompleteSeed is returned when a seed run stopped halfway, which
+	// left the database partly seeded.
+	ErrIncompleteSeed = errors.New("a previous seed did not complete, reset the database and seed again")
+)
+
+type Result struct {
+	Users     int
+	Accounts  int
+	Transfers int
+}
+
+// Seeder creates a plan through the transactional methods of the store, so
+// that the ledger holds as it does for customers: even the opening deposits
+// are balance adjustments, paid from the system accounts. Give it a store made
+// with db.WithoutEvents unless the seeded users should get emails and
+// webhooks.
+type Seeder struct {
+	store db.Store
+}
+
+func NewSeeder(store db.Store) *Seeder {
+	return &Seeder{store: store}
+}
+
+// Run creates the users of plan with the given password, their accounts with
+// their opening deposits, and then the transfers. The run is recorded, and
+// only marked as completed once everything was created, so that a database
+// is seeded at most once and a run that failed halfway is not taken for a
+// seeded database.
+func (s *Seeder) Run(ctx context.Context, plan Plan, password string) (Result, error) {
+	var result Result
+
+	lastRun, err := s.store.GetLastSeedRun(ctx)
+	switch {
+	case err == nil && lastRun.CompletedAt.Valid:
+		return result, ErrAlreadySeeded
+	case err == nil:
+		return result, fmt.Errorf("%w: run %d started at %s", ErrIncompleteSeed, lastRun.ID, lastRun.StartedAt.Format(time.RFC3339))
+	case !errors.Is(err, db.ErrNotFound):
+		return result, fmt.Errorf("cannot get last seed run: %w", err)
+	}
+
+	run, err := s.store.CreateSeedRun(ctx)
+	if err != nil {
+		return result, fmt.Errorf("cannot record seed run: %w", err)
+	}
+
+	hashedPassword, err := util.HashPassword(password)
+	if err != nil {
+		return result, fmt.Errorf("cannot hash password: %w", err)
+	}
+
+	operator := User{
+		Username: OperatorUsername,
+		FullName: "Seed Operator",
+		Email:    OperatorUsername + "@example.com",
+	}
+	if err := s.createUser(ctx, operator, hashedPassword); err != nil {
+		return result, err
+	}
+
+	for _, user := range plan.Users {
+		if err := s.createUser(ctx, user, hashedPassword); err != nil {
+			return result, err
+		}
+		result.Users++
+	}
+	logrus.WithField("users", result.Users).Info("seeded users")
+
+	accountIDs := make([]int64, len(plan.Accounts))
+	for i, account := range plan.Accounts {
+		created, err := s.store.CreateAccountTx(ctx, db.CreateAccountTxParams{
+			CreateAccountParams: db.CreateAccountParams{
+				Owner:       account.Owner,
+				Currency:    account.Currency,
+				AccountType: account.AccountType,
+			},
+		})
+		if err != nil {
+			return result, fmt.Errorf("cannot create %s account of %s: %w", account.Currency, account.Owner, err)
+		}
+		accountIDs[i] = created.Account.ID
+
+		if account.OpeningBalance > 0 {
+			_, err = s.store.AdjustBalanceTx(ctx, db.AdjustBalanceTxParams{
+				AccountID:  created.Account.ID,
+				Amount:     account.OpeningBalance,
+				Reason:     "Opening deposit",
+				AdjustedBy: OperatorUsername,
+			})
+			if err != nil {
+				return result, fmt.Errorf("cannot deposit into account %d: %w", created.Account.ID, err)
+			}
+		}
+		result.Accounts++
+	}
+	logrus.WithField("accounts", result.Accounts).Info("seeded accounts")
+
+	for _, transfer := range plan.Transfers {
+		_, err := s.store.TransferTx(ctx, db.TransferTxParams{
+			FromAccountID: accountIDs[transfer.From],
+			ToAccountID:   accountIDs[transfer.To],
+			Amount:        transfer.Amount,
+			Description:   transfer.Description,
+			Metadata:      transfer.Metadata,
+		})
+		if err != nil {
+			return result, fmt.Errorf("cannot transfer from account %d to %d: %w",
+				accountIDs[transfer.From], accountIDs[transfer.To], err)
+		}
+		result.Transfers++
+	}
+	logrus.WithField("transfers", result.Transfers).Info("seeded transfers")
+
+	if _, err := s.store.CompleteSeedRun(ctx, run.ID); err != nil {
+		return result, fmt.Errorf("cannot complete seed run %d: %w", run.ID, err)
+	}
+
+	return result, nil
+}
+
+func (s *Seeder) createUser(ctx context.Context, user User, hashedPassword string) error {
+	_, err := s.store.CreateUserTx(ctx, db.CreateUserTxParams{
+		CreateUserParams: db.CreateUserParams{
+			Username:       user.Username,
+			HashedPassword: hashedPassword,
+			FullName:       user.FullName,
+			Email:          user.Email,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create user %s: %w", user.Username, err)
+	}
+	return nil
+}
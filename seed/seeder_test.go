@@ -0,0 +1,103 @@
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	mock_sqlc "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeederRun(t *testing.T) {
+	plan, err := NewPlan(Config{Seed: 3, Users: 2, Transfers: 4})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	store := mock_sqlc.NewMockStore(ctrl)
+
+	store.EXPECT().GetLastSeedRun(gomock.Any()).Times(1).Return(db.SeedRun{}, db.ErrNotFound)
+	store.EXPECT().CreateSeedRun(gomock.Any()).Times(1).Return(db.SeedRun{ID: 1}, nil)
+	store.EXPECT().CreateUserTx(gomock.Any(), gomock.Any()).Times(len(plan.Users) + 1).
+		DoAndReturn(func(ctx context.Context, arg db.CreateUserTxParams) (db.CreateUserTxResult, error) {
+			require.NotEmpty(t, arg.HashedPassword)
+			return db.CreateUserTxResult{User: db.User{Username: arg.Username}}, nil
+		})
+
+	var nextID int64
+	store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(len(plan.Accounts)).
+		DoAndReturn(func(ctx context.Context, arg db.CreateAccountTxParams) (db.CreateAccountTxResult, error) {
+			require.Zero(t, arg.Balance)
+			nextID++
+			return db.CreateAccountTxResult{Account: db.Account{ID: nextID, Owner: arg.Owner}}, nil
+		})
+	store.EXPECT().AdjustBalanceTx(gomock.Any(), gomock.Any()).Times(len(plan.Accounts)).
+		DoAndReturn(func(ctx context.Context, arg db.AdjustBalanceTxParams) (db.AdjustBalanceTxResult, error) {
+			require.Equal(t, plan.Accounts[arg.AccountID-1].OpeningBalance, arg.Amount)
+			require.Equal(t, OperatorUsername, arg.AdjustedBy)
+			return db.AdjustBalanceTxResult{}, nil
+		})
+
+	for _, transfer := range plan.Transfers {
+		store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+			FromAccountID: int64(transfer.From + 1),
+			ToAccountID:   int64(transfer.To + 1),
+			Amount:        transfer.Amount,
+			Description:   transfer.Description,
+			Metadata:      transfer.Metadata,
+		})).Times(1).Return(db.TransferTxResult{}, nil)
+	}
+
+	// the run is only completed after the last transfer
+	store.EXPECT().CompleteSeedRun(gomock.Any(), int64(1)).Times(1).Return(db.SeedRun{ID: 1}, nil)
+
+	result, err := NewSeeder(store).Run(context.Background(), plan, "secret")
+	require.NoError(t, err)
+	require.Equal(t, Result{
+		Users:     len(plan.Users),
+		Accounts:  len(plan.Accounts),
+		Transfers: len(plan.Transfers),
+	}, result)
+}
+
+func TestSeederRunAlreadySeeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mock_sqlc.NewMockStore(ctrl)
+
+	completed := db.SeedRun{ID: 1, CompletedAt: sql.NullTime{Time: time.Now(), Valid: true}}
+	store.EXPECT().GetLastSeedRun(gomock.Any()).Times(1).Return(completed, nil)
+	store.EXPECT().CreateSeedRun(gomock.Any()).Times(0)
+	store.EXPECT().CreateUserTx(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := NewSeeder(store).Run(context.Background(), Plan{}, "secret")
+	require.ErrorIs(t, err, ErrAlreadySeeded)
+}
+
+func TestSeederRunIncomplete(t *testing.T) {
+	plan, err := NewPlan(Config{Seed: 3, Users: 2, Transfers: 4})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	store := mock_sqlc.NewMockStore(ctrl)
+
+	// the first run fails on its first transfer and is not completed
+	store.EXPECT().GetLastSeedRun(gomock.Any()).Times(1).Return(db.SeedRun{}, db.ErrNotFound)
+	store.EXPECT().CreateSeedRun(gomock.Any()).Times(1).Return(db.SeedRun{ID: 1, StartedAt: time.Now()}, nil)
+	store.EXPECT().CreateUserTx(gomock.Any(), gomock.Any()).AnyTimes().Return(db.CreateUserTxResult{}, nil)
+	store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).AnyTimes().Return(db.CreateAccountTxResult{}, nil)
+	store.EXPECT().AdjustBalanceTx(gomock.Any(), gomock.Any()).AnyTimes().Return(db.AdjustBalanceTxResult{}, nil)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, db.ErrInsufficientFunds)
+	store.EXPECT().CompleteSeedRun(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err = NewSeeder(store).Run(context.Background(), plan, "secret")
+	require.ErrorIs(t, err, db.ErrInsufficientFunds)
+
+	// the next run reports the half-seeded database instead of nothing to do
+	store.EXPECT().GetLastSeedRun(gomock.Any()).Times(1).Return(db.SeedRun{ID: 1, StartedAt: time.Now()}, nil)
+
+	_, err = NewSeeder(store).Run(context.Background(), plan, "secret")
+	require.ErrorIs(t, err, ErrIncompleteSeed)
+}
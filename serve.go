@@ -0,0 +1,452 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/apierror"
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+	"github.com/NguyenMinhKhanhBK/simple_bank/gapi"
+	"github.com/NguyenMinhKhanhBK/simple_bank/health"
+	"github.com/NguyenMinhKhanhBK/simple_bank/notify"
+	"github.com/NguyenMinhKhanhBK/simple_bank/pb"
+	"github.com/NguyenMinhKhanhBK/simple_bank/tracing"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/NguyenMinhKhanhBK/simple_bank/worker"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// components selects what a serve command runs in its process.
+type components struct {
+	migrate bool
+	grpc    bool
+	gateway bool
+	worker  bool
+}
+
+func runServe(ctx context.Context, config util.Config, args []string) error {
+	if err := noArgs("serve", args); err != nil {
+		return err
+	}
+	return serve(ctx, config, components{migrate: true, grpc: true, gateway: true, worker: true})
+}
+
+func runServeGRPC(ctx context.Context, config util.Config, args []string) error {
+	if err := noArgs("serve-grpc", args); err != nil {
+		return err
+	}
+	return serve(ctx, config, components{grpc: true})
+}
+
+func runServeGateway(ctx context.Context, config util.Config, args []string) error {
+	if err := noArgs("serve-gateway", args); err != nil {
+		return err
+	}
+	return serve(ctx, config, components{gateway: true})
+}
+
+// runWorker runs the task processor and the task scheduler. Its health probes
+// are served on the HTTP server address, as no gateway runs in its process.
+func runWorker(ctx context.Context, config util.Config, args []string) error {
+	if err := noArgs("worker", args); err != nil {
+		return err
+	}
+	return serve(ctx, config, components{worker: true})
+}
+
+func serve(ctx context.Context, config util.Config, run components) error {
+	shutdownTracing, err := tracing.Setup(context.Background(), config)
+	if err != nil {
+		return fmt.Errorf("cannot setup tracing: %w", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	pool, err := db.NewPool(ctx, config.DBSource, config)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	if run.migrate {
+		if err := runDBMigration(config.MigrationURL, config.DBSource); err != nil {
+			return err
+		}
+	}
+
+	redisOpt := asynq.RedisClientOpt{
+		Addr: config.RedisAddress,
+	}
+
+	taskDistributor := worker.NewRedisTaskDistributor(redisOpt)
+	defer taskDistributor.Close()
+
+	bus := events.NewBus()
+	storeOpts := []db.StoreOption{db.WithPublisher(bus)}
+
+	var replica *db.Replica
+	if config.DBReplicaSource != "" {
+		replicaPool, err := db.NewPool(ctx, config.DBReplicaSource, config)
+		if err != nil {
+			return fmt.Errorf("replica: %w", err)
+		}
+		defer replicaPool.Close()
+
+		replica = db.NewReplica(replicaPool, config.DBReplicaMaxLag)
+		storeOpts = append(storeOpts, db.WithReplica(replica))
+	}
+
+	store := db.NewStore(pool, storeOpts...)
+	worker.SubscribeEventHandlers(bus, taskDistributor)
+
+	// The processor is only reported by the health checks of the process that
+	// runs it.
+	var taskProcessor worker.TaskProcessor
+	var processorStatus health.ProcessorStatus
+	if run.worker {
+		taskProcessor = worker.NewRedisTaskProcessor(redisOpt, store, taskDistributor, worker.ProcessorConfig{
+			ShutdownTimeout:    config.TaskShutdownTimeout,
+			WebhookTimeout:     config.WebhookTimeout,
+			WebhookMaxAttempts: config.WebhookMaxAttempts,
+		})
+		processorStatus = taskProcessor
+	}
+
+	healthChecker, err := health.NewChecker(pool, config.MigrationURL, redisOpt, processorStatus)
+	if err != nil {
+		return fmt.Errorf("cannot create health checker: %w", err)
+	}
+	defer healthChecker.Close()
+
+	waitGroup, ctx := errgroup.WithContext(ctx)
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		healthChecker.SetShuttingDown()
+		return nil
+	})
+
+	var taskInspector worker.TaskInspector
+	if run.grpc || run.gateway {
+		taskInspector = worker.NewRedisTaskInspector(redisOpt)
+		defer taskInspector.Close()
+	}
+
+	start := func() error {
+		if replica != nil {
+			runReplicaLagCheck(ctx, waitGroup, config, replica)
+		}
+
+		if run.grpc || run.gateway {
+			hub := notify.NewHub()
+			runNotificationListener(ctx, waitGroup, config, hub)
+
+			if run.grpc {
+				if err := runGRPCServer(ctx, waitGroup, config, store, taskDistributor, taskInspector, hub, healthChecker); err != nil {
+					return err
+				}
+			}
+			if run.gateway {
+				if err := runGatewayServer(ctx, waitGroup, config, store, taskDistributor, taskInspector, hub, healthChecker); err != nil {
+					return err
+				}
+			}
+		}
+
+		if run.worker {
+			if !run.gateway {
+				runHealthServer(ctx, waitGroup, config, healthChecker)
+			}
+			if err := runTaskProcessor(ctx, waitGroup, taskProcessor); err != nil {
+				return err
+			}
+			runEventRelay(ctx, waitGroup, config, store)
+			if err := runTaskScheduler(ctx, waitGroup, config, redisOpt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := start(); err != nil {
+		// stop what was already started, so that the deferred cleanups, like
+		// flushing the spans, run before the error is returned
+		waitGroup.Go(func() error { return err })
+	}
+
+	if err := waitGroup.Wait(); err != nil {
+		return fmt.Errorf("error from wait group: %w", err)
+	}
+
+	logrus.Info("Exiting ...")
+	return nil
+}
+
+func runGRPCServer(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	config util.Config,
+	store db.Store,
+	taskDistributor worker.TaskDistributor,
+	taskInspector worker.TaskInspector,
+	hub *notify.Hub,
+	healthChecker *health.Checker,
+) error {
+	server, err := gapi.NewServer(config, store, taskDistributor, hub)
+	if err != nil {
+		return fmt.Errorf("cannot create server: %w", err)
+	}
+	adminServer := gapi.NewAdminServer(server, taskInspector)
+
+	interceptors := grpc.ChainUnaryInterceptor(
+		otelgrpc.UnaryServerInterceptor(),
+		gapi.GRPCLogger,
+		gapi.ReadYourWrites,
+	)
+	streamInterceptors := grpc.ChainStreamInterceptor(
+		otelgrpc.StreamServerInterceptor(),
+	)
+	grpcServer := grpc.NewServer(interceptors, streamInterceptors)
+	pb.RegisterSimpleBankServer(grpcServer, server)
+	pb.RegisterAdminServiceServer(grpcServer, adminServer)
+	reflection.Register(grpcServer)
+
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	listener, err := net.Listen("tcp", config.GRPCServerAddress)
+	if err != nil {
+		return fmt.Errorf("cannot create listener: %w", err)
+	}
+
+	waitGroup.Go(func() error {
+		logrus.Infof("start gRPC server at %v", config.GRPCServerAddress)
+
+		err := grpcServer.Serve(listener)
+		if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			return fmt.Errorf("gRPC server failed to serve: %w", err)
+		}
+		return nil
+	})
+
+	waitGroup.Go(func() error {
+		healthChecker.Watch(ctx, healthServer, config.HealthCheckInterval,
+			pb.SimpleBank_ServiceDesc.ServiceName, pb.AdminService_ServiceDesc.ServiceName)
+		return nil
+	})
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		logrus.Info("graceful shutdown gRPC server")
+		healthServer.Shutdown()
+
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(config.GRPCShutdownTimeout):
+			logrus.Warn("gRPC server drain timed out, closing remaining connections")
+			grpcServer.Stop()
+		}
+
+		logrus.Info("gRPC server is stopped")
+		return nil
+	})
+	return nil
+}
+
+func runGatewayServer(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	config util.Config,
+	store db.Store,
+	taskDistributor worker.TaskDistributor,
+	taskInspector worker.TaskInspector,
+	hub *notify.Hub,
+	healthChecker *health.Checker,
+) error {
+	server, err := gapi.NewServer(config, store, taskDistributor, hub)
+	if err != nil {
+		return fmt.Errorf("cannot create server: %w", err)
+	}
+	adminServer := gapi.NewAdminServer(server, taskInspector)
+
+	jsonOption := runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+		MarshalOptions: protojson.MarshalOptions{
+			UseProtoNames: true,
+		},
+		UnmarshalOptions: protojson.UnmarshalOptions{
+			DiscardUnknown: true,
+		},
+	})
+	grpcMux := runtime.NewServeMux(jsonOption, runtime.WithErrorHandler(apierror.GatewayErrorHandler))
+
+	err = pb.RegisterSimpleBankHandlerServer(ctx, grpcMux, server)
+	if err != nil {
+		return fmt.Errorf("cannot register handler server: %w", err)
+	}
+
+	err = pb.RegisterAdminServiceHandlerServer(ctx, grpcMux, adminServer)
+	if err != nil {
+		return fmt.Errorf("cannot register admin handler server: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/", grpcMux)
+
+	fsHandler := http.StripPrefix("/swagger/", http.FileServer(http.FS(swaggerFS)))
+	mux.Handle("/swagger/", fsHandler)
+
+	mux.Handle(gapi.DownloadStatementPath, server.DownloadStatementHandler())
+	mux.Handle(gapi.DownloadStatementExportPath, server.DownloadStatementExportHandler())
+	mux.Handle(gapi.WatchAccountsPath, server.WatchAccountsHandler())
+
+	mux.Handle("/healthz", healthChecker.LivenessHandler())
+	mux.Handle("/readyz", healthChecker.ReadinessHandler())
+
+	handler := otelhttp.NewHandler(gapi.HTTPLogger(gapi.HTTPReadYourWrites(mux)), "gateway",
+		otelhttp.WithSpanNameFormatter(func(_ string, req *http.Request) string {
+			return req.Method + " " + req.URL.Path
+		}),
+	)
+
+	httpServer := &http.Server{
+		Handler: handler,
+		Addr:    config.HTTPServerAddress,
+	}
+
+	waitGroup.Go(func() error {
+		logrus.Infof("start HTTP gateway server at %v", config.HTTPServerAddress)
+
+		err := httpServer.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("HTTP gateway server failed to serve: %w", err)
+		}
+		return nil
+	})
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		logrus.Info("graceful shutdown HTTP gateway server")
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), config.HTTPShutdownTimeout)
+		defer cancel()
+
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("failed to shutdown HTTP gateway server: %w", err)
+		}
+
+		logrus.Info("HTTP gateway server is stopped")
+		return nil
+	})
+	return nil
+}
+
+func runNotificationListener(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	config util.Config,
+	hub *notify.Hub,
+) {
+	waitGroup.Go(func() error {
+		logrus.Info("start notification listener")
+		if err := notify.Listen(ctx, config.DBSource, hub); err != nil {
+			return fmt.Errorf("notification listener failed: %w", err)
+		}
+
+		logrus.Info("notification listener is stopped")
+		return nil
+	})
+}
+
+// runReplicaLagCheck keeps the store reading from the replica only while it
+// keeps up with the primary.
+func runReplicaLagCheck(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	config util.Config,
+	replica *db.Replica,
+) {
+	waitGroup.Go(func() error {
+		logrus.Info("start replica lag check")
+		replica.Run(ctx, config.DBReplicaLagCheckInterval)
+
+		logrus.Info("replica lag check is stopped")
+		return nil
+	})
+}
+
+// runEventRelay publishes the domain events that the store wrote to its
+// outbox, which hands their side effects to tasks.
+func runEventRelay(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	config util.Config,
+	store db.Store,
+) {
+	waitGroup.Go(func() error {
+		logrus.Info("start event relay")
+		db.RelayEvents(ctx, store, config.EventRelayInterval)
+
+		logrus.Info("event relay is stopped")
+		return nil
+	})
+}
+
+// runHealthServer serves the health probes of a process that runs no gateway.
+func runHealthServer(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	config util.Config,
+	healthChecker *health.Checker,
+) {
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", healthChecker.LivenessHandler())
+	mux.Handle("/readyz", healthChecker.ReadinessHandler())
+
+	httpServer := &http.Server{
+		Handler: mux,
+		Addr:    config.HTTPServerAddress,
+	}
+
+	waitGroup.Go(func() error {
+		logrus.Infof("start health server at %v", config.HTTPServerAddress)
+
+		err := httpServer.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("health server failed to serve: %w", err)
+		}
+		return nil
+	})
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), config.HTTPShutdownTimeout)
+		defer cancel()
+
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("failed to shutdown health server: %w", err)
+		}
+
+		logrus.Info("health server is stopped")
+		return nil
+	})
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+)
+
+type CreateAccountParams struct {
+	Currency string
+	// checking when empty
+	AccountType string
+}
+
+func (arg CreateAccountParams) validate() error {
+	var v violations
+	if err := val.ValidateCurrency(arg.Currency); err != nil {
+		v.add("currency", err)
+	}
+
+	if arg.AccountType != "" {
+		if err := val.ValidateAccountType(arg.AccountType); err != nil {
+			v.add("account_type", err)
+		}
+	}
+
+	return v.err()
+}
+
+// CreateAccount opens an empty account for the user.
+func (s *Service) CreateAccount(ctx context.Context, username string, arg CreateAccountParams) (db.Account, error) {
+	if err := arg.validate(); err != nil {
+		return db.Account{}, err
+	}
+
+	accountType := arg.AccountType
+	if accountType == "" {
+		accountType = util.AccountTypeChecking
+	}
+
+	result, err := s.store.CreateAccountTx(ctx, db.CreateAccountTxParams{
+		CreateAccountParams: db.CreateAccountParams{
+			Owner:       username,
+			Balance:     0,
+			Currency:    arg.Currency,
+			AccountType: accountType,
+		},
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrUniqueViolation):
+			return db.Account{}, newError(ErrAlreadyExists, "the user already has an account in %s", arg.Currency)
+		case errors.Is(err, db.ErrForeignKeyViolation):
+			return db.Account{}, newError(ErrNotFound, "user not found")
+		}
+		return db.Account{}, fmt.Errorf("failed to create account: %w", err)
+	}
+
+	return result.Account, nil
+}
+
+// GetAccount returns an account of the user.
+func (s *Service) GetAccount(ctx context.Context, username string, accountID int64) (db.Account, error) {
+	if err := validateID(accountID); err != nil {
+		return db.Account{}, err
+	}
+
+	return s.OwnedAccount(ctx, username, accountID)
+}
+
+// OwnedAccount returns the account when the user owns it.
+func (s *Service) OwnedAccount(ctx context.Context, username string, accountID int64) (db.Account, error) {
+	account, err := s.getAccount(ctx, accountID)
+	if err != nil {
+		return account, err
+	}
+
+	if account.Owner != username {
+		return account, newError(ErrPermissionDenied, "account does not belong to the authenticated user")
+	}
+
+	return account, nil
+}
+
+// ListAccounts lists the accounts of the user.
+func (s *Service) ListAccounts(ctx context.Context, username string, page Page) ([]db.Account, error) {
+	var v violations
+	page.validate(&v)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	accounts, err := s.store.ListAccounts(ctx, db.ListAccountsParams{
+		Owner:  username,
+		Limit:  page.limit(),
+		Offset: page.offset(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// ActiveAccount returns the account when it is active and holds the currency,
+// whoever owns it.
+func (s *Service) ActiveAccount(ctx context.Context, accountID int64, currency string) (db.Account, error) {
+	account, err := s.getAccount(ctx, accountID)
+	if err != nil {
+		return account, err
+	}
+
+	if account.Status != util.AccountStatusActive {
+		return account, newError(ErrFailedPrecondition, "account [%d] is %s", account.ID, account.Status)
+	}
+
+	if account.Currency != currency {
+		return account, newError(ErrInvalidArgument, "account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
+	}
+
+	return account, nil
+}
+
+func (s *Service) getAccount(ctx context.Context, accountID int64) (db.Account, error) {
+	account, err := s.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return account, newError(ErrNotFound, "account [%d] not found", accountID)
+		}
+		return account, fmt.Errorf("failed to get account: %w", err)
+	}
+	return account, nil
+}
+
+type UpdateAccountStatusParams struct {
+	AccountID int64
+	Status    string
+	Reason    string
+}
+
+func (arg UpdateAccountStatusParams) validate() error {
+	var v violations
+	if err := val.ValidateID(arg.AccountID); err != nil {
+		v.add("account_id", err)
+	}
+
+	if err := val.ValidateAccountStatus(arg.Status); err != nil {
+		v.add("status", err)
+	}
+
+	if err := val.ValidateReason(arg.Reason); err != nil {
+		v.add("reason", err)
+	}
+
+	return v.err()
+}
+
+// UpdateAccountStatus freezes, closes or reopens an account. Owners can close
+// and reopen their own accounts, admins can make any allowed change.
+func (s *Service) UpdateAccountStatus(ctx context.Context, username string, arg UpdateAccountStatusParams) (db.Account, error) {
+	if err := arg.validate(); err != nil {
+		return db.Account{}, err
+	}
+
+	user, err := s.store.GetUser(ctx, username)
+	if err != nil {
+		return db.Account{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	account, err := s.getAccount(ctx, arg.AccountID)
+	if err != nil {
+		return account, err
+	}
+
+	if user.Role != util.AdminRole && account.Owner != user.Username {
+		return account, newError(ErrPermissionDenied, "account does not belong to the authenticated user")
+	}
+
+	// the role is checked against the status of the locked account
+	result, err := s.store.UpdateAccountStatusTx(ctx, db.UpdateAccountStatusTxParams{
+		AccountID:     account.ID,
+		Status:        arg.Status,
+		Reason:        arg.Reason,
+		ChangedBy:     user.Username,
+		ChangedByRole: user.Role,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrAccountStatusChangeNotAllowed) {
+			return result.Account, wrapError(ErrPermissionDenied, err)
+		}
+		return result.Account, fmt.Errorf("failed to update account status: %w", err)
+	}
+
+	return result.Account, nil
+}
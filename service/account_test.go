@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	mock_sqlc "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc/mock"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateAccountStatus(t *testing.T) {
+	owner := db.User{Username: util.RandomOwner(), Role: util.DepositorRole}
+	admin := db.User{Username: util.RandomOwner(), Role: util.AdminRole}
+	other := db.User{Username: util.RandomOwner(), Role: util.DepositorRole}
+	account := db.Account{ID: 1, Owner: owner.Username, Currency: util.USD, Status: util.AccountStatusActive}
+
+	testCases := []struct {
+		name       string
+		user       db.User
+		buildStubs func(store *mock_sqlc.MockStore, user db.User)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "Owner",
+			user: owner,
+			buildStubs: func(store *mock_sqlc.MockStore, user db.User) {
+				store.EXPECT().GetUser(gomock.Any(), user.Username).Times(1).Return(user, nil)
+				store.EXPECT().GetAccount(gomock.Any(), account.ID).Times(1).Return(account, nil)
+				store.EXPECT().
+					UpdateAccountStatusTx(gomock.Any(), gomock.Eq(db.UpdateAccountStatusTxParams{
+						AccountID:     account.ID,
+						Status:        util.AccountStatusClosed,
+						Reason:        "customer request",
+						ChangedBy:     user.Username,
+						ChangedByRole: util.DepositorRole,
+					})).
+					Times(1)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Admin",
+			user: admin,
+			buildStubs: func(store *mock_sqlc.MockStore, user db.User) {
+				store.EXPECT().GetUser(gomock.Any(), user.Username).Times(1).Return(user, nil)
+				store.EXPECT().GetAccount(gomock.Any(), account.ID).Times(1).Return(account, nil)
+				store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "OtherUser",
+			user: other,
+			buildStubs: func(store *mock_sqlc.MockStore, user db.User) {
+				store.EXPECT().GetUser(gomock.Any(), user.Username).Times(1).Return(user, nil)
+				store.EXPECT().GetAccount(gomock.Any(), account.ID).Times(1).Return(account, nil)
+				store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrPermissionDenied)
+			},
+		},
+		{
+			// an admin froze the account after it was read
+			name: "FrozenMeanwhile",
+			user: owner,
+			buildStubs: func(store *mock_sqlc.MockStore, user db.User) {
+				store.EXPECT().GetUser(gomock.Any(), user.Username).Times(1).Return(user, nil)
+				store.EXPECT().GetAccount(gomock.Any(), account.ID).Times(1).Return(account, nil)
+				store.EXPECT().
+					UpdateAccountStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UpdateAccountStatusTxResult{}, fmt.Errorf("only an admin can change account 1 from frozen to closed: %w", db.ErrAccountStatusChangeNotAllowed))
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrPermissionDenied)
+				require.ErrorIs(t, err, db.ErrAccountStatusChangeNotAllowed)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, store := newTestService(t)
+			tc.buildStubs(store, tc.user)
+
+			_, err := service.UpdateAccountStatus(context.Background(), tc.user.Username, UpdateAccountStatusParams{
+				AccountID: account.ID,
+				Status:    util.AccountStatusClosed,
+				Reason:    "customer request",
+			})
+			tc.checkError(t, err)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+)
+
+// maxBulkTransferItems bounds the transfers of a bulk transfer, as they all
+// run in one transaction holding the locks of their accounts.
+const maxBulkTransferItems = 500
+
+type BulkTransferItem struct {
+	ToAccountID       int64
+	Amount            int64
+	Description       string
+	ExternalReference *string
+	// JSON object, nil when not set
+	Metadata json.RawMessage
+}
+
+type BulkTransferParams struct {
+	FromAccountID int64
+	Currency      string
+	Items         []BulkTransferItem
+	// true to execute all of the items or none of them
+	Atomic bool
+}
+
+func (arg BulkTransferParams) validate() error {
+	var v violations
+	if err := val.ValidateID(arg.FromAccountID); err != nil {
+		v.add("from_account_id", err)
+	}
+
+	if err := val.ValidateCurrency(arg.Currency); err != nil {
+		v.add("currency", err)
+	}
+
+	if n := len(arg.Items); n < 1 || n > maxBulkTransferItems {
+		v.add("items", fmt.Errorf("must contain from 1 to %d transfers", maxBulkTransferItems))
+	}
+
+	for i, item := range arg.Items {
+		prefix := fmt.Sprintf("items[%d].", i)
+
+		if err := val.ValidateID(item.ToAccountID); err != nil {
+			v.add(prefix+"to_account_id", err)
+		} else if item.ToAccountID == arg.FromAccountID {
+			v.add(prefix+"to_account_id", errors.New("must differ from from_account_id"))
+		}
+
+		if err := val.ValidateAmount(item.Amount); err != nil {
+			v.add(prefix+"amount", err)
+		}
+
+		validateTransferDetails(&v, prefix, item.Description, item.ExternalReference, item.Metadata)
+	}
+
+	return v.err()
+}
+
+// BulkTransfer executes transfers from one account of the user to many. The
+// recipient accounts are all checked before anything is executed, so that a
+// request with an unknown recipient fails as a whole in both modes.
+//
+// The errors of the failed items are classified like the ones of
+// CreateTransfer. In atomic mode, the failed item is returned as a
+// *db.BulkTransferItemError wrapping its classified error.
+func (s *Service) BulkTransfer(ctx context.Context, username string, arg BulkTransferParams) (db.BulkTransferTxResult, error) {
+	var result db.BulkTransferTxResult
+	if err := arg.validate(); err != nil {
+		return result, err
+	}
+
+	fromAccount, err := s.ActiveAccount(ctx, arg.FromAccountID, arg.Currency)
+	if err != nil {
+		return result, err
+	}
+
+	if fromAccount.Owner != username {
+		return result, newError(ErrPermissionDenied, "from account does not belong to the authenticated user")
+	}
+
+	params := db.BulkTransferTxParams{
+		FromAccountID: fromAccount.ID,
+		Atomic:        arg.Atomic,
+	}
+
+	checked := make(map[int64]bool)
+	for _, item := range arg.Items {
+		if !checked[item.ToAccountID] {
+			if _, err := s.ActiveAccount(ctx, item.ToAccountID, arg.Currency); err != nil {
+				return result, err
+			}
+			checked[item.ToAccountID] = true
+		}
+
+		params.Items = append(params.Items, db.BulkTransferItem{
+			ToAccountID: item.ToAccountID,
+			Amount:      item.Amount,
+			Description: item.Description,
+			ExternalReference: sql.NullString{
+				String: stringValue(item.ExternalReference),
+				Valid:  item.ExternalReference != nil,
+			},
+			Metadata: item.Metadata,
+		})
+	}
+
+	result, err = s.store.BulkTransferTx(ctx, params)
+	if err != nil {
+		var itemErr *db.BulkTransferItemError
+		if errors.As(err, &itemErr) {
+			return result, &db.BulkTransferItemError{Index: itemErr.Index, Err: TransferTxError(itemErr.Err)}
+		}
+		return result, fmt.Errorf("failed to execute bulk transfer: %w", err)
+	}
+
+	for i := range result.Results {
+		if result.Results[i].Err != nil {
+			result.Results[i].Err = TransferTxError(result.Results[i].Err)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	mock_sqlc "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc/mock"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkTransfer(t *testing.T) {
+	username := util.RandomOwner()
+	fromAccount := db.Account{ID: 1, Owner: username, Currency: util.USD, Status: util.AccountStatusActive}
+	toAccount := db.Account{ID: 2, Owner: util.RandomOwner(), Currency: util.USD, Status: util.AccountStatusActive}
+	frozenAccount := toAccount
+	frozenAccount.Status = util.AccountStatusFrozen
+
+	testCases := []struct {
+		name       string
+		atomic     bool
+		buildStubs func(store *mock_sqlc.MockStore)
+		check      func(t *testing.T, result db.BulkTransferTxResult, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), fromAccount.ID).Times(1).Return(fromAccount, nil)
+				// checked once for both items
+				store.EXPECT().GetAccount(gomock.Any(), toAccount.ID).Times(1).Return(toAccount, nil)
+				store.EXPECT().
+					BulkTransferTx(gomock.Any(), gomock.Eq(db.BulkTransferTxParams{
+						FromAccountID: fromAccount.ID,
+						Items: []db.BulkTransferItem{
+							{ToAccountID: toAccount.ID, Amount: 10},
+							{ToAccountID: toAccount.ID, Amount: 20},
+						},
+					})).
+					Times(1).
+					Return(db.BulkTransferTxResult{
+						Results: []db.BulkTransferItemResult{{}, {Err: db.ErrInsufficientFunds}},
+					}, nil)
+			},
+			check: func(t *testing.T, result db.BulkTransferTxResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, result.Results, 2)
+				require.NoError(t, result.Results[0].Err)
+				require.ErrorIs(t, result.Results[1].Err, ErrFailedPrecondition)
+			},
+		},
+		{
+			name:   "AtomicItemFailed",
+			atomic: true,
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), fromAccount.ID).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), toAccount.ID).Times(1).Return(toAccount, nil)
+				store.EXPECT().
+					BulkTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.BulkTransferTxResult{}, &db.BulkTransferItemError{Index: 1, Err: db.ErrUniqueViolation})
+			},
+			check: func(t *testing.T, result db.BulkTransferTxResult, err error) {
+				var itemErr *db.BulkTransferItemError
+				require.ErrorAs(t, err, &itemErr)
+				require.Equal(t, 1, itemErr.Index)
+				require.ErrorIs(t, itemErr.Err, ErrAlreadyExists)
+			},
+		},
+		{
+			name: "RecipientNotActive",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), fromAccount.ID).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), toAccount.ID).Times(1).Return(frozenAccount, nil)
+				store.EXPECT().BulkTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, result db.BulkTransferTxResult, err error) {
+				require.ErrorIs(t, err, ErrFailedPrecondition)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, store := newTestService(t)
+			tc.buildStubs(store)
+
+			result, err := service.BulkTransfer(context.Background(), username, BulkTransferParams{
+				FromAccountID: fromAccount.ID,
+				Currency:      util.USD,
+				Items: []BulkTransferItem{
+					{ToAccountID: toAccount.ID, Amount: 10},
+					{ToAccountID: toAccount.ID, Amount: 20},
+				},
+				Atomic: tc.atomic,
+			})
+			tc.check(t, result, err)
+		})
+	}
+}
+
+func TestBulkTransferValidation(t *testing.T) {
+	service, store := newTestService(t)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := service.BulkTransfer(context.Background(), util.RandomOwner(), BulkTransferParams{
+		FromAccountID: 1,
+		Currency:      util.USD,
+		Items: []BulkTransferItem{
+			{ToAccountID: 1, Amount: 10},
+		},
+	})
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, "items[0].to_account_id", validationErr.Violations[0].Field)
+}
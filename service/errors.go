@@ -0,0 +1,125 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+)
+
+// The kinds of errors of the use cases. Every error returned by a Service
+// matches one of them with errors.Is, except for unexpected failures, which
+// the transports report as internal errors.
+var (
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrNotFound           = errors.New("not found")
+	ErrAlreadyExists      = errors.New("already exists")
+	ErrUnauthenticated    = errors.New("unauthenticated")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	ErrLimitExceeded      = errors.New("limit exceeded")
+)
+
+var (
+	errMustBeAtLeastOne = errors.New("must be at least 1")
+	errPageSize         = errors.New("must be between 5 and 10")
+)
+
+// Error is an expected failure of a use case. Its message is meant for the
+// client.
+type Error struct {
+	Kind    error
+	Message string
+	// Err is the cause, if any.
+	Err error
+}
+
+func newError(kind error, format string, args ...interface{}) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+// wrapError classifies err, keeping its message.
+func wrapError(kind error, err error) *Error {
+	return &Error{Kind: kind, Err: err}
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Message == "" && e.Err != nil:
+		return e.Err.Error()
+	case e.Message == "":
+		return e.Kind.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// ValidationError lists the invalid fields of a request. It matches
+// ErrInvalidArgument.
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		fields = append(fields, violation.Field+" "+violation.Description)
+	}
+	return "invalid argument: " + strings.Join(fields, "; ")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+// violations collects the invalid fields of a request.
+type violations []FieldViolation
+
+func (v *violations) add(field string, err error) {
+	*v = append(*v, FieldViolation{Field: field, Description: err.Error()})
+}
+
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: v}
+}
+
+// validateID validates the id of the resource a request is about.
+func validateID(id int64) error {
+	var v violations
+	if err := val.ValidateID(id); err != nil {
+		v.add("id", err)
+	}
+	return v.err()
+}
+
+// TransferTxError classifies an error of the transfer methods of db.Store.
+func TransferTxError(err error) error {
+	var limitErr *db.TransferLimitError
+	if errors.As(err, &limitErr) {
+		return wrapError(ErrLimitExceeded, err)
+	}
+	if errors.Is(err, db.ErrAccountNotActive) || errors.Is(err, db.ErrInsufficientFunds) {
+		return wrapError(ErrFailedPrecondition, err)
+	}
+	if errors.Is(err, db.ErrUniqueViolation) {
+		return &Error{Kind: ErrAlreadyExists, Message: "a transfer with this external reference already exists", Err: err}
+	}
+	return fmt.Errorf("failed to transfer: %w", err)
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	mock_sqlc "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc/mock"
+	"github.com/NguyenMinhKhanhBK/simple_bank/token"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestService(t *testing.T) (*Service, *mock_sqlc.MockStore) {
+	ctrl := gomock.NewController(t)
+	store := mock_sqlc.NewMockStore(ctrl)
+
+	tokenMaker, err := token.NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	config := util.Config{
+		AccessTokenDuration:  time.Minute,
+		RefreshTokenDuration: time.Hour,
+	}
+	return New(config, store, tokenMaker), store
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+)
+
+// CreatePayeeParams gives the target of the payee by exactly one of AccountID
+// or Username.
+type CreatePayeeParams struct {
+	Nickname  string
+	Currency  string
+	AccountID int64
+	Username  string
+}
+
+func (arg CreatePayeeParams) validate() error {
+	var v violations
+	if err := val.ValidateNickname(arg.Nickname); err != nil {
+		v.add("nickname", err)
+	}
+
+	switch {
+	case (arg.AccountID != 0) == (arg.Username != ""):
+		v.add("target", errors.New("one of account_id or username is required"))
+	case arg.AccountID != 0:
+		if err := val.ValidateID(arg.AccountID); err != nil {
+			v.add("account_id", err)
+		}
+	default:
+		if err := val.ValidateUsername(arg.Username); err != nil {
+			v.add("username", err)
+		}
+	}
+
+	if err := val.ValidateCurrency(arg.Currency); err != nil {
+		v.add("currency", err)
+	}
+
+	return v.err()
+}
+
+// CreatePayee saves a counterparty of the user. The target must be able to
+// receive transfers in the currency when the payee is created. Transfers to a
+// new payee are refused until the configured cooling-off period has passed.
+func (s *Service) CreatePayee(ctx context.Context, username string, arg CreatePayeeParams) (db.Payee, error) {
+	if err := arg.validate(); err != nil {
+		return db.Payee{}, err
+	}
+
+	params := db.CreatePayeeParams{
+		Owner:       username,
+		Nickname:    arg.Nickname,
+		Currency:    arg.Currency,
+		AvailableAt: time.Now().Add(s.config.PayeeCoolingOffPeriod),
+	}
+
+	if arg.AccountID != 0 {
+		account, err := s.ActiveAccount(ctx, arg.AccountID, arg.Currency)
+		if err != nil {
+			return db.Payee{}, err
+		}
+		if account.Owner == username {
+			return db.Payee{}, newError(ErrInvalidArgument, "cannot add an own account as a payee")
+		}
+		params.AccountID = sql.NullInt64{Int64: account.ID, Valid: true}
+	} else {
+		if arg.Username == username {
+			return db.Payee{}, newError(ErrInvalidArgument, "cannot add oneself as a payee")
+		}
+		_, err := s.store.GetRecipientAccount(ctx, db.GetRecipientAccountParams{
+			Username: sql.NullString{String: arg.Username, Valid: true},
+			Currency: arg.Currency,
+		})
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return db.Payee{}, ErrRecipientNotFound
+			}
+			return db.Payee{}, fmt.Errorf("failed to get recipient account: %w", err)
+		}
+		params.Username = sql.NullString{String: arg.Username, Valid: true}
+	}
+
+	payee, err := s.store.CreatePayee(ctx, params)
+	if err != nil {
+		return payee, fmt.Errorf("failed to create payee: %w", err)
+	}
+
+	return payee, nil
+}
+
+// GetPayee returns a payee of the user.
+func (s *Service) GetPayee(ctx context.Context, username string, payeeID int64) (db.Payee, error) {
+	if err := validateID(payeeID); err != nil {
+		return db.Payee{}, err
+	}
+
+	return s.OwnedPayee(ctx, username, payeeID)
+}
+
+// ListPayees lists the payees of the user.
+func (s *Service) ListPayees(ctx context.Context, username string, page Page) ([]db.Payee, error) {
+	var v violations
+	page.validate(&v)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	payees, err := s.store.ListPayees(ctx, db.ListPayeesParams{
+		Owner:  username,
+		Limit:  page.limit(),
+		Offset: page.offset(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payees: %w", err)
+	}
+
+	return payees, nil
+}
+
+type UpdatePayeeParams struct {
+	ID       int64
+	Nickname string
+}
+
+func (arg UpdatePayeeParams) validate() error {
+	var v violations
+	if err := val.ValidateID(arg.ID); err != nil {
+		v.add("id", err)
+	}
+
+	if err := val.ValidateNickname(arg.Nickname); err != nil {
+		v.add("nickname", err)
+	}
+
+	return v.err()
+}
+
+// UpdatePayee renames a payee of the user.
+func (s *Service) UpdatePayee(ctx context.Context, username string, arg UpdatePayeeParams) (db.Payee, error) {
+	if err := arg.validate(); err != nil {
+		return db.Payee{}, err
+	}
+
+	payee, err := s.OwnedPayee(ctx, username, arg.ID)
+	if err != nil {
+		return payee, err
+	}
+
+	payee, err = s.store.UpdatePayee(ctx, db.UpdatePayeeParams{
+		ID:       payee.ID,
+		Nickname: arg.Nickname,
+	})
+	if err != nil {
+		return payee, fmt.Errorf("failed to update payee: %w", err)
+	}
+
+	return payee, nil
+}
+
+// DeletePayee deletes a payee of the user.
+func (s *Service) DeletePayee(ctx context.Context, username string, payeeID int64) error {
+	if err := validateID(payeeID); err != nil {
+		return err
+	}
+
+	payee, err := s.OwnedPayee(ctx, username, payeeID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.DeletePayee(ctx, payee.ID); err != nil {
+		return fmt.Errorf("failed to delete payee: %w", err)
+	}
+
+	return nil
+}
+
+// OwnedPayee returns the payee when the user owns it.
+func (s *Service) OwnedPayee(ctx context.Context, username string, payeeID int64) (db.Payee, error) {
+	payee, err := s.store.GetPayee(ctx, payeeID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return payee, newError(ErrNotFound, "payee not found")
+		}
+		return payee, fmt.Errorf("failed to get payee: %w", err)
+	}
+
+	if payee.Owner != username {
+		return payee, newError(ErrPermissionDenied, "payee does not belong to the authenticated user")
+	}
+
+	return payee, nil
+}
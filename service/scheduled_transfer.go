@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+)
+
+// recentExecutionsLimit is the number of executions returned along with a
+// scheduled transfer.
+const recentExecutionsLimit = 10
+
+// CreateScheduledTransferParams schedules a one-off transfer at StartAt, or a
+// recurring one from StartAt when Recurrence is set.
+type CreateScheduledTransferParams struct {
+	FromAccountID int64
+	ToAccountID   int64
+	Amount        int64
+	Currency      string
+	// cron expression, nil for a one-off transfer
+	Recurrence *string
+	// required
+	StartAt time.Time
+	// only allowed with a recurrence
+	EndAt *time.Time
+}
+
+func (arg CreateScheduledTransferParams) validate() error {
+	var v violations
+	if err := val.ValidateID(arg.FromAccountID); err != nil {
+		v.add("from_account_id", err)
+	}
+
+	if err := val.ValidateID(arg.ToAccountID); err != nil {
+		v.add("to_account_id", err)
+	} else if arg.ToAccountID == arg.FromAccountID {
+		v.add("to_account_id", errors.New("must differ from from_account_id"))
+	}
+
+	if err := val.ValidateAmount(arg.Amount); err != nil {
+		v.add("amount", err)
+	}
+
+	if err := val.ValidateCurrency(arg.Currency); err != nil {
+		v.add("currency", err)
+	}
+
+	if arg.StartAt.IsZero() {
+		v.add("start_at", errors.New("is required"))
+	} else if !arg.StartAt.After(time.Now()) {
+		v.add("start_at", errors.New("must be in the future"))
+	}
+
+	if arg.Recurrence != nil {
+		if err := val.ValidateRecurrence(*arg.Recurrence); err != nil {
+			v.add("recurrence", err)
+		}
+	}
+
+	if arg.EndAt != nil {
+		if arg.Recurrence == nil {
+			v.add("end_at", errors.New("is only allowed with a recurrence"))
+		} else if !arg.EndAt.After(arg.StartAt) {
+			v.add("end_at", errors.New("must be after start_at"))
+		}
+	}
+
+	return v.err()
+}
+
+// CreateScheduledTransfer schedules transfers from an account of the user.
+// The accounts are checked now and again at every execution.
+func (s *Service) CreateScheduledTransfer(ctx context.Context, username string, arg CreateScheduledTransferParams) (db.ScheduledTransfer, error) {
+	if err := arg.validate(); err != nil {
+		return db.ScheduledTransfer{}, err
+	}
+
+	fromAccount, err := s.ActiveAccount(ctx, arg.FromAccountID, arg.Currency)
+	if err != nil {
+		return db.ScheduledTransfer{}, err
+	}
+
+	if fromAccount.Owner != username {
+		return db.ScheduledTransfer{}, newError(ErrPermissionDenied, "from account does not belong to the authenticated user")
+	}
+
+	if _, err := s.ActiveAccount(ctx, arg.ToAccountID, arg.Currency); err != nil {
+		return db.ScheduledTransfer{}, err
+	}
+
+	scheduledTransfer, err := s.store.CreateScheduledTransfer(ctx, db.CreateScheduledTransferParams{
+		Owner:         username,
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Recurrence: sql.NullString{
+			String: stringValue(arg.Recurrence),
+			Valid:  arg.Recurrence != nil,
+		},
+		NextRunAt: arg.StartAt,
+		EndAt:     nullTime(arg.EndAt),
+	})
+	if err != nil {
+		return scheduledTransfer, fmt.Errorf("failed to create scheduled transfer: %w", err)
+	}
+
+	return scheduledTransfer, nil
+}
+
+// GetScheduledTransfer returns a scheduled transfer of the user along with its
+// most recent executions.
+func (s *Service) GetScheduledTransfer(ctx context.Context, username string, id int64) (db.ScheduledTransfer, []db.ScheduledTransferExecution, error) {
+	if err := validateID(id); err != nil {
+		return db.ScheduledTransfer{}, nil, err
+	}
+
+	scheduledTransfer, err := s.OwnedScheduledTransfer(ctx, username, id)
+	if err != nil {
+		return scheduledTransfer, nil, err
+	}
+
+	executions, err := s.store.ListScheduledTransferExecutions(ctx, db.ListScheduledTransferExecutionsParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		Limit:               recentExecutionsLimit,
+		Offset:              0,
+	})
+	if err != nil {
+		return scheduledTransfer, nil, fmt.Errorf("failed to list executions: %w", err)
+	}
+
+	return scheduledTransfer, executions, nil
+}
+
+// ListScheduledTransfers lists the scheduled transfers of the user.
+func (s *Service) ListScheduledTransfers(ctx context.Context, username string, page Page) ([]db.ScheduledTransfer, error) {
+	var v violations
+	page.validate(&v)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	scheduledTransfers, err := s.store.ListScheduledTransfers(ctx, db.ListScheduledTransfersParams{
+		Owner:  username,
+		Limit:  page.limit(),
+		Offset: page.offset(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled transfers: %w", err)
+	}
+
+	return scheduledTransfers, nil
+}
+
+// UpdateScheduledTransferParams changes the fields that are not nil.
+type UpdateScheduledTransferParams struct {
+	ID         int64
+	Amount     *int64
+	Recurrence *string
+	NextRunAt  *time.Time
+	EndAt      *time.Time
+	// active or suspended
+	Status *string
+}
+
+func (arg UpdateScheduledTransferParams) validate() error {
+	var v violations
+	if err := val.ValidateID(arg.ID); err != nil {
+		v.add("id", err)
+	}
+
+	if arg.Amount != nil {
+		if err := val.ValidateAmount(*arg.Amount); err != nil {
+			v.add("amount", err)
+		}
+	}
+
+	if arg.Recurrence != nil {
+		if err := val.ValidateRecurrence(*arg.Recurrence); err != nil {
+			v.add("recurrence", err)
+		}
+	}
+
+	if arg.NextRunAt != nil && !arg.NextRunAt.After(time.Now()) {
+		v.add("next_run_at", errors.New("must be in the future"))
+	}
+
+	if arg.EndAt != nil && !arg.EndAt.After(time.Now()) {
+		v.add("end_at", errors.New("must be in the future"))
+	}
+
+	if arg.Status != nil {
+		switch *arg.Status {
+		case util.ScheduledTransferActive, util.ScheduledTransferSuspended:
+		default:
+			v.add("status", fmt.Errorf("must be either %s or %s", util.ScheduledTransferActive, util.ScheduledTransferSuspended))
+		}
+	}
+
+	return v.err()
+}
+
+// UpdateScheduledTransfer changes a scheduled transfer of the user that is
+// still running, suspends it or resumes it.
+func (s *Service) UpdateScheduledTransfer(ctx context.Context, username string, arg UpdateScheduledTransferParams) (db.ScheduledTransfer, error) {
+	if err := arg.validate(); err != nil {
+		return db.ScheduledTransfer{}, err
+	}
+
+	scheduledTransfer, err := s.runningScheduledTransfer(ctx, username, arg.ID)
+	if err != nil {
+		return scheduledTransfer, err
+	}
+
+	if arg.EndAt != nil && !scheduledTransfer.Recurrence.Valid && arg.Recurrence == nil {
+		return scheduledTransfer, newError(ErrFailedPrecondition, "end_at is only allowed with a recurrence")
+	}
+
+	params := db.UpdateScheduledTransferParams{
+		ID: scheduledTransfer.ID,
+		Amount: sql.NullInt64{
+			Int64: int64Value(arg.Amount),
+			Valid: arg.Amount != nil,
+		},
+		Recurrence: sql.NullString{
+			String: stringValue(arg.Recurrence),
+			Valid:  arg.Recurrence != nil,
+		},
+		NextRunAt: nullTime(arg.NextRunAt),
+		EndAt:     nullTime(arg.EndAt),
+		Status: sql.NullString{
+			String: stringValue(arg.Status),
+			Valid:  arg.Status != nil,
+		},
+	}
+
+	// resuming starts over the failed attempts, and an occurrence that was
+	// missed while suspended runs right away
+	if stringValue(arg.Status) == util.ScheduledTransferActive && scheduledTransfer.Status == util.ScheduledTransferSuspended {
+		params.FailedAttempts = sql.NullInt32{Int32: 0, Valid: true}
+		if !params.NextRunAt.Valid && scheduledTransfer.NextRunAt.Before(time.Now()) {
+			params.NextRunAt = sql.NullTime{Time: time.Now(), Valid: true}
+		}
+	}
+
+	scheduledTransfer, err = s.store.UpdateScheduledTransfer(ctx, params)
+	if err != nil {
+		return scheduledTransfer, fmt.Errorf("failed to update scheduled transfer: %w", err)
+	}
+
+	return scheduledTransfer, nil
+}
+
+// CancelScheduledTransfer cancels a scheduled transfer of the user. The record
+// is kept so that its past executions can still be looked up.
+func (s *Service) CancelScheduledTransfer(ctx context.Context, username string, id int64) (db.ScheduledTransfer, error) {
+	if err := validateID(id); err != nil {
+		return db.ScheduledTransfer{}, err
+	}
+
+	scheduledTransfer, err := s.runningScheduledTransfer(ctx, username, id)
+	if err != nil {
+		return scheduledTransfer, err
+	}
+
+	scheduledTransfer, err = s.store.UpdateScheduledTransfer(ctx, db.UpdateScheduledTransferParams{
+		ID: scheduledTransfer.ID,
+		Status: sql.NullString{
+			String: util.ScheduledTransferCancelled,
+			Valid:  true,
+		},
+	})
+	if err != nil {
+		return scheduledTransfer, fmt.Errorf("failed to cancel scheduled transfer: %w", err)
+	}
+
+	return scheduledTransfer, nil
+}
+
+// OwnedScheduledTransfer returns the scheduled transfer when the user owns it.
+func (s *Service) OwnedScheduledTransfer(ctx context.Context, username string, id int64) (db.ScheduledTransfer, error) {
+	scheduledTransfer, err := s.store.GetScheduledTransfer(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return scheduledTransfer, newError(ErrNotFound, "scheduled transfer not found")
+		}
+		return scheduledTransfer, fmt.Errorf("failed to get scheduled transfer: %w", err)
+	}
+
+	if scheduledTransfer.Owner != username {
+		return scheduledTransfer, newError(ErrPermissionDenied, "scheduled transfer does not belong to the authenticated user")
+	}
+
+	return scheduledTransfer, nil
+}
+
+// runningScheduledTransfer returns a scheduled transfer of the user that is
+// neither completed nor cancelled.
+func (s *Service) runningScheduledTransfer(ctx context.Context, username string, id int64) (db.ScheduledTransfer, error) {
+	scheduledTransfer, err := s.OwnedScheduledTransfer(ctx, username, id)
+	if err != nil {
+		return scheduledTransfer, err
+	}
+
+	switch scheduledTransfer.Status {
+	case util.ScheduledTransferCompleted, util.ScheduledTransferCancelled:
+		return scheduledTransfer, newError(ErrFailedPrecondition, "scheduled transfer is %s", scheduledTransfer.Status)
+	}
+
+	return scheduledTransfer, nil
+}
+
+func int64Value(value *int64) int64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+func nullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *value, Valid: true}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	mock_sqlc "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc/mock"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateScheduledTransfer(t *testing.T) {
+	username := util.RandomOwner()
+	suspended := db.ScheduledTransfer{
+		ID:             3,
+		Owner:          username,
+		NextRunAt:      time.Now().Add(-time.Hour),
+		Status:         util.ScheduledTransferSuspended,
+		FailedAttempts: 3,
+	}
+	cancelled := suspended
+	cancelled.Status = util.ScheduledTransferCancelled
+	otherUsers := suspended
+	otherUsers.Owner = util.RandomOwner()
+
+	active := util.ScheduledTransferActive
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mock_sqlc.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "Resume",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), suspended.ID).Times(1).Return(suspended, nil)
+				store.EXPECT().
+					UpdateScheduledTransfer(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.UpdateScheduledTransferParams) (db.ScheduledTransfer, error) {
+						require.Equal(t, sql.NullString{String: active, Valid: true}, arg.Status)
+						require.Equal(t, sql.NullInt32{Int32: 0, Valid: true}, arg.FailedAttempts)
+						// the missed occurrence runs right away
+						require.True(t, arg.NextRunAt.Valid)
+						require.WithinDuration(t, time.Now(), arg.NextRunAt.Time, time.Second)
+						return suspended, nil
+					})
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Cancelled",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), suspended.ID).Times(1).Return(cancelled, nil)
+				store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrFailedPrecondition)
+			},
+		},
+		{
+			name: "OtherUser",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), suspended.ID).Times(1).Return(otherUsers, nil)
+				store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrPermissionDenied)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), suspended.ID).Times(1).Return(db.ScheduledTransfer{}, db.ErrNotFound)
+				store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, store := newTestService(t)
+			tc.buildStubs(store)
+
+			_, err := service.UpdateScheduledTransfer(context.Background(), username, UpdateScheduledTransferParams{
+				ID:     suspended.ID,
+				Status: &active,
+			})
+			tc.checkError(t, err)
+		})
+	}
+}
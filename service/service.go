@@ -0,0 +1,48 @@
+// Package service holds the use cases of the bank that do not depend on a
+// transport. The Gin and gRPC servers decode requests, call a Service and
+// encode its results and errors.
+package service
+
+import (
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/token"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+)
+
+type Service struct {
+	store      db.Store
+	tokenMaker token.Maker
+	config     util.Config
+}
+
+func New(config util.Config, store db.Store, tokenMaker token.Maker) *Service {
+	return &Service{
+		store:      store,
+		tokenMaker: tokenMaker,
+		config:     config,
+	}
+}
+
+// Page selects a page of a list, starting at page 1.
+type Page struct {
+	ID   int32
+	Size int32
+}
+
+func (p Page) limit() int32 {
+	return p.Size
+}
+
+func (p Page) offset() int32 {
+	return (p.ID - 1) * p.Size
+}
+
+func (p Page) validate(v *violations) {
+	if p.ID < 1 {
+		v.add("page_id", errMustBeAtLeastOne)
+	}
+
+	if p.Size < 5 || p.Size > 10 {
+		v.add("page_size", errPageSize)
+	}
+}
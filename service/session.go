@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/token"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+	"github.com/google/uuid"
+)
+
+type LoginUserParams struct {
+	Username  string
+	Password  string
+	UserAgent string
+	ClientIP  string
+}
+
+type LoginUserResult struct {
+	User                  db.User
+	Session               db.Session
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+func (arg LoginUserParams) validate() error {
+	var v violations
+	if err := val.ValidateUsername(arg.Username); err != nil {
+		v.add("username", err)
+	}
+
+	if err := val.ValidatePassword(arg.Password); err != nil {
+		v.add("password", err)
+	}
+
+	return v.err()
+}
+
+// LoginUser checks the password of the user and opens a session, whose
+// refresh token renews the short-lived access tokens.
+func (s *Service) LoginUser(ctx context.Context, arg LoginUserParams) (LoginUserResult, error) {
+	var result LoginUserResult
+	if err := arg.validate(); err != nil {
+		return result, err
+	}
+
+	user, err := s.store.GetUser(ctx, arg.Username)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return result, newError(ErrNotFound, "user not found")
+		}
+		return result, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := util.CheckPassword(arg.Password, user.HashedPassword); err != nil {
+		return result, newError(ErrUnauthenticated, "password not match")
+	}
+
+	if user.IsBlocked {
+		return result, newError(ErrPermissionDenied, "user is blocked")
+	}
+
+	accessToken, accessPayload, err := s.tokenMaker.CreateToken(user.Username, s.config.AccessTokenDuration)
+	if err != nil {
+		return result, fmt.Errorf("failed to create token: %w", err)
+	}
+
+	refreshToken, refreshPayload, err := s.tokenMaker.CreateToken(user.Username, s.config.RefreshTokenDuration)
+	if err != nil {
+		return result, fmt.Errorf("failed to create token: %w", err)
+	}
+
+	session, err := s.store.CreateSession(ctx, db.CreateSessionParams{
+		ID:           refreshPayload.ID,
+		Username:     user.Username,
+		RefreshToken: refreshToken,
+		UserAgent:    arg.UserAgent,
+		ClientIp:     arg.ClientIP,
+		IsBlocked:    false,
+		ExpiresAt:    refreshPayload.ExpiredAt,
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return LoginUserResult{
+		User:                  user,
+		Session:               session,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessPayload.ExpiredAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshPayload.ExpiredAt,
+	}, nil
+}
+
+// AuthenticateUser verifies an access token and checks that its user is not
+// blocked, so that blocking a user takes effect on their next request rather
+// than when their access token expires. It costs one lookup by primary key.
+func (s *Service) AuthenticateUser(ctx context.Context, accessToken string) (*token.Payload, error) {
+	payload, err := s.tokenMaker.VerifyToken(accessToken)
+	if err != nil {
+		return nil, wrapError(ErrUnauthenticated, fmt.Errorf("invalid access token: %w", err))
+	}
+
+	user, err := s.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, newError(ErrUnauthenticated, "user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.IsBlocked {
+		return nil, newError(ErrPermissionDenied, "user is blocked")
+	}
+
+	return payload, nil
+}
+
+type RenewAccessTokenResult struct {
+	AccessToken          string
+	AccessTokenExpiresAt time.Time
+}
+
+// RenewAccessToken creates an access token from the refresh token of a
+// session that is neither blocked nor expired.
+func (s *Service) RenewAccessToken(ctx context.Context, refreshToken string) (RenewAccessTokenResult, error) {
+	var result RenewAccessTokenResult
+	if refreshToken == "" {
+		var v violations
+		v.add("refresh_token", errors.New("is required"))
+		return result, v.err()
+	}
+
+	payload, err := s.tokenMaker.VerifyToken(refreshToken)
+	if err != nil {
+		return result, wrapError(ErrUnauthenticated, err)
+	}
+
+	session, err := s.store.GetSession(ctx, payload.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return result, newError(ErrNotFound, "session not found")
+		}
+		return result, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	switch {
+	case session.IsBlocked:
+		return result, newError(ErrUnauthenticated, "blocked session")
+	case session.Username != payload.Username:
+		return result, newError(ErrUnauthenticated, "incorrect session user")
+	case session.RefreshToken != refreshToken:
+		return result, newError(ErrUnauthenticated, "mismatched session token")
+	case time.Now().After(session.ExpiresAt):
+		return result, newError(ErrUnauthenticated, "expired session")
+	}
+
+	accessToken, accessPayload, err := s.tokenMaker.CreateToken(payload.Username, s.config.AccessTokenDuration)
+	if err != nil {
+		return result, fmt.Errorf("failed to create token: %w", err)
+	}
+
+	return RenewAccessTokenResult{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: accessPayload.ExpiredAt,
+	}, nil
+}
+
+// ListSessions lists the login sessions of the user, most recent first.
+func (s *Service) ListSessions(ctx context.Context, username string, page Page) ([]db.Session, error) {
+	var v violations
+	page.validate(&v)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.store.ListUserSessions(ctx, db.ListUserSessionsParams{
+		Username: username,
+		Limit:    page.limit(),
+		Offset:   page.offset(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession blocks a session of the user, e.g. of a lost device, so that
+// it cannot renew access tokens any more.
+func (s *Service) RevokeSession(ctx context.Context, username string, sessionID string) (db.Session, error) {
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		var v violations
+		v.add("session_id", err)
+		return db.Session{}, v.err()
+	}
+
+	session, err := s.store.GetSession(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return db.Session{}, newError(ErrNotFound, "session not found")
+		}
+		return db.Session{}, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session.Username != username {
+		return db.Session{}, newError(ErrPermissionDenied, "session does not belong to the authenticated user")
+	}
+
+	result, err := s.store.RevokeSessionTx(ctx, db.RevokeSessionTxParams{
+		ID: session.ID,
+	})
+	if err != nil {
+		return db.Session{}, fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return result.Session, nil
+}
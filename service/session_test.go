@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	mock_sqlc "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc/mock"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginUser(t *testing.T) {
+	password := util.RandomString(6)
+	hashedPassword, err := util.HashPassword(password)
+	require.NoError(t, err)
+
+	user := db.User{
+		Username:       util.RandomOwner(),
+		HashedPassword: hashedPassword,
+	}
+	blockedUser := user
+	blockedUser.IsBlocked = true
+
+	testCases := []struct {
+		name       string
+		password   string
+		buildStubs func(store *mock_sqlc.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name:     "OK",
+			password: password,
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), user.Username).Times(1).Return(user, nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, "test-agent", arg.UserAgent)
+						return db.Session{ID: arg.ID, Username: arg.Username}, nil
+					})
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:     "NotFound",
+			password: password,
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), user.Username).Times(1).Return(db.User{}, db.ErrNotFound)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+		{
+			name:     "WrongPassword",
+			password: password + "x",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), user.Username).Times(1).Return(user, nil)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrUnauthenticated)
+			},
+		},
+		{
+			name:     "Blocked",
+			password: password,
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), user.Username).Times(1).Return(blockedUser, nil)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrPermissionDenied)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, store := newTestService(t)
+			tc.buildStubs(store)
+
+			result, err := service.LoginUser(context.Background(), LoginUserParams{
+				Username:  user.Username,
+				Password:  tc.password,
+				UserAgent: "test-agent",
+			})
+			tc.checkError(t, err)
+			if err == nil {
+				require.NotEmpty(t, result.AccessToken)
+				require.NotEmpty(t, result.RefreshToken)
+				require.Equal(t, result.Session.ID.String(), mustVerify(t, service, result.RefreshToken))
+			}
+		})
+	}
+}
+
+func TestRenewAccessToken(t *testing.T) {
+	service, store := newTestService(t)
+
+	username := util.RandomOwner()
+	refreshToken, payload, err := service.tokenMaker.CreateToken(username, time.Hour)
+	require.NoError(t, err)
+
+	session := db.Session{
+		ID:           payload.ID,
+		Username:     username,
+		RefreshToken: refreshToken,
+		ExpiresAt:    payload.ExpiredAt,
+	}
+	store.EXPECT().GetSession(gomock.Any(), payload.ID).Times(1).Return(session, nil)
+
+	result, err := service.RenewAccessToken(context.Background(), refreshToken)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.AccessToken)
+
+	session.IsBlocked = true
+	store.EXPECT().GetSession(gomock.Any(), payload.ID).Times(1).Return(session, nil)
+
+	_, err = service.RenewAccessToken(context.Background(), refreshToken)
+	require.ErrorIs(t, err, ErrUnauthenticated)
+}
+
+func TestRevokeSession(t *testing.T) {
+	service, store := newTestService(t)
+
+	session := db.Session{
+		ID:       uuid.New(),
+		Username: util.RandomOwner(),
+	}
+
+	_, err := service.RevokeSession(context.Background(), session.Username, "not-a-uuid")
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	store.EXPECT().GetSession(gomock.Any(), session.ID).Times(1).Return(session, nil)
+	store.EXPECT().RevokeSessionTx(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err = service.RevokeSession(context.Background(), util.RandomOwner(), session.ID.String())
+	require.ErrorIs(t, err, ErrPermissionDenied)
+
+	revoked := session
+	revoked.IsBlocked = true
+	store.EXPECT().GetSession(gomock.Any(), session.ID).Times(1).Return(session, nil)
+	store.EXPECT().
+		RevokeSessionTx(gomock.Any(), gomock.Eq(db.RevokeSessionTxParams{ID: session.ID})).
+		Times(1).
+		Return(db.RevokeSessionTxResult{Session: revoked}, nil)
+
+	result, err := service.RevokeSession(context.Background(), session.Username, session.ID.String())
+	require.NoError(t, err)
+	require.True(t, result.IsBlocked)
+}
+
+func mustVerify(t *testing.T, service *Service, token string) string {
+	payload, err := service.tokenMaker.VerifyToken(token)
+	require.NoError(t, err)
+	return payload.ID.String()
+}
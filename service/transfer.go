@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+)
+
+// ErrRecipientNotFound is returned whether the recipient does not exist, has
+// no account in the currency or cannot receive money.
+var ErrRecipientNotFound = newError(ErrNotFound, "recipient cannot receive transfers in this currency")
+
+// CreateTransferParams gives the recipient by exactly one of ToAccountID,
+// ToUsername, ToEmail or PayeeID. With a username or email, the account of
+// the recipient in the transfer currency is resolved server-side.
+type CreateTransferParams struct {
+	FromAccountID     int64
+	ToAccountID       int64
+	ToUsername        string
+	ToEmail           string
+	PayeeID           int64
+	Amount            int64
+	Currency          string
+	Description       string
+	ExternalReference *string
+	// JSON object, nil when not set
+	Metadata json.RawMessage
+}
+
+func (arg CreateTransferParams) validate() error {
+	var v violations
+	if err := val.ValidateID(arg.FromAccountID); err != nil {
+		v.add("from_account_id", err)
+	}
+
+	recipients := 0
+	for _, set := range []bool{arg.ToAccountID != 0, arg.ToUsername != "", arg.ToEmail != "", arg.PayeeID != 0} {
+		if set {
+			recipients++
+		}
+	}
+
+	switch {
+	case recipients != 1:
+		v.add("recipient", errors.New("exactly one of to_account_id, to_username, to_email or payee_id is required"))
+	case arg.ToAccountID != 0:
+		if err := val.ValidateID(arg.ToAccountID); err != nil {
+			v.add("to_account_id", err)
+		} else if arg.ToAccountID == arg.FromAccountID {
+			v.add("to_account_id", errors.New("must differ from from_account_id"))
+		}
+	case arg.ToUsername != "":
+		if err := val.ValidateUsername(arg.ToUsername); err != nil {
+			v.add("to_username", err)
+		}
+	case arg.ToEmail != "":
+		if err := val.ValidateEmail(arg.ToEmail); err != nil {
+			v.add("to_email", err)
+		}
+	case arg.PayeeID != 0:
+		if err := val.ValidateID(arg.PayeeID); err != nil {
+			v.add("payee_id", err)
+		}
+	}
+
+	if err := val.ValidateAmount(arg.Amount); err != nil {
+		v.add("amount", err)
+	}
+
+	if err := val.ValidateCurrency(arg.Currency); err != nil {
+		v.add("currency", err)
+	}
+
+	validateTransferDetails(&v, "", arg.Description, arg.ExternalReference, arg.Metadata)
+
+	return v.err()
+}
+
+// validateTransferDetails validates the optional details of a transfer, with
+// prefix prepended to the field names.
+func validateTransferDetails(v *violations, prefix string, description string, externalReference *string, metadata json.RawMessage) {
+	if err := val.ValidateDescription(description); err != nil {
+		v.add(prefix+"description", err)
+	}
+
+	if externalReference != nil {
+		if err := val.ValidateExternalReference(*externalReference); err != nil {
+			v.add(prefix+"external_reference", err)
+		}
+	}
+
+	if metadata != nil {
+		if err := val.ValidateMetadata(metadata); err != nil {
+			v.add(prefix+"metadata", err)
+		}
+	}
+}
+
+// CreateTransfer moves money from an account of the user to the recipient.
+func (s *Service) CreateTransfer(ctx context.Context, username string, arg CreateTransferParams) (db.TransferTxResult, error) {
+	var result db.TransferTxResult
+	if err := arg.validate(); err != nil {
+		return result, err
+	}
+
+	fromAccount, err := s.ActiveAccount(ctx, arg.FromAccountID, arg.Currency)
+	if err != nil {
+		return result, err
+	}
+
+	if fromAccount.Owner != username {
+		return result, newError(ErrPermissionDenied, "from account does not belong to the authenticated user")
+	}
+
+	toAccount, err := s.recipientAccount(ctx, username, arg)
+	if err != nil {
+		return result, err
+	}
+
+	if toAccount.ID == fromAccount.ID {
+		return result, newError(ErrInvalidArgument, "cannot transfer to the same account")
+	}
+
+	params := db.TransferTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        arg.Amount,
+		Description:   arg.Description,
+		ExternalReference: sql.NullString{
+			String: stringValue(arg.ExternalReference),
+			Valid:  arg.ExternalReference != nil,
+		},
+		Metadata: arg.Metadata,
+	}
+
+	result, err = s.store.TransferTx(ctx, params)
+	if err != nil {
+		return result, TransferTxError(err)
+	}
+
+	return result, nil
+}
+
+// recipientAccount resolves the account that receives the transfer, which
+// for a payee of the user is checked again at transfer time. A recipient given
+// by username or email that cannot receive the transfer gets the same
+// ErrNotFound whatever the reason, so that transfers cannot be used to find
+// out who is a customer.
+func (s *Service) recipientAccount(ctx context.Context, username string, arg CreateTransferParams) (db.Account, error) {
+	params := db.GetRecipientAccountParams{
+		Currency: arg.Currency,
+	}
+
+	switch {
+	case arg.ToAccountID != 0:
+		return s.ActiveAccount(ctx, arg.ToAccountID, arg.Currency)
+	case arg.ToUsername != "":
+		params.Username = sql.NullString{String: arg.ToUsername, Valid: true}
+	case arg.ToEmail != "":
+		params.Email = sql.NullString{String: arg.ToEmail, Valid: true}
+	case arg.PayeeID != 0:
+		payee, err := s.OwnedPayee(ctx, username, arg.PayeeID)
+		if err != nil {
+			return db.Account{}, err
+		}
+		if time.Now().Before(payee.AvailableAt) {
+			return db.Account{}, newError(ErrFailedPrecondition, "payee cannot receive transfers before %s", payee.AvailableAt.UTC().Format(time.RFC3339))
+		}
+		if payee.Currency != arg.Currency {
+			return db.Account{}, newError(ErrInvalidArgument, "payee currency mismatch: %s vs %s", payee.Currency, arg.Currency)
+		}
+		if payee.AccountID.Valid {
+			return s.ActiveAccount(ctx, payee.AccountID.Int64, arg.Currency)
+		}
+		params.Username = payee.Username
+	}
+
+	account, err := s.store.GetRecipientAccount(ctx, params)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return account, ErrRecipientNotFound
+		}
+		return account, fmt.Errorf("failed to get recipient account: %w", err)
+	}
+
+	return account, nil
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
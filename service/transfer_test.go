@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	mock_sqlc "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc/mock"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTransferToPayee(t *testing.T) {
+	username := util.RandomOwner()
+	fromAccount := db.Account{ID: 1, Owner: username, Currency: util.USD, Status: util.AccountStatusActive}
+	toAccount := db.Account{ID: 2, Owner: util.RandomOwner(), Currency: util.USD, Status: util.AccountStatusActive}
+
+	payee := db.Payee{
+		ID:          7,
+		Owner:       username,
+		Username:    sql.NullString{String: toAccount.Owner, Valid: true},
+		Currency:    util.USD,
+		AvailableAt: time.Now().Add(-time.Hour),
+	}
+	coolingOffPayee := payee
+	coolingOffPayee.AvailableAt = time.Now().Add(time.Hour)
+	otherPayee := payee
+	otherPayee.Owner = util.RandomOwner()
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mock_sqlc.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), fromAccount.ID).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetPayee(gomock.Any(), payee.ID).Times(1).Return(payee, nil)
+				store.EXPECT().
+					GetRecipientAccount(gomock.Any(), gomock.Eq(db.GetRecipientAccountParams{
+						Username: payee.Username,
+						Currency: util.USD,
+					})).
+					Times(1).
+					Return(toAccount, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+						FromAccountID: fromAccount.ID,
+						ToAccountID:   toAccount.ID,
+						Amount:        10,
+					})).
+					Times(1)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "CoolingOff",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), fromAccount.ID).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetPayee(gomock.Any(), payee.ID).Times(1).Return(coolingOffPayee, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrFailedPrecondition)
+			},
+		},
+		{
+			name: "PayeeOfOtherUser",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), fromAccount.ID).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetPayee(gomock.Any(), payee.ID).Times(1).Return(otherPayee, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrPermissionDenied)
+			},
+		},
+		{
+			name: "InsufficientFunds",
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), fromAccount.ID).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetPayee(gomock.Any(), payee.ID).Times(1).Return(payee, nil)
+				store.EXPECT().GetRecipientAccount(gomock.Any(), gomock.Any()).Times(1).Return(toAccount, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, db.ErrInsufficientFunds)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrFailedPrecondition)
+				require.ErrorIs(t, err, db.ErrInsufficientFunds)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, store := newTestService(t)
+			tc.buildStubs(store)
+
+			_, err := service.CreateTransfer(context.Background(), username, CreateTransferParams{
+				FromAccountID: fromAccount.ID,
+				PayeeID:       payee.ID,
+				Amount:        10,
+				Currency:      util.USD,
+			})
+			tc.checkError(t, err)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/NguyenMinhKhanhBK/simple_bank/val"
+)
+
+type CreateUserParams struct {
+	Username string
+	Password string
+	FullName string
+	Email    string
+}
+
+func (arg CreateUserParams) validate() error {
+	var v violations
+	if err := val.ValidateUsername(arg.Username); err != nil {
+		v.add("username", err)
+	}
+
+	if err := val.ValidatePassword(arg.Password); err != nil {
+		v.add("password", err)
+	}
+
+	if err := val.ValidateEmail(arg.Email); err != nil {
+		v.add("email", err)
+	}
+
+	if err := val.ValidateFullName(arg.FullName); err != nil {
+		v.add("full_name", err)
+	}
+
+	return v.err()
+}
+
+// CreateUser registers a customer. The verification email is sent by a
+// subscriber of the event the store publishes.
+func (s *Service) CreateUser(ctx context.Context, arg CreateUserParams) (db.User, error) {
+	if err := arg.validate(); err != nil {
+		return db.User{}, err
+	}
+
+	hashedPassword, err := util.HashPassword(arg.Password)
+	if err != nil {
+		return db.User{}, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	result, err := s.store.CreateUserTx(ctx, db.CreateUserTxParams{
+		CreateUserParams: db.CreateUserParams{
+			Username:       arg.Username,
+			HashedPassword: hashedPassword,
+			FullName:       arg.FullName,
+			Email:          arg.Email,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrUniqueViolation) {
+			return db.User{}, newError(ErrAlreadyExists, "username or email already exists")
+		}
+		return db.User{}, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return result.User, nil
+}
+
+// UpdateUserParams changes the fields that are not nil.
+type UpdateUserParams struct {
+	Username string
+	Password *string
+	FullName *string
+	Email    *string
+}
+
+func (arg UpdateUserParams) validate() error {
+	var v violations
+	if err := val.ValidateUsername(arg.Username); err != nil {
+		v.add("username", err)
+	}
+
+	if arg.Password != nil {
+		if err := val.ValidatePassword(*arg.Password); err != nil {
+			v.add("password", err)
+		}
+	}
+
+	if arg.Email != nil {
+		if err := val.ValidateEmail(*arg.Email); err != nil {
+			v.add("email", err)
+		}
+	}
+
+	if arg.FullName != nil {
+		if err := val.ValidateFullName(*arg.FullName); err != nil {
+			v.add("full_name", err)
+		}
+	}
+
+	return v.err()
+}
+
+// UpdateUser changes the profile of the authenticated user.
+func (s *Service) UpdateUser(ctx context.Context, username string, arg UpdateUserParams) (db.User, error) {
+	if err := arg.validate(); err != nil {
+		return db.User{}, err
+	}
+
+	if arg.Username != username {
+		return db.User{}, newError(ErrPermissionDenied, "cannot update other user's info")
+	}
+
+	params := db.UpdateUserParams{
+		Username: arg.Username,
+		FullName: nullString(arg.FullName),
+		Email:    nullString(arg.Email),
+	}
+
+	if arg.Password != nil {
+		hashedPassword, err := util.HashPassword(*arg.Password)
+		if err != nil {
+			return db.User{}, fmt.Errorf("failed to hash password: %w", err)
+		}
+
+		params.HashedPassword = sql.NullString{
+			String: hashedPassword,
+			Valid:  true,
+		}
+
+		params.PasswordChangedAt = sql.NullTime{
+			Time:  time.Now(),
+			Valid: true,
+		}
+	}
+
+	user, err := s.store.UpdateUser(ctx, params)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return db.User{}, newError(ErrNotFound, "user not found")
+		}
+		if errors.Is(err, db.ErrUniqueViolation) {
+			return db.User{}, newError(ErrAlreadyExists, "email already exists")
+		}
+		return db.User{}, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return user, nil
+}
+
+func nullString(value *string) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *value, Valid: true}
+}
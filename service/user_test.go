@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	mock_sqlc "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc/mock"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateUser(t *testing.T) {
+	arg := CreateUserParams{
+		Username: util.RandomOwner(),
+		Password: util.RandomString(6),
+		FullName: "John Doe",
+		Email:    util.RandomEmail(),
+	}
+
+	testCases := []struct {
+		name       string
+		arg        CreateUserParams
+		buildStubs func(store *mock_sqlc.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			arg:  arg,
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().
+					CreateUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, params db.CreateUserTxParams) (db.CreateUserTxResult, error) {
+						require.Equal(t, arg.Username, params.Username)
+						require.NoError(t, util.CheckPassword(arg.Password, params.HashedPassword))
+						return db.CreateUserTxResult{User: db.User{Username: params.Username}}, nil
+					})
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "AlreadyExists",
+			arg:  arg,
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().CreateUserTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CreateUserTxResult{}, &db.QueryError{Kind: db.ErrUniqueViolation, Constraint: "users_pkey"})
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrAlreadyExists)
+			},
+		},
+		{
+			name: "InternalError",
+			arg:  arg,
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().CreateUserTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CreateUserTxResult{}, sql.ErrConnDone)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+				require.NotErrorIs(t, err, ErrAlreadyExists)
+			},
+		},
+		{
+			name: "InvalidArguments",
+			arg: CreateUserParams{
+				Username: "Invalid-User",
+				Password: "123",
+				FullName: "John Doe",
+				Email:    "invalid",
+			},
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().CreateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidArgument)
+
+				var validationErr *ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				fields := make([]string, 0, len(validationErr.Violations))
+				for _, violation := range validationErr.Violations {
+					fields = append(fields, violation.Field)
+				}
+				require.Equal(t, []string{"username", "password", "email"}, fields)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, store := newTestService(t)
+			tc.buildStubs(store)
+
+			_, err := service.CreateUser(context.Background(), tc.arg)
+			tc.checkError(t, err)
+		})
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	username := util.RandomOwner()
+	email := util.RandomEmail()
+
+	testCases := []struct {
+		name       string
+		username   string
+		buildStubs func(store *mock_sqlc.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name:     "OK",
+			username: username,
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Eq(db.UpdateUserParams{
+						Username: username,
+						Email:    sql.NullString{String: email, Valid: true},
+					})).
+					Times(1).
+					Return(db.User{Username: username, Email: email}, nil)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:     "OtherUser",
+			username: util.RandomOwner(),
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrPermissionDenied)
+			},
+		},
+		{
+			name:     "NotFound",
+			username: username,
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, db.ErrNotFound)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+		{
+			name:     "EmailTaken",
+			username: username,
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, &db.QueryError{Kind: db.ErrUniqueViolation, Constraint: "users_pkey"})
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrAlreadyExists)
+			},
+		},
+		{
+			name:     "InternalError",
+			username: username,
+			buildStubs: func(store *mock_sqlc.MockStore) {
+				store.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, sql.ErrConnDone)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+				require.NotErrorIs(t, err, ErrAlreadyExists)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, store := newTestService(t)
+			tc.buildStubs(store)
+
+			_, err := service.UpdateUser(context.Background(), tc.username, UpdateUserParams{
+				Username: username,
+				Email:    &email,
+			})
+			tc.checkError(t, err)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// csvRenderer writes the summary as key/value rows, followed by one row per
+// entry. Rows are flushed as the csv writer buffer fills up.
+type csvRenderer struct {
+	writer *csv.Writer
+}
+
+func newCSVRenderer(w io.Writer) Renderer {
+	return &csvRenderer{writer: csv.NewWriter(w)}
+}
+
+func (r *csvRenderer) Begin(statement Statement) error {
+	records := [][]string{
+		{"account_id", strconv.FormatInt(statement.Account.ID, 10)},
+		{"owner", statement.Account.Owner},
+		{"currency", statement.Account.Currency},
+		{"period_start", statement.PeriodStart.UTC().Format(dateLayout)},
+		{"period_end", formatPeriodEnd(statement.PeriodEnd)},
+		{"opening_balance", strconv.FormatInt(statement.OpeningBalance, 10)},
+		{"closing_balance", strconv.FormatInt(statement.ClosingBalance, 10)},
+		{},
+		{"entry_id", "created_at", "transfer_id", "counterparty_account_id", "counterparty_owner", "amount", "balance"},
+	}
+	return r.writer.WriteAll(records)
+}
+
+func (r *csvRenderer) Line(line Line) error {
+	return r.writer.Write([]string{
+		strconv.FormatInt(line.EntryID, 10),
+		formatTime(line.CreatedAt),
+		formatID(line.TransferID),
+		formatID(line.CounterpartyAccountID),
+		line.CounterpartyOwner,
+		strconv.FormatInt(line.Amount, 10),
+		strconv.FormatInt(line.Balance, 10),
+	})
+}
+
+func (r *csvRenderer) End() error {
+	r.writer.Flush()
+	return r.writer.Error()
+}
@@ -0,0 +1,101 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/go-pdf/fpdf"
+)
+
+var pdfColumns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{title: "Entry", width: 20, align: "L"},
+	{title: "Date", width: 45, align: "L"},
+	{title: "Counterparty", width: 65, align: "L"},
+	{title: "Amount", width: 30, align: "R"},
+	{title: "Balance", width: 30, align: "R"},
+}
+
+// pdfRenderer lays out the statement as a table. fpdf builds the whole
+// document in memory, so it is only written out by End.
+type pdfRenderer struct {
+	w   io.Writer
+	pdf *fpdf.Fpdf
+	// set once the summary is written, from then on every page starts with
+	// the column titles
+	tableStarted bool
+}
+
+func newPDFRenderer(w io.Writer) Renderer {
+	return &pdfRenderer{w: w}
+}
+
+func (r *pdfRenderer) Begin(statement Statement) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("Statement of account #%d", statement.Account.ID), true)
+	pdf.SetHeaderFunc(r.tableHeader)
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 10, fmt.Sprintf("Page %d", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	r.pdf = pdf
+
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 10, "Account statement", "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	summary := [][2]string{
+		{"Account", fmt.Sprintf("#%d (%s)", statement.Account.ID, statement.Account.Currency)},
+		{"Owner", statement.Account.Owner},
+		{"Period", statement.PeriodStart.UTC().Format(dateLayout) + " to " + formatPeriodEnd(statement.PeriodEnd)},
+		{"Opening balance", strconv.FormatInt(statement.OpeningBalance, 10)},
+		{"Closing balance", strconv.FormatInt(statement.ClosingBalance, 10)},
+	}
+	for _, row := range summary {
+		pdf.CellFormat(40, 6, row[0], "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 6, row[1], "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	r.tableStarted = true
+	r.tableHeader()
+	return pdf.Error()
+}
+
+func (r *pdfRenderer) tableHeader() {
+	if !r.tableStarted {
+		return
+	}
+
+	r.pdf.SetFont("Helvetica", "B", 10)
+	for _, column := range pdfColumns {
+		r.pdf.CellFormat(column.width, 7, column.title, "B", 0, column.align, false, 0, "")
+	}
+	r.pdf.Ln(-1)
+	r.pdf.SetFont("Helvetica", "", 9)
+}
+
+func (r *pdfRenderer) Line(line Line) error {
+	values := []string{
+		strconv.FormatInt(line.EntryID, 10),
+		formatTime(line.CreatedAt),
+		formatCounterparty(line),
+		strconv.FormatInt(line.Amount, 10),
+		strconv.FormatInt(line.Balance, 10),
+	}
+	for i, column := range pdfColumns {
+		r.pdf.CellFormat(column.width, 6, values[i], "", 0, column.align, false, 0, "")
+	}
+	r.pdf.Ln(-1)
+	return r.pdf.Error()
+}
+
+func (r *pdfRenderer) End() error {
+	return r.pdf.Output(r.w)
+}
@@ -0,0 +1,46 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// NewRenderer returns a renderer writing the format to w.
+func NewRenderer(format string, w io.Writer) (Renderer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVRenderer(w), nil
+	case FormatPDF:
+		return newPDFRenderer(w), nil
+	default:
+		return nil, fmt.Errorf("unsupported statement format: %s", format)
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatPeriodEnd shows the exclusive end of the period as the last day it
+// includes.
+func formatPeriodEnd(periodEnd time.Time) string {
+	return periodEnd.UTC().Add(-time.Nanosecond).Format(dateLayout)
+}
+
+func formatCounterparty(line Line) string {
+	if line.CounterpartyAccountID == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s (#%d)", line.CounterpartyOwner, line.CounterpartyAccountID)
+}
+
+func formatID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
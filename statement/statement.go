@@ -0,0 +1,160 @@
+package statement
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+)
+
+const (
+	FormatCSV = "csv"
+	FormatPDF = "pdf"
+
+	ExportPending   = "pending"
+	ExportCompleted = "completed"
+	ExportFailed    = "failed"
+
+	// entries are read from the database in pages of this size so that long
+	// periods never have to be held in memory
+	entryPageSize = 500
+)
+
+// Statement is the summary of an account over a period. PeriodEnd is exclusive.
+type Statement struct {
+	Account        db.Account
+	PeriodStart    time.Time
+	PeriodEnd      time.Time
+	OpeningBalance int64
+	ClosingBalance int64
+}
+
+// Line is one entry of a statement with the balance right after it.
+type Line struct {
+	EntryID    int64
+	CreatedAt  time.Time
+	Amount     int64
+	Balance    int64
+	TransferID int64
+	// the other account of the transfer, zero for entries without a transfer
+	CounterpartyAccountID int64
+	CounterpartyOwner     string
+}
+
+// Renderer writes a statement. Begin is called once with the statement, Line
+// for every entry in order, then End.
+type Renderer interface {
+	Begin(statement Statement) error
+	Line(line Line) error
+	End() error
+}
+
+func IsSupportedFormat(format string) bool {
+	switch format {
+	case FormatCSV, FormatPDF:
+		return true
+	default:
+		return false
+	}
+}
+
+// ContentType returns the MIME type of the rendered format.
+func ContentType(format string) string {
+	switch format {
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "text/csv"
+	}
+}
+
+// FileName returns the name a statement is downloaded as, named after the
+// first and last day of the period.
+func FileName(accountID int64, periodStart time.Time, periodEnd time.Time, format string) string {
+	return fmt.Sprintf("statement_%d_%s_%s.%s",
+		accountID,
+		periodStart.UTC().Format("20060102"),
+		periodEnd.UTC().Add(-time.Nanosecond).Format("20060102"),
+		format,
+	)
+}
+
+type Generator struct {
+	store db.Querier
+}
+
+func NewGenerator(store db.Querier) *Generator {
+	return &Generator{store: store}
+}
+
+// Generate computes the statement of the account for the period and streams
+// it to the renderer page by page.
+func (g *Generator) Generate(ctx context.Context, account db.Account, periodStart time.Time, periodEnd time.Time, renderer Renderer) error {
+	balances, err := g.store.GetStatementBalances(ctx, db.GetStatementBalancesParams{
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		AccountID:   account.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get statement balances: %w", err)
+	}
+
+	err = renderer.Begin(Statement{
+		Account:        account,
+		PeriodStart:    periodStart,
+		PeriodEnd:      periodEnd,
+		OpeningBalance: balances.OpeningBalance,
+		ClosingBalance: balances.ClosingBalance,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render statement: %w", err)
+	}
+
+	balance := balances.OpeningBalance
+	var afterID int64
+	for {
+		entries, err := g.store.ListStatementEntries(ctx, db.ListStatementEntriesParams{
+			AccountID:   account.ID,
+			PeriodStart: periodStart,
+			PeriodEnd:   periodEnd,
+			AfterID:     afterID,
+			LastEntryID: balances.LastEntryID,
+			PageSize:    entryPageSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list statement entries: %w", err)
+		}
+
+		for _, entry := range entries {
+			afterID = entry.ID
+			balance += entry.Amount
+
+			err := renderer.Line(Line{
+				EntryID:               entry.ID,
+				CreatedAt:             entry.CreatedAt,
+				Amount:                entry.Amount,
+				Balance:               balance,
+				TransferID:            entry.TransferID.Int64,
+				CounterpartyAccountID: entry.CounterpartyAccountID,
+				CounterpartyOwner:     entry.CounterpartyOwner,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to render statement line: %w", err)
+			}
+		}
+
+		if len(entries) < entryPageSize {
+			break
+		}
+	}
+
+	if balance != balances.ClosingBalance {
+		return fmt.Errorf("statement entries do not add up to the closing balance: %d vs %d", balance, balances.ClosingBalance)
+	}
+
+	if err := renderer.End(); err != nil {
+		return fmt.Errorf("failed to render statement: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package statement
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	mock_sqlc "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc/mock"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	periodStart = time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd   = time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func buildStubs(store *mock_sqlc.MockStore, account db.Account, closingBalance int64) {
+	store.EXPECT().
+		GetStatementBalances(gomock.Any(), gomock.Eq(db.GetStatementBalancesParams{
+			PeriodStart: periodStart,
+			PeriodEnd:   periodEnd,
+			AccountID:   account.ID,
+		})).
+		Times(1).
+		Return(db.GetStatementBalancesRow{
+			OpeningBalance: 100,
+			ClosingBalance: closingBalance,
+			LastEntryID:    12,
+		}, nil)
+
+	store.EXPECT().
+		ListStatementEntries(gomock.Any(), gomock.Eq(db.ListStatementEntriesParams{
+			AccountID:   account.ID,
+			PeriodStart: periodStart,
+			PeriodEnd:   periodEnd,
+			AfterID:     0,
+			LastEntryID: 12,
+			PageSize:    entryPageSize,
+		})).
+		Times(1).
+		Return([]db.ListStatementEntriesRow{
+			{
+				ID:                    10,
+				Amount:                -30,
+				CreatedAt:             periodStart.Add(time.Hour),
+				TransferID:            sql.NullInt64{Int64: 5, Valid: true},
+				CounterpartyAccountID: 7,
+				CounterpartyOwner:     "bob",
+			},
+			{
+				ID:        12,
+				Amount:    50,
+				CreatedAt: periodStart.Add(2 * time.Hour),
+			},
+		}, nil)
+}
+
+func TestGenerateCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	account := db.Account{ID: 1, Owner: "alice", Currency: util.USD}
+	store := mock_sqlc.NewMockStore(ctrl)
+	buildStubs(store, account, 120)
+
+	var buf bytes.Buffer
+	renderer, err := NewRenderer(FormatCSV, &buf)
+	require.NoError(t, err)
+
+	err = NewGenerator(store).Generate(context.Background(), account, periodStart, periodEnd, renderer)
+	require.NoError(t, err)
+
+	expected := `account_id,1
+owner,alice
+currency,USD
+period_start,2022-03-01
+period_end,2022-03-31
+opening_balance,100
+closing_balance,120
+
+entry_id,created_at,transfer_id,counterparty_account_id,counterparty_owner,amount,balance
+10,2022-03-01T01:00:00Z,5,7,bob,-30,70
+12,2022-03-01T02:00:00Z,,,,50,120
+`
+	require.Equal(t, expected, buf.String())
+}
+
+func TestGeneratePDF(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	account := db.Account{ID: 1, Owner: "alice", Currency: util.USD}
+	store := mock_sqlc.NewMockStore(ctrl)
+	buildStubs(store, account, 120)
+
+	var buf bytes.Buffer
+	renderer, err := NewRenderer(FormatPDF, &buf)
+	require.NoError(t, err)
+
+	err = NewGenerator(store).Generate(context.Background(), account, periodStart, periodEnd, renderer)
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
+
+func TestGenerateBalanceMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	account := db.Account{ID: 1, Owner: "alice", Currency: util.USD}
+	store := mock_sqlc.NewMockStore(ctrl)
+	buildStubs(store, account, 999)
+
+	renderer, err := NewRenderer(FormatCSV, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	err = NewGenerator(store).Generate(context.Background(), account, periodStart, periodEnd, renderer)
+	require.Error(t, err)
+}
+
+func TestNewRendererUnsupportedFormat(t *testing.T) {
+	_, err := NewRenderer("xlsx", &bytes.Buffer{})
+	require.Error(t, err)
+}
@@ -0,0 +1,157 @@
+// Command errwrap generates a Querier that translates the errors of the
+// queries generated by sqlc into the typed errors of the db package.
+//
+// Usage, from db/sqlc:
+//
+//	go run ../../tools/errwrap -o querier_errors.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	input := flag.String("i", "querier.go", "file declaring the Querier interface")
+	output := flag.String("o", "querier_errors.go", "file to generate")
+	flag.Parse()
+
+	src, err := generate(*input)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(input string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, input, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	querier := findInterface(file, "Querier")
+	if querier == nil {
+		return nil, fmt.Errorf("no Querier interface in %s", input)
+	}
+
+	var body bytes.Buffer
+	for _, method := range querier.Methods.List {
+		fn, ok := method.Type.(*ast.FuncType)
+		if !ok || len(method.Names) != 1 {
+			return nil, fmt.Errorf("unsupported method in Querier")
+		}
+		if err := writeMethod(&body, fset, method.Names[0].Name, fn); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by errwrap from %s. DO NOT EDIT.\n\n", input)
+	fmt.Fprintf(&out, "package %s\n\n", file.Name.Name)
+
+	// The standard library comes first, as goimports would group it.
+	var std, other []string
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if !bytes.Contains(body.Bytes(), []byte(name+".")) {
+			continue
+		}
+
+		if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+			other = append(other, spec.Path.Value)
+		} else {
+			std = append(std, spec.Path.Value)
+		}
+	}
+
+	out.WriteString("import (\n")
+	for _, path := range std {
+		fmt.Fprintf(&out, "\t%s\n", path)
+	}
+	if len(std) > 0 && len(other) > 0 {
+		out.WriteString("\n")
+	}
+	for _, path := range other {
+		fmt.Fprintf(&out, "\t%s\n", path)
+	}
+	out.WriteString(")\n\n")
+
+	out.WriteString("// errorQuerier runs the queries and translates their errors with\n")
+	out.WriteString("// translateError.\n")
+	out.WriteString("type errorQuerier struct {\n\tq *Queries\n}\n\n")
+	out.WriteString("var _ Querier = errorQuerier{}\n")
+	out.Write(body.Bytes())
+
+	return format.Source(out.Bytes())
+}
+
+func findInterface(file *ast.File, name string) *ast.InterfaceType {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if iface, ok := typeSpec.Type.(*ast.InterfaceType); ok && typeSpec.Name.Name == name {
+				return iface
+			}
+		}
+	}
+	return nil
+}
+
+func writeMethod(w *bytes.Buffer, fset *token.FileSet, name string, fn *ast.FuncType) error {
+	var params, args []string
+	for _, field := range fn.Params.List {
+		typ := render(fset, field.Type)
+		for _, paramName := range field.Names {
+			params = append(params, paramName.Name+" "+typ)
+			args = append(args, paramName.Name)
+		}
+	}
+
+	var results []string
+	if fn.Results != nil {
+		for _, field := range fn.Results.List {
+			results = append(results, render(fset, field.Type))
+		}
+	}
+
+	call := fmt.Sprintf("w.q.%s(%s)", name, strings.Join(args, ", "))
+	fmt.Fprintf(w, "\nfunc (w errorQuerier) %s(%s) ", name, strings.Join(params, ", "))
+
+	switch {
+	case len(results) == 1 && results[0] == "error":
+		fmt.Fprintf(w, "error {\n\treturn translateError(%s)\n}\n", call)
+	case len(results) == 2 && results[1] == "error":
+		fmt.Fprintf(w, "(%s, error) {\n\tr, err := %s\n\treturn r, translateError(err)\n}\n", results[0], call)
+	default:
+		return fmt.Errorf("method %s does not return an error last", name)
+	}
+	return nil
+}
+
+func render(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, node)
+	return buf.String()
+}
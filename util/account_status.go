@@ -0,0 +1,51 @@
+package util
+
+const (
+	AccountStatusActive = "active"
+	AccountStatusFrozen = "frozen"
+	AccountStatusClosed = "closed"
+)
+
+const (
+	DepositorRole = "depositor"
+	AdminRole     = "admin"
+)
+
+type accountStatusTransition struct {
+	from string
+	to   string
+}
+
+// accountStatusTransitions lists the allowed status changes, mapped to whether
+// the owner of the account may make them. Admins may make all of them.
+var accountStatusTransitions = map[accountStatusTransition]bool{
+	{from: AccountStatusActive, to: AccountStatusFrozen}: false,
+	{from: AccountStatusFrozen, to: AccountStatusActive}: false,
+	{from: AccountStatusActive, to: AccountStatusClosed}: true,
+	{from: AccountStatusFrozen, to: AccountStatusClosed}: false,
+	{from: AccountStatusClosed, to: AccountStatusActive}: true,
+}
+
+func IsSupportedAccountStatus(status string) bool {
+	switch status {
+	case AccountStatusActive, AccountStatusFrozen, AccountStatusClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidAccountStatusTransition(from string, to string) bool {
+	_, ok := accountStatusTransitions[accountStatusTransition{from: from, to: to}]
+	return ok
+}
+
+// CanChangeAccountStatus reports whether a user with the role may move an
+// account they own from one status to the other.
+func CanChangeAccountStatus(role string, from string, to string) bool {
+	ownerAllowed, ok := accountStatusTransitions[accountStatusTransition{from: from, to: to}]
+	if !ok {
+		return false
+	}
+	return role == AdminRole || ownerAllowed
+}
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountStatusTransitions(t *testing.T) {
+	assert.True(t, IsValidAccountStatusTransition(AccountStatusActive, AccountStatusFrozen))
+	assert.True(t, IsValidAccountStatusTransition(AccountStatusClosed, AccountStatusActive))
+	assert.False(t, IsValidAccountStatusTransition(AccountStatusClosed, AccountStatusFrozen))
+	assert.False(t, IsValidAccountStatusTransition(AccountStatusActive, AccountStatusActive))
+
+	// owners can close and reopen their accounts but not freeze them
+	assert.True(t, CanChangeAccountStatus(DepositorRole, AccountStatusActive, AccountStatusClosed))
+	assert.True(t, CanChangeAccountStatus(DepositorRole, AccountStatusClosed, AccountStatusActive))
+	assert.False(t, CanChangeAccountStatus(DepositorRole, AccountStatusActive, AccountStatusFrozen))
+	assert.False(t, CanChangeAccountStatus(DepositorRole, AccountStatusFrozen, AccountStatusActive))
+	assert.False(t, CanChangeAccountStatus(DepositorRole, AccountStatusFrozen, AccountStatusClosed))
+
+	assert.True(t, CanChangeAccountStatus(AdminRole, AccountStatusActive, AccountStatusFrozen))
+	assert.True(t, CanChangeAccountStatus(AdminRole, AccountStatusFrozen, AccountStatusClosed))
+	assert.False(t, CanChangeAccountStatus(AdminRole, AccountStatusClosed, AccountStatusFrozen))
+}
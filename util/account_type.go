@@ -0,0 +1,19 @@
+package util
+
+const (
+	AccountTypeChecking = "checking"
+	AccountTypeSavings  = "savings"
+)
+
+// SystemUsername owns the internal accounts that fund interest payments. It is
+// not a valid username so no customer can register it.
+const SystemUsername = "$system"
+
+func IsSupportedAccountType(accountType string) bool {
+	switch accountType {
+	case AccountTypeChecking, AccountTypeSavings:
+		return true
+	default:
+		return false
+	}
+}
@@ -7,15 +7,42 @@ import (
 )
 
 type Config struct {
-	DBDriver             string        `mapstructure:"DB_DRIVER"`
-	DBSource             string        `mapstructure:"DB_SOURCE"`
-	MigrationURL         string        `mapstructure:"MIGRATION_URL"`
-	HTTPServerAddress    string        `mapstructure:"HTTP_SERVER_ADDRESS"`
-	GRPCServerAddress    string        `mapstructure:"GRPC_SERVER_ADDRESS"`
-	RedisAddress         string        `mapstructure:"REDIS_ADDRESS"`
-	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	DBSource              string        `mapstructure:"DB_SOURCE"`
+	MigrationURL          string        `mapstructure:"MIGRATION_URL"`
+	HTTPServerAddress     string        `mapstructure:"HTTP_SERVER_ADDRESS"`
+	GRPCServerAddress     string        `mapstructure:"GRPC_SERVER_ADDRESS"`
+	RedisAddress          string        `mapstructure:"REDIS_ADDRESS"`
+	TokenSymmetricKey     string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	AccessTokenDuration   time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration  time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	TracingExporter       string        `mapstructure:"TRACING_EXPORTER"`
+	OTLPEndpoint          string        `mapstructure:"OTLP_ENDPOINT"`
+	GRPCShutdownTimeout   time.Duration `mapstructure:"GRPC_SHUTDOWN_TIMEOUT"`
+	HTTPShutdownTimeout   time.Duration `mapstructure:"HTTP_SHUTDOWN_TIMEOUT"`
+	TaskShutdownTimeout   time.Duration `mapstructure:"TASK_SHUTDOWN_TIMEOUT"`
+	HealthCheckInterval   time.Duration `mapstructure:"HEALTH_CHECK_INTERVAL"`
+	EventRelayInterval    time.Duration `mapstructure:"EVENT_RELAY_INTERVAL"`
+	InterestAccrualCron   string        `mapstructure:"INTEREST_ACCRUAL_CRON"`
+	InterestPostingCron   string        `mapstructure:"INTEREST_POSTING_CRON"`
+	ScheduledTransferCron string        `mapstructure:"SCHEDULED_TRANSFER_CRON"`
+	HoldExpiryCron        string        `mapstructure:"HOLD_EXPIRY_CRON"`
+	PayeeCoolingOffPeriod time.Duration `mapstructure:"PAYEE_COOLING_OFF_PERIOD"`
+	WebhookTimeout        time.Duration `mapstructure:"WEBHOOK_TIMEOUT"`
+	WebhookMaxAttempts    int           `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+
+	// database pool, zero keeps the default of pgxpool
+	DBMaxConns        int32         `mapstructure:"DB_MAX_CONNS"`
+	DBMinConns        int32         `mapstructure:"DB_MIN_CONNS"`
+	DBMaxConnLifetime time.Duration `mapstructure:"DB_MAX_CONN_LIFETIME"`
+	DBMaxConnIdleTime time.Duration `mapstructure:"DB_MAX_CONN_IDLE_TIME"`
+	// cache_statement, cache_describe, describe_exec, exec or simple_protocol
+	DBQueryExecMode          string `mapstructure:"DB_QUERY_EXEC_MODE"`
+	DBStatementCacheCapacity int    `mapstructure:"DB_STATEMENT_CACHE_CAPACITY"`
+
+	// read replica, none when the source is empty
+	DBReplicaSource           string        `mapstructure:"DB_REPLICA_SOURCE"`
+	DBReplicaMaxLag           time.Duration `mapstructure:"DB_REPLICA_MAX_LAG"`
+	DBReplicaLagCheckInterval time.Duration `mapstructure:"DB_REPLICA_LAG_CHECK_INTERVAL"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
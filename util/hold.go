@@ -0,0 +1,8 @@
+package util
+
+const (
+	HoldStatusHeld     = "held"
+	HoldStatusCaptured = "captured"
+	HoldStatusReleased = "released"
+	HoldStatusExpired  = "expired"
+)
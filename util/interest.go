@@ -0,0 +1,49 @@
+package util
+
+import (
+	"math/big"
+	"time"
+)
+
+const (
+	// InterestScale is the number of interest units in one minor currency unit.
+	// Accruals are kept at this precision so that daily truncation does not
+	// lose money on small balances.
+	InterestScale = 1_000_000
+
+	basisPoints = 10_000
+)
+
+// DaysInYear returns 366 for leap years and 365 otherwise.
+func DaysInYear(year int) int {
+	if time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay() == 366 {
+		return 366
+	}
+	return 365
+}
+
+// DailyInterest returns the interest earned by the balance over the given day
+// at the annual rate, in interest units. The day count convention is
+// actual/actual, and the result is truncated toward zero so the bank never
+// pays a fraction of a unit that was not earned. Non-positive balances and
+// rates earn nothing.
+func DailyInterest(balance int64, annualRateBps int32, day time.Time) int64 {
+	if balance <= 0 || annualRateBps <= 0 {
+		return 0
+	}
+
+	numerator := new(big.Int).Mul(big.NewInt(balance), big.NewInt(int64(annualRateBps)))
+	numerator.Mul(numerator, big.NewInt(InterestScale))
+	denominator := big.NewInt(int64(basisPoints * DaysInYear(day.Year())))
+
+	return numerator.Quo(numerator, denominator).Int64()
+}
+
+// SplitAccruedInterest splits accrued interest units into whole minor currency
+// units that can be posted and the remainder carried over to the next period.
+func SplitAccruedInterest(accrued int64) (posted int64, remainder int64) {
+	if accrued <= 0 {
+		return 0, accrued
+	}
+	return accrued / InterestScale, accrued % InterestScale
+}
@@ -0,0 +1,58 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDaysInYear(t *testing.T) {
+	assert.Equal(t, 365, DaysInYear(2022))
+	assert.Equal(t, 366, DaysInYear(2024))
+	assert.Equal(t, 365, DaysInYear(2100))
+	assert.Equal(t, 366, DaysInYear(2000))
+}
+
+func TestDailyInterest(t *testing.T) {
+	day := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+	leapDay := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		name     string
+		balance  int64
+		rateBps  int32
+		day      time.Time
+		expected int64
+	}{
+		// 100000 * 200 * 1e6 / (10000 * 365) = 5479452.05...
+		{name: "Truncated", balance: 100000, rateBps: 200, day: day, expected: 5479452},
+		// 100000 * 200 * 1e6 / (10000 * 366) = 5464480.87...
+		{name: "LeapYear", balance: 100000, rateBps: 200, day: leapDay, expected: 5464480},
+		{name: "Exact", balance: 365, rateBps: 10000, day: day, expected: 1000000},
+		{name: "ZeroBalance", balance: 0, rateBps: 200, day: day, expected: 0},
+		{name: "NegativeBalance", balance: -100000, rateBps: 200, day: day, expected: 0},
+		{name: "ZeroRate", balance: 100000, rateBps: 0, day: day, expected: 0},
+		{name: "LargeBalance", balance: 1 << 50, rateBps: 500, day: day, expected: 154232863951044383},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, DailyInterest(tc.balance, tc.rateBps, tc.day))
+		})
+	}
+}
+
+func TestSplitAccruedInterest(t *testing.T) {
+	posted, remainder := SplitAccruedInterest(2*InterestScale + 345)
+	assert.Equal(t, int64(2), posted)
+	assert.Equal(t, int64(345), remainder)
+
+	posted, remainder = SplitAccruedInterest(InterestScale - 1)
+	assert.Equal(t, int64(0), posted)
+	assert.Equal(t, int64(InterestScale-1), remainder)
+
+	posted, remainder = SplitAccruedInterest(0)
+	assert.Equal(t, int64(0), posted)
+	assert.Equal(t, int64(0), remainder)
+}
@@ -0,0 +1,28 @@
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	ScheduledTransferActive    = "active"
+	ScheduledTransferSuspended = "suspended"
+	ScheduledTransferCompleted = "completed"
+	ScheduledTransferCancelled = "cancelled"
+
+	ExecutionSucceeded = "succeeded"
+	ExecutionFailed    = "failed"
+)
+
+// NextRecurrence returns the first time strictly after the given time that
+// matches the recurrence, a standard 5-field cron expression evaluated in UTC.
+func NextRecurrence(recurrence string, after time.Time) (time.Time, error) {
+	schedule, err := cron.ParseStandard(recurrence)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid recurrence: %w", err)
+	}
+	return schedule.Next(after.UTC()), nil
+}
@@ -0,0 +1,36 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextRecurrence(t *testing.T) {
+	after := time.Date(2022, time.March, 15, 10, 30, 0, 0, time.UTC)
+
+	testcases := []struct {
+		name       string
+		recurrence string
+		expected   time.Time
+	}{
+		{name: "Daily", recurrence: "0 9 * * *", expected: time.Date(2022, time.March, 16, 9, 0, 0, 0, time.UTC)},
+		{name: "SameDay", recurrence: "0 12 * * *", expected: time.Date(2022, time.March, 15, 12, 0, 0, 0, time.UTC)},
+		{name: "Monthly", recurrence: "0 0 1 * *", expected: time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "Descriptor", recurrence: "@weekly", expected: time.Date(2022, time.March, 20, 0, 0, 0, 0, time.UTC)},
+		// the reference time itself is never returned
+		{name: "StrictlyAfter", recurrence: "30 10 * * *", expected: time.Date(2022, time.March, 16, 10, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			next, err := NextRecurrence(tc.recurrence, after)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, next)
+		})
+	}
+
+	_, err := NextRecurrence("every day", after)
+	require.Error(t, err)
+}
@@ -0,0 +1,19 @@
+package util
+
+const (
+	WebhookEventTransferCompleted = "transfer.completed"
+	WebhookEventAccountCreated    = "account.created"
+
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryDead      = "dead"
+)
+
+func IsSupportedWebhookEventType(eventType string) bool {
+	switch eventType {
+	case WebhookEventTransferCompleted, WebhookEventAccountCreated:
+		return true
+	default:
+		return false
+	}
+}
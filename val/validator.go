@@ -1,14 +1,29 @@
 package val
 
 import (
+	"encoding/json"
 	"fmt"
+	"net"
 	"net/mail"
+	"net/url"
 	"regexp"
+	"strings"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/NguyenMinhKhanhBK/simple_bank/webhook"
+	"github.com/robfig/cron/v3"
 )
 
 var (
-	isValidUser     = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
-	isValidFullName = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	isValidUser              = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
+	isValidFullName          = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	isValidExternalReference = regexp.MustCompile(`^[a-zA-Z0-9._:/-]+$`).MatchString
+)
+
+const (
+	// maximum size of the metadata of a transfer, in bytes of JSON
+	maxMetadataSize = 4096
+	maxMetadataKeys = 50
 )
 
 func ValidateString(value string, minLength int, maxLength int) error {
@@ -55,3 +70,118 @@ func ValidateEmail(value string) error {
 	}
 	return nil
 }
+
+func ValidateID(value int64) error {
+	if value < 1 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateAmount(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+	return nil
+}
+
+func ValidateCurrency(value string) error {
+	if !util.IsSupportedCurrency(value) {
+		return fmt.Errorf("is not a supported currency")
+	}
+	return nil
+}
+
+func ValidateAccountType(value string) error {
+	if !util.IsSupportedAccountType(value) {
+		return fmt.Errorf("is not a supported account type")
+	}
+	return nil
+}
+
+func ValidateAccountStatus(value string) error {
+	if !util.IsSupportedAccountStatus(value) {
+		return fmt.Errorf("is not a supported account status")
+	}
+	return nil
+}
+
+func ValidateReason(value string) error {
+	return ValidateString(value, 3, 500)
+}
+
+func ValidateRecurrence(value string) error {
+	if _, err := cron.ParseStandard(value); err != nil {
+		return fmt.Errorf("is not a valid cron expression: %w", err)
+	}
+	return nil
+}
+
+func ValidateNickname(value string) error {
+	return ValidateString(value, 1, 50)
+}
+
+func ValidateDescription(value string) error {
+	return ValidateString(value, 0, 200)
+}
+
+func ValidateExternalReference(value string) error {
+	if err := ValidateString(value, 1, 64); err != nil {
+		return err
+	}
+
+	if !isValidExternalReference(value) {
+		return fmt.Errorf("must contain only letters, digits or . _ : / -")
+	}
+	return nil
+}
+
+// ValidateMetadata checks that value is a JSON object of limited size.
+func ValidateMetadata(value []byte) error {
+	if len(value) > maxMetadataSize {
+		return fmt.Errorf("must not be larger than %d bytes", maxMetadataSize)
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(value, &object); err != nil || object == nil {
+		return fmt.Errorf("must be a JSON object")
+	}
+
+	if len(object) > maxMetadataKeys {
+		return fmt.Errorf("must not have more than %d keys", maxMetadataKeys)
+	}
+	for key := range object {
+		if err := ValidateString(key, 1, 40); err != nil {
+			return fmt.Errorf("key %q %w", key, err)
+		}
+	}
+	return nil
+}
+
+func ValidateWebhookURL(value string) error {
+	if err := ValidateString(value, 1, 2000); err != nil {
+		return err
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("must be an absolute http or https URL")
+	}
+
+	// host names are checked once a delivery resolves them
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("must not point to an internal address")
+	}
+	if ip := net.ParseIP(host); ip != nil && !webhook.IsPublicIP(ip) {
+		return fmt.Errorf("must not point to an internal address")
+	}
+	return nil
+}
+
+func ValidateWebhookEventType(value string) error {
+	if !util.IsSupportedWebhookEventType(value) {
+		return fmt.Errorf("is not a supported event type")
+	}
+	return nil
+}
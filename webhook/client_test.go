@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	testCases := []struct {
+		ip     string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"100.64.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"64:ff9b::a9fe:a9fe", false},
+		{"224.0.0.1", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.ip, func(t *testing.T) {
+			ip := net.ParseIP(tc.ip)
+			require.NotNil(t, ip)
+			assert.Equal(t, tc.public, IsPublicIP(ip))
+		})
+	}
+}
+
+func TestNewClientRejectsInternalAddresses(t *testing.T) {
+	called := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		called = true
+	}))
+	defer receiver.Close()
+
+	_, err := Send(context.Background(), NewClient(time.Second), Delivery{
+		ID:        1,
+		URL:       receiver.URL,
+		Secret:    "secret",
+		EventType: util.WebhookEventAccountCreated,
+		Payload:   []byte(`{}`),
+	}, time.Now())
+	assert.ErrorIs(t, err, ErrAddressNotAllowed)
+	assert.False(t, called)
+}
+
+func TestNewClientDoesNotFollowRedirects(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		t.Error("redirect was followed")
+	}))
+	defer target.Close()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Redirect(w, req, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer receiver.Close()
+
+	// the test servers listen on loopback, so only the redirect policy of the
+	// client is used
+	client := NewClient(time.Second)
+	client.Transport = receiver.Client().Transport
+
+	statusCode, err := Send(context.Background(), client, Delivery{
+		ID:        1,
+		URL:       receiver.URL,
+		Secret:    "secret",
+		EventType: util.WebhookEventAccountCreated,
+		Payload:   []byte(`{}`),
+	}, time.Now())
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusTemporaryRedirect, statusCode)
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+)
+
+// Event is the body of a delivery.
+type Event struct {
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// TransferData is the data of a transfer.completed event.
+type TransferData struct {
+	ID                int64           `json:"id"`
+	FromAccountID     int64           `json:"from_account_id"`
+	ToAccountID       int64           `json:"to_account_id"`
+	Amount            int64           `json:"amount"`
+	Description       string          `json:"description"`
+	ExternalReference string          `json:"external_reference,omitempty"`
+	Metadata          json.RawMessage `json:"metadata"`
+	ReversalOfID      int64           `json:"reversal_of_id,omitempty"`
+	CreatedAt         time.Time       `json:"created_at"`
+}
+
+func NewTransferData(event events.TransferCompleted) TransferData {
+	return TransferData{
+		ID:                event.TransferID,
+		FromAccountID:     event.FromAccountID,
+		ToAccountID:       event.ToAccountID,
+		Amount:            event.Amount,
+		Description:       event.Description,
+		ExternalReference: event.ExternalReference,
+		Metadata:          event.Metadata,
+		ReversalOfID:      event.ReversalOfID,
+		CreatedAt:         event.CreatedAt,
+	}
+}
+
+// AccountData is the data of an account.created event.
+type AccountData struct {
+	ID          int64     `json:"id"`
+	Owner       string    `json:"owner"`
+	Currency    string    `json:"currency"`
+	AccountType string    `json:"account_type"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewAccountData(event events.AccountCreated) AccountData {
+	return AccountData{
+		ID:          event.AccountID,
+		Owner:       event.Owner,
+		Currency:    event.Currency,
+		AccountType: event.AccountType,
+		CreatedAt:   event.CreatedAt,
+	}
+}
@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderEvent      = "X-Webhook-Event"
+	HeaderDeliveryID = "X-Webhook-Delivery-Id"
+	HeaderTimestamp  = "X-Webhook-Timestamp"
+	HeaderSignature  = "X-Webhook-Signature"
+)
+
+// NewSecret returns a random secret to sign the deliveries of a subscription.
+func NewSecret() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(key), nil
+}
+
+// Sign returns the signature of a delivery: the hex HMAC-SHA256, keyed with
+// the secret of the subscription, of the timestamp, a dot and the body.
+// Receivers should recompute it and reject old timestamps to prevent replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a delivery in constant time.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Delivery is one attempt to post an event to a subscription.
+type Delivery struct {
+	ID        int64
+	URL       string
+	Secret    string
+	EventType string
+	Payload   []byte
+}
+
+// Send posts the delivery and returns the status code of the response. Any
+// response other than 2xx is an error. The body of the response is never
+// read, so that nothing the receiver returns ends up in the delivery.
+func Send(ctx context.Context, client *http.Client, delivery Delivery, now time.Time) (statusCode int, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDeliveryID, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	rsp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return rsp.StatusCode, fmt.Errorf("receiver responded %s", rsp.Status)
+	}
+	return rsp.StatusCode, nil
+}
+
+// RetryDelay is the exponential backoff between the attempts of a delivery:
+// 10s after the first failure, doubling up to an hour.
+func RetryDelay(retries int) time.Duration {
+	delay := 10 * time.Second
+	for i := 0; i < retries && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > time.Hour {
+		delay = time.Hour
+	}
+	return delay
+}
@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	delivery := Delivery{
+		ID:        7,
+		Secret:    "secret",
+		EventType: util.WebhookEventTransferCompleted,
+		Payload:   []byte(`{"id":1}`),
+	}
+	now := time.Unix(1650000000, 0)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, delivery.Payload, body)
+		assert.Equal(t, util.WebhookEventTransferCompleted, req.Header.Get(HeaderEvent))
+		assert.Equal(t, "7", req.Header.Get(HeaderDeliveryID))
+
+		timestamp, err := strconv.ParseInt(req.Header.Get(HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, now.Unix(), timestamp)
+		assert.True(t, Verify("secret", timestamp, body, req.Header.Get(HeaderSignature)))
+		assert.False(t, Verify("other", timestamp, body, req.Header.Get(HeaderSignature)))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	delivery.URL = receiver.URL
+	statusCode, err := Send(context.Background(), receiver.Client(), delivery, now)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, statusCode)
+}
+
+func TestSendFailure(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "try later", http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	statusCode, err := Send(context.Background(), receiver.Client(), Delivery{
+		ID:        1,
+		URL:       receiver.URL,
+		Secret:    "secret",
+		EventType: util.WebhookEventAccountCreated,
+		Payload:   []byte(`{}`),
+	}, time.Now())
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "try later")
+	assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 10*time.Second, RetryDelay(0))
+	assert.Equal(t, 20*time.Second, RetryDelay(1))
+	assert.Equal(t, 80*time.Second, RetryDelay(3))
+	assert.Equal(t, time.Hour, RetryDelay(20))
+}
+
+func TestNewSecret(t *testing.T) {
+	secret1, err := NewSecret()
+	require.NoError(t, err)
+	secret2, err := NewSecret()
+	require.NoError(t, err)
+	assert.NotEqual(t, secret1, secret2)
+	assert.Len(t, secret1, len("whsec_")+64)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/NguyenMinhKhanhBK/simple_bank/worker"
+)
+
+func runTaskProcessor(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	taskProcessor worker.TaskProcessor,
+) error {
+	logrus.Info("start task processor")
+	if err := taskProcessor.Start(); err != nil {
+		return fmt.Errorf("failed to start task processor: %w", err)
+	}
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		logrus.Info("graceful shutdown task processor")
+
+		taskProcessor.Shutdown()
+		logrus.Info("task processor is stopped")
+		return nil
+	})
+	return nil
+}
+
+func runTaskScheduler(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	config util.Config,
+	redisOpt asynq.RedisClientOpt,
+) error {
+	taskScheduler := worker.NewRedisTaskScheduler(redisOpt, worker.ScheduleConfig{
+		InterestAccrualCron:   config.InterestAccrualCron,
+		InterestPostingCron:   config.InterestPostingCron,
+		ScheduledTransferCron: config.ScheduledTransferCron,
+		HoldExpiryCron:        config.HoldExpiryCron,
+	})
+	logrus.Info("start task scheduler")
+	if err := taskScheduler.Start(); err != nil {
+		return fmt.Errorf("failed to start task scheduler: %w", err)
+	}
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		logrus.Info("graceful shutdown task scheduler")
+
+		taskScheduler.Shutdown()
+		logrus.Info("task scheduler is stopped")
+		return nil
+	})
+	return nil
+}
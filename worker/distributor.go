@@ -12,6 +12,47 @@ type TaskDistributor interface {
 		payload *PayloadSendVerifyEmail,
 		opts ...asynq.Option,
 	) error
+	DistributeTaskAccrueInterest(
+		ctx context.Context,
+		payload *PayloadAccrueInterest,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskPostInterest(
+		ctx context.Context,
+		payload *PayloadPostInterest,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskExecuteScheduledTransfers(
+		ctx context.Context,
+		payload *PayloadExecuteScheduledTransfers,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskNotifyScheduledTransferSuspended(
+		ctx context.Context,
+		payload *PayloadNotifyScheduledTransferSuspended,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskGenerateStatement(
+		ctx context.Context,
+		payload *PayloadGenerateStatement,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskExpireHolds(
+		ctx context.Context,
+		payload *PayloadExpireHolds,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskDispatchWebhookEvent(
+		ctx context.Context,
+		payload *PayloadDispatchWebhookEvent,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskDeliverWebhook(
+		ctx context.Context,
+		payload *PayloadDeliverWebhook,
+		opts ...asynq.Option,
+	) error
+	Close() error
 }
 
 type RedisTaskDistributor struct {
@@ -23,3 +64,7 @@ func NewRedisTaskDistributor(redisOpt asynq.RedisClientOpt) TaskDistributor {
 		client: asynq.NewClient(redisOpt),
 	}
 }
+
+func (rt *RedisTaskDistributor) Close() error {
+	return rt.client.Close()
+}
@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/events"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/NguyenMinhKhanhBK/simple_bank/webhook"
+	"github.com/hibiken/asynq"
+)
+
+// SubscribeEventHandlers hands the side effects of domain events to tasks, so
+// that they survive a restart and are retried. A handler fails when a task
+// cannot be enqueued, which leaves the event in the outbox of the store.
+func SubscribeEventHandlers(bus *events.Bus, distributor TaskDistributor) {
+	bus.Subscribe("send_verify_email", func(ctx context.Context, event events.Event) error {
+		return sendVerifyEmail(ctx, distributor, event.(events.UserCreated))
+	}, events.UserCreatedEvent)
+
+	bus.Subscribe("webhooks", func(ctx context.Context, event events.Event) error {
+		return emitWebhookEvent(ctx, distributor, event)
+	}, events.AccountCreatedEvent, events.TransferCompletedEvent)
+}
+
+func sendVerifyEmail(ctx context.Context, distributor TaskDistributor, event events.UserCreated) error {
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.ProcessIn(10 * time.Second),
+		asynq.Queue(QueueCritical),
+	}
+
+	err := distributor.DistributeTaskSendVerifyEmail(ctx, &PayloadSendVerifyEmail{
+		Username: event.Username,
+	}, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to distribute task to send verify email: %w", err)
+	}
+	return nil
+}
+
+func emitWebhookEvent(ctx context.Context, distributor TaskDistributor, event events.Event) error {
+	switch event := event.(type) {
+	case events.AccountCreated:
+		return EmitWebhookEvent(ctx, distributor, util.WebhookEventAccountCreated, webhook.NewAccountData(event), event.Owner)
+	case events.TransferCompleted:
+		return EmitWebhookEvent(ctx, distributor, util.WebhookEventTransferCompleted, webhook.NewTransferData(event),
+			event.FromOwner, event.ToOwner)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+)
+
+// Queues lists the queues the processor serves.
+var Queues = []string{QueueCritical, QueueDefault}
+
+// TaskInspector gives operators a look into the task queues.
+type TaskInspector interface {
+	// RequeueArchivedTasks moves tasks that ran out of retries back to be
+	// processed again, all the archived tasks of the queue when no id is
+	// given. It returns how many were requeued.
+	RequeueArchivedTasks(queue string, taskIDs ...string) (int, error)
+	Close() error
+}
+
+type RedisTaskInspector struct {
+	inspector *asynq.Inspector
+}
+
+func NewRedisTaskInspector(redisOpt asynq.RedisClientOpt) TaskInspector {
+	return &RedisTaskInspector{
+		inspector: asynq.NewInspector(redisOpt),
+	}
+}
+
+func (ri *RedisTaskInspector) RequeueArchivedTasks(queue string, taskIDs ...string) (int, error) {
+	if len(taskIDs) == 0 {
+		n, err := ri.inspector.RunAllArchivedTasks(queue)
+		// a queue only exists once a task was enqueued to it
+		if errors.Is(err, asynq.ErrQueueNotFound) {
+			return 0, nil
+		}
+		return n, err
+	}
+
+	requeued := 0
+	for _, taskID := range taskIDs {
+		if err := ri.inspector.RunTask(queue, taskID); err != nil {
+			return requeued, fmt.Errorf("failed to requeue task %s: %w", taskID, err)
+		}
+		requeued++
+	}
+	return requeued, nil
+}
+
+func (ri *RedisTaskInspector) Close() error {
+	return ri.inspector.Close()
+}
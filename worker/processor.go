@@ -2,12 +2,15 @@ package worker
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
 	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/webhook"
 	"github.com/hibiken/asynq"
 	"github.com/sirupsen/logrus"
 )
@@ -19,26 +22,66 @@ const (
 
 type TaskProcessor interface {
 	Start() error
+	Shutdown()
+	Status() error
 	ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error
+	ProcessTaskPostInterest(ctx context.Context, task *asynq.Task) error
+	ProcessTaskExecuteScheduledTransfers(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNotifyScheduledTransferSuspended(ctx context.Context, task *asynq.Task) error
+	ProcessTaskGenerateStatement(ctx context.Context, task *asynq.Task) error
+	ProcessTaskExpireHolds(ctx context.Context, task *asynq.Task) error
+	ProcessTaskDispatchWebhookEvent(ctx context.Context, task *asynq.Task) error
+	ProcessTaskDeliverWebhook(ctx context.Context, task *asynq.Task) error
+}
+
+type ProcessorConfig struct {
+	// in-flight tasks get this long to finish on shutdown before they are
+	// pushed back to Redis
+	ShutdownTimeout time.Duration
+	// timeout of a webhook delivery attempt
+	WebhookTimeout time.Duration
+	// attempts of a webhook delivery before it is dead
+	WebhookMaxAttempts int
 }
 
 type RedisTaskProcessor struct {
-	server *asynq.Server
-	store  db.Store
+	server      *asynq.Server
+	store       db.Store
+	distributor TaskDistributor
+	config      ProcessorConfig
+	// client of the webhook deliveries
+	webhookClient *http.Client
+
+	mu        sync.RWMutex
+	running   bool
+	healthErr error
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store) TaskProcessor {
+func NewRedisTaskProcessor(
+	redisOpt asynq.RedisClientOpt,
+	store db.Store,
+	distributor TaskDistributor,
+	config ProcessorConfig,
+) TaskProcessor {
 	conf := asynq.Config{
 		Queues: map[string]int{
 			QueueCritical: 6,
 			QueueDefault:  3,
 		},
+		ShutdownTimeout: config.ShutdownTimeout,
+		RetryDelayFunc:  retryDelay,
 	}
-	server := asynq.NewServer(redisOpt, conf)
-	return &RedisTaskProcessor{
-		server: server,
-		store:  store,
+	processor := &RedisTaskProcessor{
+		store:         store,
+		distributor:   distributor,
+		config:        config,
+		webhookClient: webhook.NewClient(config.WebhookTimeout),
 	}
+	conf.HealthCheckFunc = processor.setHealth
+	processor.server = asynq.NewServer(redisOpt, conf)
+
+	return processor
 }
 
 func (rp *RedisTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error {
@@ -50,7 +93,7 @@ func (rp *RedisTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Context, ta
 
 	user, err := rp.store.GetUser(ctx, payload.Username)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, db.ErrNotFound) {
 			return fmt.Errorf("user does not exist: %w", asynq.SkipRetry)
 		}
 		return fmt.Errorf("failed to get user: %w", err)
@@ -62,8 +105,59 @@ func (rp *RedisTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Context, ta
 	return nil
 }
 
+// primaryMiddleware makes the tasks read from the primary, since they are
+// enqueued right after the writes they are about, which a replica may not
+// have yet.
+func primaryMiddleware(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		return next.ProcessTask(db.WithPrimary(ctx), task)
+	})
+}
+
 func (rp *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
+	mux.Use(tracingMiddleware, primaryMiddleware)
 	mux.HandleFunc(TASK_SEND_VERIFY_EMAIL, rp.ProcessTaskSendVerifyEmail)
-	return rp.server.Start(mux)
+	mux.HandleFunc(TASK_ACCRUE_INTEREST, rp.ProcessTaskAccrueInterest)
+	mux.HandleFunc(TASK_POST_INTEREST, rp.ProcessTaskPostInterest)
+	mux.HandleFunc(TASK_EXECUTE_SCHEDULED_TRANSFERS, rp.ProcessTaskExecuteScheduledTransfers)
+	mux.HandleFunc(TASK_NOTIFY_SCHEDULED_TRANSFER_SUSPENDED, rp.ProcessTaskNotifyScheduledTransferSuspended)
+	mux.HandleFunc(TASK_GENERATE_STATEMENT, rp.ProcessTaskGenerateStatement)
+	mux.HandleFunc(TASK_EXPIRE_HOLDS, rp.ProcessTaskExpireHolds)
+	mux.HandleFunc(TASK_DISPATCH_WEBHOOK_EVENT, rp.ProcessTaskDispatchWebhookEvent)
+	mux.HandleFunc(TASK_DELIVER_WEBHOOK, rp.ProcessTaskDeliverWebhook)
+	if err := rp.server.Start(mux); err != nil {
+		return err
+	}
+
+	rp.mu.Lock()
+	rp.running = true
+	rp.mu.Unlock()
+	return nil
+}
+
+func (rp *RedisTaskProcessor) Shutdown() {
+	rp.mu.Lock()
+	rp.running = false
+	rp.mu.Unlock()
+
+	rp.server.Shutdown()
+}
+
+// Status returns nil while the processor is running and its last periodic
+// redis health check succeeded.
+func (rp *RedisTaskProcessor) Status() error {
+	rp.mu.RLock()
+	defer rp.mu.RUnlock()
+
+	if !rp.running {
+		return fmt.Errorf("task processor is not running")
+	}
+	return rp.healthErr
+}
+
+func (rp *RedisTaskProcessor) setHealth(err error) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	rp.healthErr = err
 }
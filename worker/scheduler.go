@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+)
+
+// uniqueScheduledTaskTTL keeps replicas that run the same schedule from
+// enqueueing a periodic task more than once.
+const uniqueScheduledTaskTTL = time.Hour
+
+type TaskScheduler interface {
+	Start() error
+	Shutdown()
+}
+
+type ScheduleConfig struct {
+	InterestAccrualCron   string
+	InterestPostingCron   string
+	ScheduledTransferCron string
+	HoldExpiryCron        string
+}
+
+type RedisTaskScheduler struct {
+	scheduler *asynq.Scheduler
+	config    ScheduleConfig
+}
+
+func NewRedisTaskScheduler(redisOpt asynq.RedisClientOpt, config ScheduleConfig) TaskScheduler {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Location: time.UTC,
+	})
+	return &RedisTaskScheduler{
+		scheduler: scheduler,
+		config:    config,
+	}
+}
+
+func (rs *RedisTaskScheduler) Start() error {
+	entries := []struct {
+		cron     string
+		taskType string
+	}{
+		{cron: rs.config.InterestAccrualCron, taskType: TASK_ACCRUE_INTEREST},
+		{cron: rs.config.InterestPostingCron, taskType: TASK_POST_INTEREST},
+		{cron: rs.config.ScheduledTransferCron, taskType: TASK_EXECUTE_SCHEDULED_TRANSFERS},
+		{cron: rs.config.HoldExpiryCron, taskType: TASK_EXPIRE_HOLDS},
+	}
+
+	for _, entry := range entries {
+		task := asynq.NewTask(entry.taskType, []byte("{}"),
+			asynq.Queue(QueueDefault),
+			asynq.Unique(uniqueScheduledTaskTTL),
+		)
+
+		entryID, err := rs.scheduler.Register(entry.cron, task)
+		if err != nil {
+			return fmt.Errorf("failed to register %s: %w", entry.taskType, err)
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"entry_id":  entryID,
+			"task_type": entry.taskType,
+			"cron":      entry.cron,
+		}).Info("registered periodic task")
+	}
+
+	return rs.scheduler.Start()
+}
+
+func (rs *RedisTaskScheduler) Shutdown() {
+	rs.scheduler.Shutdown()
+}
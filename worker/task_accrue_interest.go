@@ -0,0 +1,110 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TASK_ACCRUE_INTEREST = "task:accrue_interest"
+
+	dateLayout      = "2006-01-02"
+	accountPageSize = 100
+)
+
+type PayloadAccrueInterest struct {
+	TaskHeaders
+	// day to accrue interest for, formatted as YYYY-MM-DD. The scheduled task
+	// leaves it empty to accrue for the day before it runs.
+	AccrualDate string `json:"accrual_date,omitempty"`
+}
+
+func (rt *RedisTaskDistributor) DistributeTaskAccrueInterest(
+	ctx context.Context,
+	payload *PayloadAccrueInterest,
+	opts ...asynq.Option,
+) error {
+	info, err := rt.enqueueTask(ctx, TASK_ACCRUE_INTEREST, payload, opts...)
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"task_id":    info.ID,
+		"task_type":  info.Type,
+		"task_queue": info.Queue,
+	}).Info("enqueued task")
+
+	return nil
+}
+
+func (rp *RedisTaskProcessor) ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadAccrueInterest
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	accrualDate := time.Now().UTC().AddDate(0, 0, -1)
+	if payload.AccrualDate != "" {
+		var err error
+		accrualDate, err = time.Parse(dateLayout, payload.AccrualDate)
+		if err != nil {
+			return fmt.Errorf("invalid accrual date %q: %w", payload.AccrualDate, asynq.SkipRetry)
+		}
+	}
+
+	var afterID int64
+	var accrued, failed int
+	for {
+		accounts, err := rp.store.ListInterestBearingAccounts(ctx, db.ListInterestBearingAccountsParams{
+			AfterID:  afterID,
+			PageSize: accountPageSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list interest bearing accounts: %w", err)
+		}
+
+		for _, account := range accounts {
+			afterID = account.ID
+
+			result, err := rp.store.AccrueInterestTx(ctx, db.AccrueInterestTxParams{
+				AccountID:   account.ID,
+				AccrualDate: accrualDate,
+			})
+			if err != nil {
+				logrus.WithError(err).WithField("account_id", account.ID).Error("failed to accrue interest")
+				failed++
+				continue
+			}
+			if result.Accrued {
+				accrued++
+			}
+		}
+
+		if len(accounts) < accountPageSize {
+			break
+		}
+	}
+
+	logEntry := logrus.WithFields(logrus.Fields{
+		"task_type":    task.Type(),
+		"accrual_date": accrualDate.Format(dateLayout),
+		"accrued":      accrued,
+		"failed":       failed,
+	})
+
+	// accruals are idempotent per day, so a retry only picks up the failures
+	if failed > 0 {
+		logEntry.Error("failed to process task")
+		return fmt.Errorf("failed to accrue interest for %d accounts", failed)
+	}
+
+	logEntry.Info("processed task")
+	return nil
+}
@@ -0,0 +1,138 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/NguyenMinhKhanhBK/simple_bank/webhook"
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TASK_DELIVER_WEBHOOK = "task:deliver_webhook"
+)
+
+type PayloadDeliverWebhook struct {
+	TaskHeaders
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+func (rt *RedisTaskDistributor) DistributeTaskDeliverWebhook(
+	ctx context.Context,
+	payload *PayloadDeliverWebhook,
+	opts ...asynq.Option,
+) error {
+	info, err := rt.enqueueTask(ctx, TASK_DELIVER_WEBHOOK, payload, opts...)
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"task_id":        info.ID,
+		"task_type":      info.Type,
+		"task_queue":     info.Queue,
+		"task_max_retry": info.MaxRetry,
+		"delivery_id":    payload.DeliveryID,
+	}).Info("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskDeliverWebhook makes one attempt of a delivery and records it.
+// Failed attempts are retried by asynq with webhook.RetryDelay between them;
+// after the last one the delivery is dead and the task is archived. A dead
+// delivery only runs again when its archived task is requeued, which makes
+// one more attempt: the task keeps its retry count, so a failure leaves the
+// delivery dead again.
+func (rp *RedisTaskProcessor) ProcessTaskDeliverWebhook(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadDeliverWebhook
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	delivery, err := rp.store.GetWebhookDelivery(ctx, payload.DeliveryID)
+	if err != nil {
+		// deleted together with its subscription
+		if errors.Is(err, db.ErrNotFound) {
+			return fmt.Errorf("webhook delivery does not exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	if delivery.Status == util.WebhookDeliverySucceeded {
+		return nil
+	}
+
+	subscription, err := rp.store.GetWebhookSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return fmt.Errorf("webhook subscription does not exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	now := time.Now()
+	statusCode, sendErr := webhook.Send(ctx, rp.webhookClient, webhook.Delivery{
+		ID:        delivery.ID,
+		URL:       subscription.Url,
+		Secret:    subscription.Secret,
+		EventType: delivery.EventType,
+		Payload:   delivery.Payload,
+	}, now)
+
+	arg := db.UpdateWebhookDeliveryAttemptParams{
+		ID:               delivery.ID,
+		Status:           util.WebhookDeliveryPending,
+		LastResponseCode: sql.NullInt32{Int32: int32(statusCode), Valid: statusCode != 0},
+	}
+	if sendErr == nil {
+		arg.Status = util.WebhookDeliverySucceeded
+		arg.DeliveredAt = sql.NullTime{Time: now, Valid: true}
+	} else {
+		arg.LastError = sql.NullString{String: sendErr.Error(), Valid: true}
+
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+		if retried >= maxRetry {
+			arg.Status = util.WebhookDeliveryDead
+		}
+	}
+
+	delivery, err = rp.store.UpdateWebhookDeliveryAttempt(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	logEntry := logrus.WithFields(logrus.Fields{
+		"task_type":   task.Type(),
+		"delivery_id": delivery.ID,
+		"status":      delivery.Status,
+		"attempts":    delivery.Attempts,
+	})
+	if sendErr != nil {
+		logEntry.WithError(sendErr).Warn("failed to deliver webhook")
+		if delivery.Status == util.WebhookDeliveryDead {
+			return fmt.Errorf("webhook delivery is dead: %w", asynq.SkipRetry)
+		}
+		return sendErr
+	}
+
+	logEntry.Info("processed task")
+	return nil
+}
+
+// retryDelay spaces out the retries of webhook deliveries exponentially and
+// leaves the other tasks to the default of asynq.
+func retryDelay(n int, err error, task *asynq.Task) time.Duration {
+	if task.Type() == TASK_DELIVER_WEBHOOK {
+		return webhook.RetryDelay(n)
+	}
+	return asynq.DefaultRetryDelayFunc(n, err, task)
+}
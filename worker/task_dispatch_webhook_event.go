@@ -0,0 +1,129 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/webhook"
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TASK_DISPATCH_WEBHOOK_EVENT = "task:dispatch_webhook_event"
+)
+
+type PayloadDispatchWebhookEvent struct {
+	TaskHeaders
+	// user whose subscriptions receive the event
+	Owner      string          `json:"owner"`
+	EventType  string          `json:"event_type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func (rt *RedisTaskDistributor) DistributeTaskDispatchWebhookEvent(
+	ctx context.Context,
+	payload *PayloadDispatchWebhookEvent,
+	opts ...asynq.Option,
+) error {
+	info, err := rt.enqueueTask(ctx, TASK_DISPATCH_WEBHOOK_EVENT, payload, opts...)
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"task_id":    info.ID,
+		"task_type":  info.Type,
+		"task_queue": info.Queue,
+		"event_type": payload.EventType,
+	}).Info("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskDispatchWebhookEvent records a delivery of the event for every
+// subscription of the owner to its type and enqueues them.
+func (rp *RedisTaskProcessor) ProcessTaskDispatchWebhookEvent(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadDispatchWebhookEvent
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	subscriptions, err := rp.store.ListWebhookSubscriptionsForEvent(ctx, db.ListWebhookSubscriptionsForEventParams{
+		Owner:     payload.Owner,
+		EventType: payload.EventType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	body, err := json.Marshal(webhook.Event{
+		Type:       payload.EventType,
+		OccurredAt: payload.OccurredAt,
+		Data:       payload.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", asynq.SkipRetry)
+	}
+
+	for _, subscription := range subscriptions {
+		delivery, err := rp.store.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+			SubscriptionID: subscription.ID,
+			EventType:      payload.EventType,
+			Payload:        body,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create webhook delivery: %w", err)
+		}
+
+		// a delivery that could not be enqueued stays pending in the log
+		err = rp.distributor.DistributeTaskDeliverWebhook(ctx, &PayloadDeliverWebhook{
+			DeliveryID: delivery.ID,
+		}, asynq.MaxRetry(rp.config.WebhookMaxAttempts-1))
+		if err != nil {
+			logrus.WithError(err).WithField("delivery_id", delivery.ID).Error("failed to distribute task to deliver webhook")
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"task_type":     task.Type(),
+		"event_type":    payload.EventType,
+		"subscriptions": len(subscriptions),
+	}).Info("processed task")
+	return nil
+}
+
+// EmitWebhookEvent dispatches an event to the webhooks of each owner. It
+// returns the first error once it tried every owner, so that the domain event
+// is published again.
+func EmitWebhookEvent(ctx context.Context, distributor TaskDistributor, eventType string, data interface{}, owners ...string) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+
+	var firstErr error
+	now := time.Now()
+	seen := make(map[string]bool)
+	for _, owner := range owners {
+		if seen[owner] {
+			continue
+		}
+		seen[owner] = true
+
+		err := distributor.DistributeTaskDispatchWebhookEvent(ctx, &PayloadDispatchWebhookEvent{
+			Owner:      owner,
+			EventType:  eventType,
+			OccurredAt: now,
+			Data:       body,
+		}, asynq.MaxRetry(10))
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to distribute task to dispatch webhook event: %w", err)
+		}
+	}
+	return firstErr
+}
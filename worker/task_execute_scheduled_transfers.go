@@ -0,0 +1,123 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TASK_EXECUTE_SCHEDULED_TRANSFERS = "task:execute_scheduled_transfers"
+
+	// an occurrence that fails for insufficient funds is retried this long
+	// later, until it failed scheduledTransferMaxAttempts times in a row
+	scheduledTransferRetryDelay  = time.Hour
+	scheduledTransferMaxAttempts = 3
+
+	scheduledTransferPageSize = 100
+)
+
+type PayloadExecuteScheduledTransfers struct {
+	TaskHeaders
+}
+
+func (rt *RedisTaskDistributor) DistributeTaskExecuteScheduledTransfers(
+	ctx context.Context,
+	payload *PayloadExecuteScheduledTransfers,
+	opts ...asynq.Option,
+) error {
+	info, err := rt.enqueueTask(ctx, TASK_EXECUTE_SCHEDULED_TRANSFERS, payload, opts...)
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"task_id":    info.ID,
+		"task_type":  info.Type,
+		"task_queue": info.Queue,
+	}).Info("enqueued task")
+
+	return nil
+}
+
+func (rp *RedisTaskProcessor) ProcessTaskExecuteScheduledTransfers(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadExecuteScheduledTransfers
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	now := time.Now()
+
+	var afterID int64
+	var executed, declined, suspended, failed int
+	for {
+		scheduledTransfers, err := rp.store.ListDueScheduledTransfers(ctx, db.ListDueScheduledTransfersParams{
+			Now:      now,
+			AfterID:  afterID,
+			PageSize: scheduledTransferPageSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list due scheduled transfers: %w", err)
+		}
+
+		for _, scheduledTransfer := range scheduledTransfers {
+			afterID = scheduledTransfer.ID
+
+			result, err := rp.store.ExecuteScheduledTransferTx(ctx, db.ExecuteScheduledTransferTxParams{
+				ScheduledTransferID: scheduledTransfer.ID,
+				Now:                 now,
+				RetryDelay:          scheduledTransferRetryDelay,
+				MaxAttempts:         scheduledTransferMaxAttempts,
+			})
+			if err != nil {
+				logrus.WithError(err).WithField("scheduled_transfer_id", scheduledTransfer.ID).Error("failed to execute scheduled transfer")
+				failed++
+				continue
+			}
+			if !result.Executed {
+				continue
+			}
+
+			executed++
+			if result.Execution.Status == util.ExecutionSucceeded {
+				continue
+			}
+
+			declined++
+			if result.Suspended {
+				suspended++
+				rp.notifyScheduledTransferSuspended(ctx, result.ScheduledTransfer)
+			}
+		}
+
+		if len(scheduledTransfers) < scheduledTransferPageSize {
+			break
+		}
+	}
+
+	// a failed scheduled transfer stays due and is picked up again by the
+	// next run, so the task itself is not retried
+	logrus.WithFields(logrus.Fields{
+		"task_type": task.Type(),
+		"executed":  executed,
+		"declined":  declined,
+		"suspended": suspended,
+		"failed":    failed,
+	}).Info("processed task")
+	return nil
+}
+
+func (rp *RedisTaskProcessor) notifyScheduledTransferSuspended(ctx context.Context, scheduledTransfer db.ScheduledTransfer) {
+	err := rp.distributor.DistributeTaskNotifyScheduledTransferSuspended(ctx, &PayloadNotifyScheduledTransferSuspended{
+		ScheduledTransferID: scheduledTransfer.ID,
+	}, asynq.MaxRetry(10), asynq.Queue(QueueCritical))
+	if err != nil {
+		logrus.WithError(err).WithField("scheduled_transfer_id", scheduledTransfer.ID).Error("failed to distribute task to notify suspended scheduled transfer")
+	}
+}
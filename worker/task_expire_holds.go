@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TASK_EXPIRE_HOLDS = "task:expire_holds"
+
+	holdPageSize = 100
+)
+
+type PayloadExpireHolds struct {
+	TaskHeaders
+}
+
+func (rt *RedisTaskDistributor) DistributeTaskExpireHolds(
+	ctx context.Context,
+	payload *PayloadExpireHolds,
+	opts ...asynq.Option,
+) error {
+	info, err := rt.enqueueTask(ctx, TASK_EXPIRE_HOLDS, payload, opts...)
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"task_id":    info.ID,
+		"task_type":  info.Type,
+		"task_queue": info.Queue,
+	}).Info("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskExpireHolds releases the holds that are past their expiry time,
+// making their money available again.
+func (rp *RedisTaskProcessor) ProcessTaskExpireHolds(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadExpireHolds
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	now := time.Now()
+
+	var afterID int64
+	var expired, failed int
+	for {
+		holds, err := rp.store.ListExpiredHolds(ctx, db.ListExpiredHoldsParams{
+			Now:      now,
+			AfterID:  afterID,
+			PageSize: holdPageSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list expired holds: %w", err)
+		}
+
+		for _, hold := range holds {
+			afterID = hold.ID
+
+			result, err := rp.store.ExpireHoldTx(ctx, db.ExpireHoldTxParams{
+				HoldID: hold.ID,
+				Now:    now,
+			})
+			if err != nil {
+				logrus.WithError(err).WithField("hold_id", hold.ID).Error("failed to expire hold")
+				failed++
+				continue
+			}
+			if result.Expired {
+				expired++
+			}
+		}
+
+		if len(holds) < holdPageSize {
+			break
+		}
+	}
+
+	// a hold that failed to expire is still listed by the next run, so the
+	// task itself is not retried
+	logrus.WithFields(logrus.Fields{
+		"task_type": task.Type(),
+		"expired":   expired,
+		"failed":    failed,
+	}).Info("processed task")
+	return nil
+}
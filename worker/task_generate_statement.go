@@ -0,0 +1,126 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/statement"
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TASK_GENERATE_STATEMENT = "task:generate_statement"
+)
+
+type PayloadGenerateStatement struct {
+	TaskHeaders
+	StatementExportID int64 `json:"statement_export_id"`
+}
+
+func (rt *RedisTaskDistributor) DistributeTaskGenerateStatement(
+	ctx context.Context,
+	payload *PayloadGenerateStatement,
+	opts ...asynq.Option,
+) error {
+	info, err := rt.enqueueTask(ctx, TASK_GENERATE_STATEMENT, payload, opts...)
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"task_id":      info.ID,
+		"task_type":    info.Type,
+		"task_queue":   info.Queue,
+		"task_payload": string(info.Payload),
+	}).Info("enqueued task")
+
+	return nil
+}
+
+func (rp *RedisTaskProcessor) ProcessTaskGenerateStatement(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadGenerateStatement
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	export, err := rp.store.GetStatementExport(ctx, payload.StatementExportID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return fmt.Errorf("statement export does not exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get statement export: %w", err)
+	}
+
+	if export.Status != statement.ExportPending {
+		return nil
+	}
+
+	err = rp.writeStatement(ctx, export)
+	if err != nil {
+		// the export stays pending while the task is retried
+		retryCount, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+		if retryCount >= maxRetry {
+			_, updateErr := rp.store.CompleteStatementExport(ctx, db.CompleteStatementExportParams{
+				ID:     export.ID,
+				Status: statement.ExportFailed,
+				Error:  err.Error(),
+			})
+			if updateErr != nil {
+				logrus.WithError(updateErr).WithField("statement_export_id", export.ID).Error("failed to mark statement export as failed")
+			}
+		}
+		return err
+	}
+
+	_, err = rp.store.CompleteStatementExport(ctx, db.CompleteStatementExportParams{
+		ID:     export.ID,
+		Status: statement.ExportCompleted,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete statement export: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"task_type":           task.Type(),
+		"statement_export_id": export.ID,
+	}).Info("processed task")
+
+	return nil
+}
+
+// writeStatement renders the statement and saves it to the database, where
+// the gateway serves it from. The export is only completed afterwards, so a
+// partial file is never served.
+func (rp *RedisTaskProcessor) writeStatement(ctx context.Context, export db.StatementExport) error {
+	account, err := rp.store.GetAccount(ctx, export.AccountID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	var content bytes.Buffer
+	renderer, err := statement.NewRenderer(export.Format, &content)
+	if err != nil {
+		return err
+	}
+
+	err = statement.NewGenerator(rp.store).Generate(ctx, account, export.PeriodStart, export.PeriodEnd, renderer)
+	if err != nil {
+		return err
+	}
+
+	err = rp.store.SaveStatementFile(ctx, db.SaveStatementFileParams{
+		ExportID: export.ID,
+		Content:  content.Bytes(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save statement file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TASK_NOTIFY_SCHEDULED_TRANSFER_SUSPENDED = "task:notify_scheduled_transfer_suspended"
+)
+
+type PayloadNotifyScheduledTransferSuspended struct {
+	TaskHeaders
+	ScheduledTransferID int64 `json:"scheduled_transfer_id"`
+}
+
+func (rt *RedisTaskDistributor) DistributeTaskNotifyScheduledTransferSuspended(
+	ctx context.Context,
+	payload *PayloadNotifyScheduledTransferSuspended,
+	opts ...asynq.Option,
+) error {
+	info, err := rt.enqueueTask(ctx, TASK_NOTIFY_SCHEDULED_TRANSFER_SUSPENDED, payload, opts...)
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"task_id":      info.ID,
+		"task_type":    info.Type,
+		"task_queue":   info.Queue,
+		"task_payload": string(info.Payload),
+	}).Info("enqueued task")
+
+	return nil
+}
+
+func (rp *RedisTaskProcessor) ProcessTaskNotifyScheduledTransferSuspended(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadNotifyScheduledTransferSuspended
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	scheduledTransfer, err := rp.store.GetScheduledTransfer(ctx, payload.ScheduledTransferID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return fmt.Errorf("scheduled transfer does not exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get scheduled transfer: %w", err)
+	}
+
+	user, err := rp.store.GetUser(ctx, scheduledTransfer.Owner)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// TODO: send email to user
+	logrus.WithFields(logrus.Fields{
+		"task_type":             task.Type(),
+		"user":                  user.Username,
+		"scheduled_transfer_id": scheduledTransfer.ID,
+		"failed_attempts":       scheduledTransfer.FailedAttempts,
+	}).Info("processed task")
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	db "github.com/NguyenMinhKhanhBK/simple_bank/db/sqlc"
+	"github.com/NguyenMinhKhanhBK/simple_bank/util"
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TASK_POST_INTEREST = "task:post_interest"
+
+	monthLayout = "2006-01"
+)
+
+type PayloadPostInterest struct {
+	TaskHeaders
+	// month to post interest for, formatted as YYYY-MM. The scheduled task
+	// leaves it empty to post for the month before it runs.
+	Period string `json:"period,omitempty"`
+}
+
+func (rt *RedisTaskDistributor) DistributeTaskPostInterest(
+	ctx context.Context,
+	payload *PayloadPostInterest,
+	opts ...asynq.Option,
+) error {
+	info, err := rt.enqueueTask(ctx, TASK_POST_INTEREST, payload, opts...)
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"task_id":    info.ID,
+		"task_type":  info.Type,
+		"task_queue": info.Queue,
+	}).Info("enqueued task")
+
+	return nil
+}
+
+func (rp *RedisTaskProcessor) ProcessTaskPostInterest(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadPostInterest
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	now := time.Now().UTC()
+	period := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	if payload.Period != "" {
+		var err error
+		period, err = time.Parse(monthLayout, payload.Period)
+		if err != nil {
+			return fmt.Errorf("invalid period %q: %w", payload.Period, asynq.SkipRetry)
+		}
+	}
+
+	var afterID int64
+	var posted, failed int
+	for {
+		accounts, err := rp.store.ListAccountsWithAccruedInterest(ctx, db.ListAccountsWithAccruedInterestParams{
+			MinAccruedInterest: util.InterestScale,
+			AfterID:            afterID,
+			PageSize:           accountPageSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list accounts with accrued interest: %w", err)
+		}
+
+		for _, account := range accounts {
+			afterID = account.ID
+
+			result, err := rp.store.PostInterestTx(ctx, db.PostInterestTxParams{
+				AccountID: account.ID,
+				Period:    period,
+			})
+			if err != nil {
+				logrus.WithError(err).WithField("account_id", account.ID).Error("failed to post interest")
+				failed++
+				continue
+			}
+			if result.Posted {
+				posted++
+			}
+		}
+
+		if len(accounts) < accountPageSize {
+			break
+		}
+	}
+
+	logEntry := logrus.WithFields(logrus.Fields{
+		"task_type": task.Type(),
+		"period":    period.Format(monthLayout),
+		"posted":    posted,
+		"failed":    failed,
+	})
+
+	// postings are idempotent per month, so a retry only picks up the failures
+	if failed > 0 {
+		logEntry.Error("failed to process task")
+		return fmt.Errorf("failed to post interest for %d accounts", failed)
+	}
+
+	logEntry.Info("processed task")
+	return nil
+}
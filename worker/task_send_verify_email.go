@@ -2,14 +2,13 @@ package worker
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 
 	"github.com/hibiken/asynq"
 	"github.com/sirupsen/logrus"
 )
 
 type PayloadSendVerifyEmail struct {
+	TaskHeaders
 	Username string `json:"username"`
 }
 
@@ -22,15 +21,9 @@ func (rt *RedisTaskDistributor) DistributeTaskSendVerifyEmail(
 	payload *PayloadSendVerifyEmail,
 	opts ...asynq.Option,
 ) error {
-	jsPayload, err := json.Marshal(payload)
+	info, err := rt.enqueueTask(ctx, TASK_SEND_VERIFY_EMAIL, payload, opts...)
 	if err != nil {
-		return fmt.Errorf("failed to marshal payload: %w", err)
-	}
-
-	task := asynq.NewTask(TASK_SEND_VERIFY_EMAIL, jsPayload, opts...)
-	info, err := rt.client.EnqueueContext(ctx, task)
-	if err != nil {
-		return fmt.Errorf("failed to enqueue task: %w", err)
+		return err
 	}
 
 	logrus.WithFields(logrus.Fields{
@@ -0,0 +1,102 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/NguyenMinhKhanhBK/simple_bank/tracing"
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TaskHeaders carries metadata such as the trace context alongside a task
+// payload. asynq has no native task headers, so every payload embeds it.
+type TaskHeaders struct {
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+func (h *TaskHeaders) injectTraceContext(ctx context.Context) {
+	h.Headers = make(map[string]string)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(h.Headers))
+}
+
+type tracedPayload interface {
+	injectTraceContext(ctx context.Context)
+}
+
+// enqueueTask marshals the payload together with the current trace context and
+// enqueues it under a producer span.
+func (rt *RedisTaskDistributor) enqueueTask(
+	ctx context.Context,
+	taskType string,
+	payload tracedPayload,
+	opts ...asynq.Option,
+) (*asynq.TaskInfo, error) {
+	ctx, span := tracing.Tracer().Start(ctx, taskType+" enqueue",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.String("task.type", taskType)),
+	)
+	defer span.End()
+
+	payload.injectTraceContext(ctx)
+	jsPayload, err := json.Marshal(payload)
+	if err != nil {
+		recordTaskError(span, err)
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(taskType, jsPayload, opts...)
+	info, err := rt.client.EnqueueContext(ctx, task)
+	if err != nil {
+		recordTaskError(span, err)
+		return nil, fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.String("task.id", info.ID),
+		attribute.String("task.queue", info.Queue),
+	)
+	return info, nil
+}
+
+// tracingMiddleware restores the trace context from the task headers and
+// wraps the handler in a consumer span.
+func tracingMiddleware(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		var headers TaskHeaders
+		// payloads enqueued before tracing was added have no headers
+		_ = json.Unmarshal(task.Payload(), &headers)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(headers.Headers))
+
+		attrs := []attribute.KeyValue{attribute.String("task.type", task.Type())}
+		if taskID, ok := asynq.GetTaskID(ctx); ok {
+			attrs = append(attrs, attribute.String("task.id", taskID))
+		}
+		if retryCount, ok := asynq.GetRetryCount(ctx); ok {
+			attrs = append(attrs, attribute.Int("task.retry_count", retryCount))
+		}
+
+		ctx, span := tracing.Tracer().Start(ctx, task.Type()+" process",
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(attrs...),
+		)
+		defer span.End()
+
+		err := next.ProcessTask(ctx, task)
+		recordTaskError(span, err)
+		return err
+	})
+}
+
+func recordTaskError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}